@@ -43,6 +43,17 @@ true
 {{- end }}
 {{- end }}
 
+{{/*
+The name of the service account of the controller manager, suffixed with the release name if appendReleaseSuffix is true.
+*/}}
+{{- define "controller-manager.serviceAccount" -}}
+{{- if eq .Values.appendReleaseSuffix true -}}
+{{ .Values.controllerManager.serviceAccount }}-{{ .Release.Name }}
+{{- else -}}
+{{ .Values.controllerManager.serviceAccount }}
+{{- end -}}
+{{- end -}}
+
 {{- define "helm-toolkit.utils.template" -}}
 {{- $name := index . 0 -}}
 {{- $context := index . 1 -}}
@@ -16,6 +16,7 @@ extenders:
   - urlPrefix: http://127.0.0.1:10262/scheduler
     filterVerb: filter
     preemptVerb: preempt
+    prioritizeVerb: prioritize
     weight: 1
     enableHTTPS: false
     httpTimeout: 30s
@@ -16,6 +16,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"strings"
@@ -23,18 +24,30 @@ import (
 
 	"github.com/openshift/generic-admission-server/pkg/cmd"
 	"github.com/pingcap/tidb-operator/pkg/features"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/version"
+	"github.com/pingcap/tidb-operator/pkg/webhook/conversion"
+	"github.com/pingcap/tidb-operator/pkg/webhook/deletion"
 	"github.com/pingcap/tidb-operator/pkg/webhook/pod"
 	"github.com/pingcap/tidb-operator/pkg/webhook/statefulset"
 	"github.com/pingcap/tidb-operator/pkg/webhook/strategy"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/rest"
 	"k8s.io/component-base/logs"
+	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/klog"
 )
 
 var (
-	printVersion         bool
-	extraServiceAccounts string
-	minResyncDuration    time.Duration
+	printVersion                    bool
+	extraServiceAccounts            string
+	controllerManagerServiceAccount string
+	minResyncDuration               time.Duration
+	conversionPort                  int
+	conversionCertFile              string
+	conversionKeyFile               string
+	conversionCAFile                string
+	conversionService               string
 )
 
 func init() {
@@ -42,6 +55,12 @@ func init() {
 	flag.BoolVar(&printVersion, "V", false, "Show version and quit")
 	flag.BoolVar(&printVersion, "version", false, "Show version and quit")
 	flag.StringVar(&extraServiceAccounts, "extraServiceAccounts", "", "comma-separated, extra Service Accounts the Webhook should control. The full pattern for each common service account is system:serviceaccount:<namespace>:<serviceaccount-name>")
+	flag.StringVar(&controllerManagerServiceAccount, "controllerManagerServiceAccount", "tidb-controller-manager", "The Service Account of tidb-controller-manager in the same namespace, which is allowed to delete the StatefulSets and PVCs of the TidbClusters with deletion protection")
+	flag.IntVar(&conversionPort, "conversionPort", 0, "The port to serve the conversion webhook of the CustomResourceDefinitions on, 0 means the conversion webhook is disabled")
+	flag.StringVar(&conversionCertFile, "conversionCertFile", "", "The x509 certificate file to serve the conversion webhook with")
+	flag.StringVar(&conversionKeyFile, "conversionKeyFile", "", "The x509 private key file matching --conversionCertFile")
+	flag.StringVar(&conversionCAFile, "conversionCAFile", "", "The CA file to verify the conversion webhook with, it's set to the CustomResourceDefinitions converted by the webhook")
+	flag.StringVar(&conversionService, "conversionService", "tidb-admission-webhook", "The Service serving the conversion webhook in the same namespace")
 	flag.DurationVar(&minResyncDuration, "min-resync-duration", 12*time.Hour, "The resync period in reflectors will be random between MinResyncPeriod and 2*MinResyncPeriod.")
 	features.DefaultFeatureGate.AddFlag(flag.CommandLine)
 }
@@ -75,6 +94,29 @@ func main() {
 	podAdmissionHook := pod.NewPodAdmissionControl(strings.Split(extraServiceAccounts, ","), resyncDuration)
 	statefulSetAdmissionHook := statefulset.NewStatefulSetAdmissionControl()
 	strategyAdmissionHook := strategy.NewStrategyAdmissionHook(&strategy.Registry)
+	deletionProtectionAdmissionHook := deletion.NewDeletionProtectionAdmissionControl([]string{
+		fmt.Sprintf("system:serviceaccount:%s:%s", ns, controllerManagerServiceAccount),
+	})
+
+	// the metrics endpoint of the admission server serves the metrics in the legacy registry
+	legacyregistry.RawMustRegister(metrics.WebhookAdmissionTotal)
+
+	if conversionPort > 0 {
+		caBundle, err := ioutil.ReadFile(conversionCAFile)
+		if err != nil {
+			klog.Fatalf("failed to read the CA of the conversion webhook, error: %v", err)
+		}
+		cfg, err := rest.InClusterConfig()
+		if err != nil {
+			klog.Fatalf("failed to get config: %v", err)
+		}
+		if err := conversion.EnableConversion(apiextensionsclientset.NewForConfigOrDie(cfg), ns, conversionService, int32(conversionPort), caBundle); err != nil {
+			klog.Fatalf("failed to enable the conversion webhook of the CustomResourceDefinitions, error: %v", err)
+		}
+		go func() {
+			klog.Fatal(conversion.ListenAndServeTLS(conversionPort, conversionCertFile, conversionKeyFile))
+		}()
+	}
 
-	cmd.RunAdmissionServer(podAdmissionHook, statefulSetAdmissionHook, strategyAdmissionHook)
+	cmd.RunAdmissionServer(podAdmissionHook, statefulSetAdmissionHook, strategyAdmissionHook, deletionProtectionAdmissionHook)
 }
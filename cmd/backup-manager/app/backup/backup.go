@@ -36,7 +36,7 @@ type Options struct {
 }
 
 // backupData generates br args and runs br binary to do the real backup work
-func (bo *Options) backupData(ctx context.Context, backup *v1alpha1.Backup) error {
+func (bo *Options) backupData(ctx context.Context, backup *v1alpha1.Backup, tracker *backupUtil.ProgressTracker) error {
 	clusterNamespace := backup.Spec.BR.ClusterNamespace
 	if backup.Spec.BR.ClusterNamespace == "" {
 		clusterNamespace = backup.Namespace
@@ -89,6 +89,7 @@ func (bo *Options) backupData(ctx context.Context, backup *v1alpha1.Backup) erro
 		if strings.Contains(line, "[ERROR]") {
 			errMsg += line
 		}
+		tracker.Observe(line)
 
 		klog.Info(strings.Replace(line, "\n", "", -1))
 		if err != nil || io.EOF == err {
@@ -99,6 +100,7 @@ func (bo *Options) backupData(ctx context.Context, backup *v1alpha1.Backup) erro
 	if len(tmpErr) > 0 {
 		klog.Info(string(tmpErr))
 		errMsg += string(tmpErr)
+		tracker.ObserveError(string(tmpErr))
 	}
 	err = cmd.Wait()
 	if err != nil {
@@ -125,6 +127,9 @@ func constructOptions(backup *v1alpha1.Backup) ([]string, error) {
 	if config.TimeAgo != "" {
 		args = append(args, fmt.Sprintf("--timeago=%s", config.TimeAgo))
 	}
+	if config.LastBackupTS != "" {
+		args = append(args, fmt.Sprintf("--lastbackupts=%s", config.LastBackupTS))
+	}
 	if config.Checksum != nil {
 		args = append(args, fmt.Sprintf("--checksum=%t", *config.Checksum))
 	}
@@ -260,8 +260,13 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 		return err
 	}
 
-	// run br binary to do the real job
-	backupErr := bm.backupData(ctx, backup)
+	// run br binary to do the real job and report its progress in status
+	tracker := util.NewProgressTracker(constants.ProgressUpdateInterval, func(progress *v1alpha1.BRProgress) {
+		if err := bm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{Progress: progress}); err != nil {
+			klog.Warningf("update progress of backup %s for cluster %s failed, err: %v", bm.ResourceName, bm, err)
+		}
+	})
+	backupErr := bm.backupData(ctx, backup, tracker)
 
 	if db != nil && oldTikvGCTimeDuration < tikvGCTimeDuration {
 		// use another context to revert `tikv_gc_life_time` back.
@@ -290,12 +295,16 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 	if backupErr != nil {
 		errs = append(errs, backupErr)
 		klog.Errorf("backup cluster %s data failed, err: %s", bm, backupErr)
+		lastError := tracker.LastError()
 		uerr := bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
 			Type:    v1alpha1.BackupFailed,
 			Status:  corev1.ConditionTrue,
 			Reason:  "BackupDataToRemoteFailed",
 			Message: backupErr.Error(),
-		}, nil)
+		}, &controller.BackupUpdateStatus{
+			Progress:  tracker.Progress(),
+			LastError: &lastError,
+		})
 		errs = append(errs, uerr)
 		return errorutils.NewAggregate(errs)
 	}
@@ -330,6 +339,7 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 		BackupSize:         &backupSize,
 		BackupSizeReadable: &backupSizeReadable,
 		CommitTs:           &ts,
+		Progress:           tracker.Progress(),
 	}
 	return bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
 		Type:   v1alpha1.BackupComplete,
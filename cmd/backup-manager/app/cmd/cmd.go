@@ -34,6 +34,8 @@ func NewBackupMgrCommand() *cobra.Command {
 	cmds.AddCommand(NewRestoreCommand())
 	cmds.AddCommand(NewImportCommand())
 	cmds.AddCommand(NewCleanCommand())
+	cmds.AddCommand(NewVerifyCommand())
+	cmds.AddCommand(NewShipLogCommand())
 	return cmds
 }
 
@@ -61,6 +61,9 @@ func runRestore(restoreOpts restore.Options, kubecfg string) error {
 	informerFactory := informers.NewSharedInformerFactoryWithOptions(cli, constants.ResyncDuration, options...)
 	recorder := util.NewEventRecorder(kubeCli, "restore")
 	restoreInformer := informerFactory.Pingcap().V1alpha1().Restores()
+	// the backups are listed to resolve the backup chain to restore
+	backupInformer := informerFactory.Pingcap().V1alpha1().Backups()
+	backupLister := backupInformer.Lister()
 	statusUpdater := controller.NewRealRestoreConditionUpdater(cli, restoreInformer.Lister(), recorder)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -68,9 +71,9 @@ func runRestore(restoreOpts restore.Options, kubecfg string) error {
 	go informerFactory.Start(ctx.Done())
 
 	// waiting for the shared informer's store has synced.
-	cache.WaitForCacheSync(ctx.Done(), restoreInformer.Informer().HasSynced)
+	cache.WaitForCacheSync(ctx.Done(), restoreInformer.Informer().HasSynced, backupInformer.Informer().HasSynced)
 
 	klog.Infof("start to process restore %s", restoreOpts.String())
-	rm := restore.NewManager(restoreInformer.Lister(), statusUpdater, restoreOpts)
+	rm := restore.NewManager(restoreInformer.Lister(), backupLister, statusUpdater, restoreOpts)
 	return rm.ProcessRestore()
 }
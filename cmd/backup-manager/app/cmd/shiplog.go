@@ -0,0 +1,64 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/shiplog"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/klog"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// NewShipLogCommand implements the ship-log command
+func NewShipLogCommand() *cobra.Command {
+	so := shiplog.Options{}
+
+	cmd := &cobra.Command{
+		Use:   "ship-log",
+		Short: "Ship the change log written by Drainer to the storage for point-in-time recovery.",
+		Run: func(cmd *cobra.Command, args []string) {
+			util.ValidCmdFlags(cmd.CommandPath(), cmd.LocalFlags())
+			cmdutil.CheckErr(runShipLog(so))
+		},
+	}
+
+	cmd.Flags().StringVar(&so.Dir, "dir", "/data/pb", "The directory Drainer writes the binlog files to, i.e. `syncer.to.dir` of Drainer")
+	cmd.Flags().StringVar(&so.Savepoint, "savepoint", "/data/savepoint", "The savepoint file Drainer writes to its data dir")
+	cmd.Flags().StringVar(&so.Storage, "storage", "", "The JSON of the storage provider the change log is shipped to, e.g. {\"s3\":{\"provider\":\"aws\",\"bucket\":\"backup\",\"prefix\":\"binlog\"}}")
+	cmd.Flags().DurationVar(&so.Interval, "interval", 10*time.Second, "The interval between two rounds of shipping")
+	return cmd
+}
+
+func runShipLog(so shiplog.Options) error {
+	var provider v1alpha1.StorageProvider
+	if err := json.Unmarshal([]byte(so.Storage), &provider); err != nil {
+		return fmt.Errorf("parse storage %s failed, err: %v", so.Storage, err)
+	}
+
+	ctx, cancel := util.GetContextForTerminationSignals("ship-log")
+	defer cancel()
+
+	shipper, err := shiplog.NewShipper(so.Dir, so.Savepoint, provider)
+	if err != nil {
+		return err
+	}
+	klog.Infof("start to ship the change log in %s every %s", so.Dir, so.Interval)
+	return shipper.Run(ctx, so.Interval)
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/verify"
+	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// NewVerifyCommand implements the verify command
+func NewVerifyCommand() *cobra.Command {
+	vo := verify.Options{}
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify specific tidb cluster backup offline.",
+		Run: func(cmd *cobra.Command, args []string) {
+			util.ValidCmdFlags(cmd.CommandPath(), cmd.LocalFlags())
+			cmdutil.CheckErr(runVerify(vo, kubecfg))
+		},
+	}
+
+	cmd.Flags().StringVar(&vo.Namespace, "namespace", "", "Backup CR's namespace")
+	cmd.Flags().StringVar(&vo.ResourceName, "backupName", "", "Backup CRD object name")
+	cmd.Flags().StringVar(&vo.RequestTime, "requestTime", "", "RFC3339 time of the verification request")
+	return cmd
+}
+
+func runVerify(verifyOpts verify.Options, kubecfg string) error {
+	kubeCli, cli, err := util.NewKubeAndCRCli(kubecfg)
+	if err != nil {
+		return err
+	}
+	options := []informers.SharedInformerOption{
+		informers.WithNamespace(verifyOpts.Namespace),
+	}
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(cli, constants.ResyncDuration, options...)
+	recorder := util.NewEventRecorder(kubeCli, "backup")
+	backupInformer := informerFactory.Pingcap().V1alpha1().Backups()
+	statusUpdater := controller.NewRealBackupConditionUpdater(cli, backupInformer.Lister(), recorder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go informerFactory.Start(ctx.Done())
+
+	// waiting for the shared informer's store has synced.
+	cache.WaitForCacheSync(ctx.Done(), backupInformer.Informer().HasSynced)
+
+	klog.Infof("start to verify backup %s", verifyOpts.String())
+	vm := verify.NewManager(backupInformer.Lister(), statusUpdater, verifyOpts)
+	return vm.ProcessVerify()
+}
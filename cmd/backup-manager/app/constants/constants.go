@@ -22,6 +22,9 @@ const (
 	// PollInterval is the interval to check if the tidb cluster is ready
 	PollInterval = 5 * time.Second
 
+	// ProgressUpdateInterval is the minimal interval between two updates of the BR progress in status
+	ProgressUpdateInterval = 30 * time.Second
+
 	// CheckTimeout is the maximum time to wait for the tidb cluster ready
 	CheckTimeout = 30 * time.Minute
 
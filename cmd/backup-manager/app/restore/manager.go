@@ -27,7 +27,9 @@ import (
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog"
@@ -35,6 +37,7 @@ import (
 
 type Manager struct {
 	restoreLister listers.RestoreLister
+	backupLister  listers.BackupLister
 	StatusUpdater controller.RestoreConditionUpdaterInterface
 	Options
 }
@@ -42,10 +45,12 @@ type Manager struct {
 // NewManager return a RestoreManager
 func NewManager(
 	restoreLister listers.RestoreLister,
+	backupLister listers.BackupLister,
 	statusUpdater controller.RestoreConditionUpdaterInterface,
 	restoreOpts Options) *Manager {
 	return &Manager{
 		restoreLister,
+		backupLister,
 		statusUpdater,
 		restoreOpts,
 	}
@@ -148,7 +153,23 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 
 	var errs []error
 
-	commitTs, err := util.GetCommitTsFromBRMetaData(ctx, restore.Spec.StorageProvider)
+	chain, err := rm.getBackupChain(restore)
+	if err != nil {
+		errs = append(errs, err)
+		klog.Errorf("get cluster %s backup chain failed, err: %s", rm, err)
+		uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+			Type:    v1alpha1.RestoreFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "GetBackupChainFailed",
+			Message: err.Error(),
+		}, nil)
+		errs = append(errs, uerr)
+		return errorutils.NewAggregate(errs)
+	}
+
+	// the cluster is restored to the commit ts of the last incremental backup if any
+	storages := getStorageProviders(restore, chain)
+	commitTs, err := util.GetCommitTsFromBRMetaData(ctx, storages[len(storages)-1])
 	if err != nil {
 		errs = append(errs, err)
 		klog.Errorf("get cluster %s commitTs failed, err: %s", rm, err)
@@ -162,6 +183,27 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 		return errorutils.NewAggregate(errs)
 	}
 
+	// the change log can only be replayed on top of a backup taken before the target
+	var pitrTargetTs uint64
+	if restore.Spec.Pitr != nil {
+		pitrTargetTs, err = restore.Spec.Pitr.GetRestoredTSO(time.Local)
+		if err == nil && commitTs > pitrTargetTs {
+			err = fmt.Errorf("the backup is taken at %d, after the target %d of the point-in-time recovery", commitTs, pitrTargetTs)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			klog.Errorf("cluster %s check point-in-time recovery target failed, err: %s", rm, err)
+			uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "InvalidPitrTarget",
+				Message: err.Error(),
+			}, nil)
+			errs = append(errs, uerr)
+			return errorutils.NewAggregate(errs)
+		}
+	}
+
 	var (
 		oldTikvGCTime, tikvGCLifeTime             string
 		oldTikvGCTimeDuration, tikvGCTimeDuration time.Duration
@@ -248,7 +290,13 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 		}
 	}
 
-	restoreErr := rm.restoreData(ctx, restore)
+	// run br binary to do the real job and report its progress in status
+	tracker := util.NewProgressTracker(constants.ProgressUpdateInterval, func(progress *v1alpha1.BRProgress) {
+		if err := rm.StatusUpdater.Update(restore, nil, &controller.RestoreUpdateStatus{Progress: progress}); err != nil {
+			klog.Warningf("update progress of restore %s for cluster %s failed, err: %v", rm.ResourceName, rm, err)
+		}
+	})
+	restoreErr := rm.restoreData(ctx, restore, storages, tracker)
 
 	if db != nil && oldTikvGCTimeDuration < tikvGCTimeDuration {
 		// use another context to revert `tikv_gc_life_time` back.
@@ -277,26 +325,141 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 	if restoreErr != nil {
 		errs = append(errs, restoreErr)
 		klog.Errorf("restore cluster %s from %s failed, err: %s", rm, restore.Spec.Type, restoreErr)
+		lastError := tracker.LastError()
 		uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
 			Type:    v1alpha1.RestoreFailed,
 			Status:  corev1.ConditionTrue,
 			Reason:  "RestoreDataFromRemoteFailed",
 			Message: restoreErr.Error(),
-		}, nil)
+		}, &controller.RestoreUpdateStatus{
+			Progress:  tracker.Progress(),
+			LastError: &lastError,
+		})
 		errs = append(errs, uerr)
 		return errorutils.NewAggregate(errs)
 	}
 	klog.Infof("restore cluster %s from %s succeed", rm, restore.Spec.Type)
 
+	var pitrRestoredTs *string
+	if restore.Spec.Pitr != nil {
+		restoredTs, err := rm.replayChangeLog(ctx, restore, commitTs, pitrTargetTs)
+		if err != nil {
+			errs = append(errs, err)
+			klog.Errorf("replay change log for cluster %s failed, err: %s", rm, err)
+			uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "ReplayChangeLogFailed",
+				Message: err.Error(),
+			}, nil)
+			errs = append(errs, uerr)
+			return errorutils.NewAggregate(errs)
+		}
+		if restoredTs < pitrTargetTs {
+			klog.Warningf("the change log of cluster %s stops at %d before the target %d", rm, restoredTs, pitrTargetTs)
+		}
+		pts := strconv.FormatUint(restoredTs, 10)
+		pitrRestoredTs = &pts
+	}
+
 	finish := time.Now()
 	ts := strconv.FormatUint(commitTs, 10)
 	updateStatus := &controller.RestoreUpdateStatus{
-		TimeStarted:   &metav1.Time{Time: started},
-		TimeCompleted: &metav1.Time{Time: finish},
-		CommitTs:      &ts,
+		TimeStarted:    &metav1.Time{Time: started},
+		TimeCompleted:  &metav1.Time{Time: finish},
+		CommitTs:       &ts,
+		Progress:       tracker.Progress(),
+		PitrRestoredTs: pitrRestoredTs,
 	}
 	return rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
 		Type:   v1alpha1.RestoreComplete,
 		Status: corev1.ConditionTrue,
 	}, updateStatus)
 }
+
+// getBackupChain returns the full backup followed by the incremental backups chained from it in order,
+// the last one is the backup to restore. The nearest backup before the target of the point-in-time recovery
+// is picked if the restore does not name one. It returns nil if the restore does not refer to a backup.
+func (rm *Manager) getBackupChain(restore *v1alpha1.Restore) ([]*v1alpha1.Backup, error) {
+	lister := rm.backupLister.Backups(restore.Namespace)
+	if restore.Spec.BackupName != "" {
+		return resolveBackupChain(lister, restore.Spec.BackupName)
+	}
+	if restore.Spec.Pitr == nil {
+		return nil, nil
+	}
+
+	targetTs, err := restore.Spec.Pitr.GetRestoredTSO(time.Local)
+	if err != nil {
+		return nil, err
+	}
+	backups, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	nearest := getNearestBackup(backups, restore.Spec.StorageProvider, targetTs)
+	if nearest == nil {
+		return nil, fmt.Errorf("no completed BR backup in the storage is taken before the target %d", targetTs)
+	}
+	klog.Infof("restore backup %s taken at %s for cluster %s, the nearest one before the target %d", nearest.Name, nearest.Status.CommitTs, rm, targetTs)
+	return resolveBackupChain(lister, nearest.Name)
+}
+
+// getNearestBackup returns the completed BR backup in the same bucket as the storage taken last before the target
+func getNearestBackup(backups []*v1alpha1.Backup, provider v1alpha1.StorageProvider, targetTs uint64) *v1alpha1.Backup {
+	var nearest *v1alpha1.Backup
+	var nearestTs uint64
+	for _, backup := range backups {
+		if backup.Spec.BR == nil || backup.DeletionTimestamp != nil || !v1alpha1.IsBackupComplete(backup) {
+			continue
+		}
+		if !isSameBucket(backup.Spec.StorageProvider, provider) {
+			continue
+		}
+		commitTs, err := strconv.ParseUint(backup.Status.CommitTs, 10, 64)
+		if err != nil || commitTs > targetTs {
+			continue
+		}
+		if nearest == nil || commitTs > nearestTs {
+			nearest, nearestTs = backup, commitTs
+		}
+	}
+	return nearest
+}
+
+// isSameBucket returns whether the objects of the two storages are in the same bucket regardless of the prefixes
+func isSameBucket(a, b v1alpha1.StorageProvider) bool {
+	switch {
+	case a.S3 != nil && b.S3 != nil:
+		return a.S3.Provider == b.S3.Provider && a.S3.Endpoint == b.S3.Endpoint && a.S3.Bucket == b.S3.Bucket
+	case a.Gcs != nil && b.Gcs != nil:
+		return a.Gcs.Bucket == b.Gcs.Bucket
+	case a.Azblob != nil && b.Azblob != nil:
+		return a.Azblob.StorageAccount == b.Azblob.StorageAccount && a.Azblob.Container == b.Azblob.Container
+	case a.Local != nil && b.Local != nil:
+		return apiequality.Semantic.DeepEqual(a.Local.Volume, b.Local.Volume)
+	}
+	return false
+}
+
+// resolveBackupChain follows the parent backups recorded in status from the named backup to the full backup
+func resolveBackupChain(lister listers.BackupNamespaceLister, name string) ([]*v1alpha1.Backup, error) {
+	var chain []*v1alpha1.Backup
+	visited := map[string]bool{}
+	for name != "" {
+		if visited[name] {
+			return nil, fmt.Errorf("backup %s is chained from itself", name)
+		}
+		visited[name] = true
+		backup, err := lister.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("get backup %s failed, err: %v", name, err)
+		}
+		if backup.Spec.BR == nil || !v1alpha1.IsBackupComplete(backup) {
+			return nil, fmt.Errorf("backup %s is not a completed BR backup", name)
+		}
+		chain = append([]*v1alpha1.Backup{backup}, chain...)
+		name = backup.Status.ParentBackup
+	}
+	return chain, nil
+}
@@ -0,0 +1,325 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/shiplog"
+	backupUtil "github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"k8s.io/klog"
+)
+
+const (
+	// pitrListPageSize is the number of objects listed at a time when downloading the change log
+	pitrListPageSize = 1000
+	// binlogFileMagic is the magic number leading each record of the binlog files written by Drainer
+	binlogFileMagic uint32 = 471532804
+	// binlogHeaderSize is the size of the magic number and the payload size leading each record
+	binlogHeaderSize = 12
+	// binlogPayloadPeekSize is the size of the payload read to find the commit ts of the first record,
+	// the commit ts follows the binlog type at the beginning of the payload
+	binlogPayloadPeekSize = 32
+	// binlogCommitTsField is the field number of the commit ts in the binlog payload
+	binlogCommitTsField = 2
+)
+
+// drainerSavepoint is the content of the savepoint file of Drainer
+type drainerSavepoint struct {
+	CommitTS uint64 `toml:"commitTS"`
+}
+
+// reparoConfig is the config of Reparo to replay the change log to tidb,
+// the password of tidb is passed to Reparo through the environment instead.
+type reparoConfig struct {
+	DataDir  string       `toml:"data-dir"`
+	LogLevel string       `toml:"log-level"`
+	DestType string       `toml:"dest-type"`
+	StartTSO uint64       `toml:"start-tso"`
+	StopTSO  uint64       `toml:"stop-tso"`
+	DestDB   reparoDestDB `toml:"dest-db"`
+}
+
+type reparoDestDB struct {
+	Host string `toml:"host"`
+	Port int32  `toml:"port"`
+	User string `toml:"user"`
+}
+
+// changeLogFile is a binlog file of the change log and the commit ts of its first record
+type changeLogFile struct {
+	key     string
+	firstTs uint64
+}
+
+// replayChangeLog downloads the change log after the restored backup and replays it up to the
+// target of the point-in-time recovery, it returns the TSO the cluster is recovered to.
+func (ro *Options) replayChangeLog(ctx context.Context, restore *v1alpha1.Restore, commitTs, targetTs uint64) (uint64, error) {
+	storage := storageWithPrefix(restore.Spec.StorageProvider, restore.Spec.Pitr.LogPrefix)
+	logDir := path.Join(util.PitrLogPath, "data")
+	savepoint, err := downloadChangeLog(ctx, storage, logDir, commitTs, targetTs)
+	if err != nil {
+		return 0, fmt.Errorf("cluster %s, download change log failed, err: %v", ro, err)
+	}
+	restoredTs := getPitrRestoredTs(commitTs, targetTs, savepoint)
+	if restoredTs == commitTs {
+		klog.Warningf("the change log of cluster %s reaches %d, no change after the restored backup at %d", ro, savepoint, commitTs)
+		return restoredTs, nil
+	}
+
+	conf := &reparoConfig{
+		DataDir:  logDir,
+		LogLevel: "info",
+		DestType: "mysql",
+		StartTSO: commitTs + 1,
+		StopTSO:  restoredTs,
+		DestDB: reparoDestDB{
+			Host: ro.Host,
+			Port: ro.Port,
+			User: ro.User,
+		},
+	}
+	confFile := path.Join(util.PitrLogPath, "reparo.toml")
+	f, err := os.Create(confFile)
+	if err != nil {
+		return 0, fmt.Errorf("cluster %s, create reparo config failed, err: %v", ro, err)
+	}
+	err = toml.NewEncoder(f).Encode(conf)
+	f.Close()
+	if err != nil {
+		return 0, fmt.Errorf("cluster %s, write reparo config failed, err: %v", ro, err)
+	}
+
+	if err := ro.runReparo(ctx, confFile); err != nil {
+		return 0, err
+	}
+	klog.Infof("Replay change log for cluster %s to %d successfully", ro, restoredTs)
+	return restoredTs, nil
+}
+
+// downloadChangeLog copies the binlog files which may hold the changes after commitTs up to targetTs
+// to the local directory, it returns the commit ts recorded in the savepoint of the change log.
+func downloadChangeLog(ctx context.Context, provider v1alpha1.StorageProvider, dir string, commitTs, targetTs uint64) (uint64, error) {
+	s, err := backupUtil.NewStorageBackend(provider)
+	if err != nil {
+		return 0, err
+	}
+	defer s.Close()
+
+	// the savepoint is read before listing the binlog files, which are shipped before it
+	exist, err := s.Exists(ctx, shiplog.SavepointFile)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return 0, fmt.Errorf("no %s in the change log, can't tell how far the change log reaches", shiplog.SavepointFile)
+	}
+	data, err := s.ReadAll(ctx, shiplog.SavepointFile)
+	if err != nil {
+		return 0, fmt.Errorf("read %s failed, err: %v", shiplog.SavepointFile, err)
+	}
+	savepoint, err := parseSavepoint(data)
+	if err != nil {
+		return 0, err
+	}
+
+	var files []changeLogFile
+	iter := s.ListPage(nil)
+	for {
+		objs, err := iter.Next(ctx, pitrListPageSize)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		for _, obj := range objs {
+			if obj.IsDir || obj.Key == shiplog.SavepointFile || obj.Size == 0 {
+				continue
+			}
+			firstTs, err := readFirstCommitTs(ctx, s, obj.Key)
+			if err != nil {
+				return 0, err
+			}
+			files = append(files, changeLogFile{key: obj.Key, firstTs: firstTs})
+		}
+	}
+
+	selected, err := selectChangeLogFiles(files, commitTs, targetTs)
+	if err != nil {
+		return 0, err
+	}
+	for _, file := range selected {
+		if err := downloadObject(ctx, s, file.key, filepath.Join(dir, filepath.FromSlash(file.key))); err != nil {
+			return 0, err
+		}
+	}
+	return savepoint, nil
+}
+
+// selectChangeLogFiles returns the binlog files which may hold the changes after commitTs up to targetTs.
+// Drainer writes the changes in the order of the commit ts, so a binlog file holds the changes from the
+// commit ts of its first record until the one of the next binlog file. It fails if the change log starts
+// after commitTs, the changes in between are missing and can't be replayed.
+func selectChangeLogFiles(files []changeLogFile, commitTs, targetTs uint64) ([]changeLogFile, error) {
+	sort.Slice(files, func(i, j int) bool { return files[i].key < files[j].key })
+	if len(files) > 0 && files[0].firstTs > commitTs+1 {
+		return nil, fmt.Errorf("the change log starts at %d, the changes after the restored backup at %d are missing", files[0].firstTs, commitTs)
+	}
+	var selected []changeLogFile
+	for i, file := range files {
+		if file.firstTs > targetTs {
+			break
+		}
+		if i+1 < len(files) && files[i+1].firstTs <= commitTs {
+			continue
+		}
+		selected = append(selected, file)
+	}
+	return selected, nil
+}
+
+// readFirstCommitTs reads the commit ts of the first record of the binlog file in the storage
+func readFirstCommitTs(ctx context.Context, s *backupUtil.StorageBackend, key string) (uint64, error) {
+	r, err := s.NewRangeReader(ctx, key, 0, binlogHeaderSize+binlogPayloadPeekSize, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return 0, fmt.Errorf("read %s failed, err: %v", key, err)
+	}
+	ts, err := parseFirstCommitTs(data)
+	if err != nil {
+		return 0, fmt.Errorf("binlog file %s, %v", key, err)
+	}
+	return ts, nil
+}
+
+// parseFirstCommitTs parses the commit ts from the beginning of a binlog file, a record of the binlog
+// file is the magic number, the payload size, the payload and the checksum, the payload is a binlog
+// in protobuf.
+func parseFirstCommitTs(data []byte) (uint64, error) {
+	if len(data) < binlogHeaderSize || binary.LittleEndian.Uint32(data) != binlogFileMagic {
+		return 0, fmt.Errorf("not a binlog file")
+	}
+	payload := data[binlogHeaderSize:]
+	if size := binary.LittleEndian.Uint64(data[4:binlogHeaderSize]); size < uint64(len(payload)) {
+		payload = payload[:size]
+	}
+	for len(payload) > 0 {
+		key, n := binary.Uvarint(payload)
+		if n <= 0 {
+			break
+		}
+		payload = payload[n:]
+		field, wireType := key>>3, key&7
+		var skip int
+		switch wireType {
+		case 0:
+			value, m := binary.Uvarint(payload)
+			if m <= 0 {
+				return 0, fmt.Errorf("no commit ts in the first record")
+			}
+			if field == binlogCommitTsField {
+				return value, nil
+			}
+			skip = m
+		case 1:
+			skip = 8
+		case 2:
+			size, m := binary.Uvarint(payload)
+			if m <= 0 || size > uint64(len(payload)) {
+				return 0, fmt.Errorf("no commit ts in the first record")
+			}
+			skip = m + int(size)
+		case 5:
+			skip = 4
+		default:
+			return 0, fmt.Errorf("unknown wire type %d in the first record", wireType)
+		}
+		if skip > len(payload) {
+			break
+		}
+		payload = payload[skip:]
+	}
+	return 0, fmt.Errorf("no commit ts in the first record")
+}
+
+func downloadObject(ctx context.Context, s *backupUtil.StorageBackend, key, file string) error {
+	if err := backupUtil.EnsureDirectoryExist(filepath.Dir(file)); err != nil {
+		return err
+	}
+	r, err := s.NewReader(ctx, key, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("download %s failed, err: %v", key, err)
+	}
+	return nil
+}
+
+// parseSavepoint returns the commit ts recorded in the savepoint file of Drainer
+func parseSavepoint(data []byte) (uint64, error) {
+	sp := &drainerSavepoint{}
+	if _, err := toml.Decode(string(data), sp); err != nil {
+		return 0, fmt.Errorf("parse %s failed, err: %v", shiplog.SavepointFile, err)
+	}
+	if sp.CommitTS == 0 {
+		return 0, fmt.Errorf("no commitTS in %s", shiplog.SavepointFile)
+	}
+	return sp.CommitTS, nil
+}
+
+// getPitrRestoredTs returns the TSO the cluster is recovered to, the change log can be replayed up to
+// the target unless it stops before, in which case the cluster is recovered to where the log reaches.
+func getPitrRestoredTs(commitTs, targetTs, savepoint uint64) uint64 {
+	if savepoint >= targetTs {
+		return targetTs
+	}
+	if savepoint < commitTs {
+		return commitTs
+	}
+	return savepoint
+}
+
+func (ro *Options) runReparo(ctx context.Context, confFile string) error {
+	args := []string{fmt.Sprintf("--config=%s", confFile)}
+	klog.Infof("Running reparo command with args: %v", args)
+	bin := path.Join(util.ReparoBinPath, "reparo")
+	cmd := exec.CommandContext(ctx, bin, args...)
+	// keep the password out of the config file
+	cmd.Env = append(os.Environ(), fmt.Sprintf("MYSQL_PWD=%s", ro.Password))
+	return ro.runCommand(cmd, nil)
+}
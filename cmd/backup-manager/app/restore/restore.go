@@ -34,7 +34,71 @@ type Options struct {
 	backupUtil.GenericOptions
 }
 
-func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore) error {
+// restoreData restores the full backup and then the incremental backups in order
+func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore, storages []v1alpha1.StorageProvider, tracker *backupUtil.ProgressTracker) error {
+	for i, storage := range storages {
+		if len(storages) > 1 {
+			klog.Infof("Restore backup %d/%d for cluster %s", i+1, len(storages), ro)
+		}
+		r := restore.DeepCopy()
+		r.Spec.StorageProvider = storage
+		if err := ro.restoreBackup(ctx, r, tracker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getStorageProviders returns the storage of the full backup followed by the storages of the incremental backups,
+// the incremental backups are read from the same storage as the full backup with their own prefixes.
+// The prefixes of the backup chain are used instead of the ones in spec if the restore refers to a backup.
+func getStorageProviders(restore *v1alpha1.Restore, chain []*v1alpha1.Backup) []v1alpha1.StorageProvider {
+	if len(chain) == 0 {
+		storages := []v1alpha1.StorageProvider{restore.Spec.StorageProvider}
+		for _, prefix := range restore.Spec.IncrementalPrefixes {
+			storages = append(storages, storageWithPrefix(restore.Spec.StorageProvider, prefix))
+		}
+		return storages
+	}
+	var storages []v1alpha1.StorageProvider
+	for _, backup := range chain {
+		storages = append(storages, storageWithPrefix(restore.Spec.StorageProvider, getStoragePrefix(backup.Spec.StorageProvider)))
+	}
+	return storages
+}
+
+// getStoragePrefix returns the prefix of the objects in the storage
+func getStoragePrefix(provider v1alpha1.StorageProvider) string {
+	switch {
+	case provider.S3 != nil:
+		return provider.S3.Prefix
+	case provider.Gcs != nil:
+		return provider.Gcs.Prefix
+	case provider.Azblob != nil:
+		return provider.Azblob.Prefix
+	case provider.Local != nil:
+		return provider.Local.Prefix
+	}
+	return ""
+}
+
+// storageWithPrefix returns a copy of the storage which reads the objects under another prefix
+func storageWithPrefix(provider v1alpha1.StorageProvider, prefix string) v1alpha1.StorageProvider {
+	storage := provider.DeepCopy()
+	switch {
+	case storage.S3 != nil:
+		storage.S3.Prefix = prefix
+	case storage.Gcs != nil:
+		storage.Gcs.Prefix = prefix
+	case storage.Azblob != nil:
+		storage.Azblob.Prefix = prefix
+	case storage.Local != nil:
+		storage.Local.Prefix = prefix
+	}
+	return *storage
+}
+
+func (ro *Options) restoreBackup(ctx context.Context, restore *v1alpha1.Restore, tracker *backupUtil.ProgressTracker) error {
 	clusterNamespace := restore.Spec.BR.ClusterNamespace
 	if restore.Spec.BR.ClusterNamespace == "" {
 		clusterNamespace = restore.Namespace
@@ -67,7 +131,17 @@ func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore) e
 	klog.Infof("Running br command with args: %v", fullArgs)
 	bin := path.Join(util.BRBinPath, "br")
 	cmd := exec.CommandContext(ctx, bin, fullArgs...)
+	if err := ro.runCommand(cmd, tracker); err != nil {
+		return err
+	}
+	klog.Infof("Restore data for cluster %s successfully", ro)
+	return nil
+}
 
+// runCommand runs the command and logs its output, the error lines printed are returned in the error
+// if it fails. The output is observed by the tracker if any.
+func (ro *Options) runCommand(cmd *exec.Cmd, tracker *backupUtil.ProgressTracker) error {
+	name := path.Base(cmd.Path)
 	stdOut, err := cmd.StdoutPipe()
 	if err != nil {
 		return fmt.Errorf("cluster %s, create stdout pipe failed, err: %v", ro, err)
@@ -78,7 +152,7 @@ func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore) e
 	}
 	err = cmd.Start()
 	if err != nil {
-		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", ro, fullArgs, err)
+		return fmt.Errorf("cluster %s, execute %s command failed, args: %s, err: %v", ro, name, cmd.Args[1:], err)
 	}
 	var errMsg string
 	reader := bufio.NewReader(stdOut)
@@ -87,6 +161,9 @@ func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore) e
 		if strings.Contains(line, "[ERROR]") {
 			errMsg += line
 		}
+		if tracker != nil {
+			tracker.Observe(line)
+		}
 		klog.Info(strings.Replace(line, "\n", "", -1))
 		if err != nil || io.EOF == err {
 			break
@@ -96,13 +173,15 @@ func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore) e
 	if len(tmpErr) > 0 {
 		klog.Info(string(tmpErr))
 		errMsg += string(tmpErr)
+		if tracker != nil {
+			tracker.ObserveError(string(tmpErr))
+		}
 	}
 
 	err = cmd.Wait()
 	if err != nil {
 		return fmt.Errorf("cluster %s, wait pipe message failed, errMsg %s, err: %v", ro, errMsg, err)
 	}
-	klog.Infof("Restore data for cluster %s successfully", ro)
 	return nil
 }
 
@@ -0,0 +1,249 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/shiplog"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestGetStorageProviders(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	restore := &v1alpha1.Restore{}
+	restore.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket", Prefix: "full"}
+	storages := getStorageProviders(restore, nil)
+	g.Expect(storages).To(HaveLen(1))
+	g.Expect(storages[0].S3.Prefix).To(Equal("full"))
+
+	restore.Spec.IncrementalPrefixes = []string{"incr-1", "incr-2"}
+	storages = getStorageProviders(restore, nil)
+	g.Expect(storages).To(HaveLen(3))
+	for i, prefix := range []string{"full", "incr-1", "incr-2"} {
+		g.Expect(storages[i].S3.Bucket).To(Equal("bucket"))
+		g.Expect(storages[i].S3.Prefix).To(Equal(prefix))
+	}
+	g.Expect(restore.Spec.S3.Prefix).To(Equal("full"))
+
+	// the prefixes of the backup chain are used instead of the ones in spec
+	var chain []*v1alpha1.Backup
+	for _, prefix := range []string{"chain-full", "chain-incr"} {
+		backup := &v1alpha1.Backup{}
+		backup.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket", Prefix: prefix}
+		chain = append(chain, backup)
+	}
+	storages = getStorageProviders(restore, chain)
+	g.Expect(storages).To(HaveLen(2))
+	for i, prefix := range []string{"chain-full", "chain-incr"} {
+		g.Expect(storages[i].S3.Bucket).To(Equal("bucket"))
+		g.Expect(storages[i].S3.Prefix).To(Equal(prefix))
+	}
+}
+
+func TestResolveBackupChain(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := listers.NewBackupLister(indexer).Backups(corev1.NamespaceDefault)
+	addBackup := func(name, parent string, complete bool) {
+		backup := &v1alpha1.Backup{}
+		backup.Namespace = corev1.NamespaceDefault
+		backup.Name = name
+		backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "demo"}
+		backup.Status.ParentBackup = parent
+		if complete {
+			backup.Status.Conditions = []v1alpha1.BackupCondition{{Type: v1alpha1.BackupComplete, Status: corev1.ConditionTrue}}
+		}
+		indexer.Add(backup)
+	}
+	addBackup("full", "", true)
+	addBackup("incr-1", "full", true)
+	addBackup("incr-2", "incr-1", true)
+	addBackup("running", "incr-2", false)
+	addBackup("orphan", "gone", true)
+	addBackup("loop", "loop", true)
+
+	chain, err := resolveBackupChain(lister, "incr-2")
+	g.Expect(err).NotTo(HaveOccurred())
+	var names []string
+	for _, backup := range chain {
+		names = append(names, backup.Name)
+	}
+	g.Expect(names).To(Equal([]string{"full", "incr-1", "incr-2"}))
+
+	chain, err = resolveBackupChain(lister, "full")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(chain).To(HaveLen(1))
+
+	for _, name := range []string{"running", "orphan", "loop"} {
+		_, err = resolveBackupChain(lister, name)
+		g.Expect(err).To(HaveOccurred())
+	}
+}
+
+func TestParseSavepoint(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ts, err := parseSavepoint([]byte("commitTS = 421762809912885249\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ts).To(Equal(uint64(421762809912885249)))
+
+	_, err = parseSavepoint([]byte("commitTS = \"invalid\"\n"))
+	g.Expect(err).To(HaveOccurred())
+	_, err = parseSavepoint([]byte(""))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGetPitrRestoredTs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(getPitrRestoredTs(100, 200, 300)).To(Equal(uint64(200)))
+	g.Expect(getPitrRestoredTs(100, 200, 150)).To(Equal(uint64(150)))
+	g.Expect(getPitrRestoredTs(100, 200, 50)).To(Equal(uint64(100)))
+}
+
+// newBinlogFile returns a binlog file of Drainer with one record committed at ts
+func newBinlogFile(ts uint64) []byte {
+	// the binlog type, the commit ts and an empty DML
+	payload := []byte{0x08, 0x00, 0x10}
+	buf := make([]byte, binary.MaxVarintLen64)
+	payload = append(payload, buf[:binary.PutUvarint(buf, ts)]...)
+	payload = append(payload, 0x1a, 0x00)
+	data := make([]byte, binlogHeaderSize, binlogHeaderSize+len(payload)+4)
+	binary.LittleEndian.PutUint32(data, binlogFileMagic)
+	binary.LittleEndian.PutUint64(data[4:], uint64(len(payload)))
+	data = append(data, payload...)
+	return append(data, 0, 0, 0, 0)
+}
+
+func TestParseFirstCommitTs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ts, err := parseFirstCommitTs(newBinlogFile(421762809912885249))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ts).To(Equal(uint64(421762809912885249)))
+
+	// the commit ts is found after the other fields
+	data := newBinlogFile(421762809912885249)
+	data = append(data[:binlogHeaderSize], append([]byte{0x1a, 0x01, 0x00}, data[binlogHeaderSize:]...)...)
+	binary.LittleEndian.PutUint64(data[4:], binary.LittleEndian.Uint64(data[4:])+3)
+	ts, err = parseFirstCommitTs(data)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ts).To(Equal(uint64(421762809912885249)))
+
+	_, err = parseFirstCommitTs([]byte("commitTS = 421762809912885249\n"))
+	g.Expect(err).To(HaveOccurred())
+	_, err = parseFirstCommitTs(newBinlogFile(1)[:binlogHeaderSize+2])
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestSelectChangeLogFiles(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	files := []changeLogFile{
+		{key: "binlog-0000000000000002", firstTs: 300},
+		{key: "binlog-0000000000000000", firstTs: 100},
+		{key: "binlog-0000000000000001", firstTs: 200},
+		{key: "binlog-0000000000000003", firstTs: 400},
+	}
+	keys := func(commitTs, targetTs uint64) []string {
+		selected, err := selectChangeLogFiles(files, commitTs, targetTs)
+		g.Expect(err).NotTo(HaveOccurred())
+		var keys []string
+		for _, file := range selected {
+			keys = append(keys, file.key)
+		}
+		return keys
+	}
+	g.Expect(keys(250, 350)).To(Equal([]string{"binlog-0000000000000001", "binlog-0000000000000002"}))
+	g.Expect(keys(200, 300)).To(Equal([]string{"binlog-0000000000000001", "binlog-0000000000000002"}))
+	g.Expect(keys(99, 150)).To(Equal([]string{"binlog-0000000000000000"}))
+	g.Expect(keys(450, 500)).To(Equal([]string{"binlog-0000000000000003"}))
+
+	// the change log starting after the restored backup leaves a hole
+	_, err := selectChangeLogFiles(files, 50, 150)
+	g.Expect(err).To(HaveOccurred())
+	_, err = selectChangeLogFiles(files, 10, 50)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestDownloadChangeLog(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := ioutil.TempDir("", "pitr")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+	logDir := filepath.Join(dir, "storage", "binlog")
+	g.Expect(os.MkdirAll(logDir, 0755)).To(Succeed())
+	provider := v1alpha1.StorageProvider{Local: &v1alpha1.LocalStorageProvider{
+		VolumeMount: corev1.VolumeMount{MountPath: filepath.Join(dir, "storage")},
+		Prefix:      "binlog",
+	}}
+	for i, ts := range []uint64{100, 200, 300} {
+		g.Expect(ioutil.WriteFile(filepath.Join(logDir, fmt.Sprintf("binlog-000000000000000%d", i)), newBinlogFile(ts), 0644)).To(Succeed())
+	}
+
+	// the savepoint is required to tell how far the change log reaches
+	_, err = downloadChangeLog(context.TODO(), provider, filepath.Join(dir, "data"), 250, 280)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(ioutil.WriteFile(filepath.Join(logDir, shiplog.SavepointFile), []byte("commitTS = 350\n"), 0644)).To(Succeed())
+	savepoint, err := downloadChangeLog(context.TODO(), provider, filepath.Join(dir, "data"), 250, 280)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(savepoint).To(Equal(uint64(350)))
+	files, err := ioutil.ReadDir(filepath.Join(dir, "data"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(files).To(HaveLen(1))
+	g.Expect(files[0].Name()).To(Equal("binlog-0000000000000001"))
+}
+
+func TestGetNearestBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newBackup := func(name, bucket, commitTs string, complete bool) *v1alpha1.Backup {
+		backup := &v1alpha1.Backup{}
+		backup.Name = name
+		backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "demo"}
+		backup.Spec.S3 = &v1alpha1.S3StorageProvider{Provider: v1alpha1.S3StorageProviderTypeAWS, Bucket: bucket, Prefix: name}
+		backup.Status.CommitTs = commitTs
+		if complete {
+			backup.Status.Conditions = []v1alpha1.BackupCondition{{Type: v1alpha1.BackupComplete, Status: corev1.ConditionTrue}}
+		}
+		return backup
+	}
+	backups := []*v1alpha1.Backup{
+		newBackup("old", "bucket", "100", true),
+		newBackup("nearest", "bucket", "200", true),
+		newBackup("running", "bucket", "", false),
+		newBackup("other-bucket", "other", "250", true),
+		newBackup("after", "bucket", "400", true),
+	}
+	provider := v1alpha1.StorageProvider{S3: &v1alpha1.S3StorageProvider{Provider: v1alpha1.S3StorageProviderTypeAWS, Bucket: "bucket", Prefix: "binlog"}}
+
+	g.Expect(getNearestBackup(backups, provider, 300).Name).To(Equal("nearest"))
+	g.Expect(getNearestBackup(backups, provider, 150).Name).To(Equal("old"))
+	g.Expect(getNearestBackup(backups, provider, 50)).To(BeNil())
+}
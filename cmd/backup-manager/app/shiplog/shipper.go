@@ -0,0 +1,154 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shiplog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"k8s.io/klog"
+)
+
+// SavepointFile is the name of the savepoint file Drainer writes to its data dir and the change log is
+// shipped with, it records the commit ts the change log reaches.
+const SavepointFile = "savepoint"
+
+// Options contains the input arguments to the ship-log command
+type Options struct {
+	// Dir is the directory Drainer writes the binlog files to, i.e. `syncer.to.dir`
+	Dir string
+	// Savepoint is the savepoint file Drainer writes to its data dir
+	Savepoint string
+	// Storage is the JSON of the storage provider the change log is shipped to
+	Storage string
+	// Interval is the interval between two rounds of shipping
+	Interval time.Duration
+}
+
+// fileState is the state of a local file when it is shipped
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// Shipper uploads the change log written by Drainer with the `file` dest type to the storage continuously,
+// so that Restore can recover a cluster to a point in time by replaying it after a backup.
+type Shipper struct {
+	dir       string
+	savepoint string
+	storage   *util.StorageBackend
+	// shipped records the state of the binlog files which have been uploaded
+	shipped map[string]fileState
+}
+
+// NewShipper returns a Shipper uploading the binlog files in dir and the savepoint file to the storage
+func NewShipper(dir, savepoint string, provider v1alpha1.StorageProvider) (*Shipper, error) {
+	s, err := util.NewStorageBackend(provider)
+	if err != nil {
+		return nil, err
+	}
+	return &Shipper{
+		dir:       dir,
+		savepoint: savepoint,
+		storage:   s,
+		shipped:   map[string]fileState{},
+	}, nil
+}
+
+// Run ships the change log every interval until the context is done, the change log is shipped
+// once more before it returns so that the last changes flushed by Drainer are not lost.
+func (s *Shipper) Run(ctx context.Context, interval time.Duration) error {
+	defer s.storage.Close()
+	for {
+		if err := s.Ship(ctx); err != nil && ctx.Err() == nil {
+			klog.Errorf("ship change log in %s failed, err: %v", s.dir, err)
+		}
+		select {
+		case <-ctx.Done():
+			// `DefaultTerminationGracePeriodSeconds` for a pod is 30, so we use a smaller timeout value here.
+			ctx2, cancel2 := context.WithTimeout(context.Background(), 25*time.Second)
+			defer cancel2()
+			return s.Ship(ctx2)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Ship uploads the binlog files changed since they were shipped and then the savepoint, the binlog file Drainer
+// is writing is uploaded again whenever it grows. The savepoint is read before the binlog files, Drainer saves it
+// after the binlog files are flushed, so the savepoint in the storage never claims changes not shipped yet.
+func (s *Shipper) Ship(ctx context.Context) error {
+	savepoint, err := ioutil.ReadFile(s.savepoint)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read %s failed, err: %v", s.savepoint, err)
+	}
+
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("read dir %s failed, err: %v", s.dir, err)
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || filepath.Join(s.dir, name) == filepath.Clean(s.savepoint) {
+			continue
+		}
+		state := fileState{size: file.Size(), modTime: file.ModTime()}
+		if shipped, ok := s.shipped[name]; ok && shipped.size == state.size && shipped.modTime.Equal(state.modTime) {
+			continue
+		}
+		if err := s.upload(ctx, name, state.size); err != nil {
+			return err
+		}
+		s.shipped[name] = state
+		klog.V(4).Infof("ship binlog file %s of %d bytes", name, state.size)
+	}
+
+	if savepoint == nil {
+		return nil
+	}
+	if err := s.storage.WriteAll(ctx, SavepointFile, savepoint, nil); err != nil {
+		return fmt.Errorf("upload %s failed, err: %v", SavepointFile, err)
+	}
+	return nil
+}
+
+// upload copies the first size bytes of the file to the storage, the bytes Drainer appends meanwhile
+// are left to the next round
+func (s *Shipper) upload(ctx context.Context, name string, size int64) error {
+	f, err := os.Open(filepath.Join(s.dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w, err := s.storage.NewWriter(ctx, name, nil)
+	if err != nil {
+		return fmt.Errorf("upload %s failed, err: %v", name, err)
+	}
+	if _, err := io.Copy(w, io.LimitReader(f, size)); err != nil {
+		w.Close()
+		return fmt.Errorf("upload %s failed, err: %v", name, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("upload %s failed, err: %v", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shiplog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestShip(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := ioutil.TempDir("", "shiplog")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+	// the layout of the Drainer data dir with the chart defaults: the binlog files are in /data/pb
+	// and the savepoint is /data/savepoint
+	dataDir := filepath.Join(dir, "data")
+	logDir := filepath.Join(dataDir, "pb")
+	savepoint := filepath.Join(dataDir, SavepointFile)
+	storageDir := filepath.Join(dir, "storage", "binlog")
+	g.Expect(os.MkdirAll(logDir, 0755)).To(Succeed())
+	g.Expect(os.MkdirAll(storageDir, 0755)).To(Succeed())
+
+	shipper, err := NewShipper(logDir, savepoint, v1alpha1.StorageProvider{Local: &v1alpha1.LocalStorageProvider{
+		VolumeMount: corev1.VolumeMount{MountPath: filepath.Join(dir, "storage")},
+		Prefix:      "binlog",
+	}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	expectShipped := func(name, content string) {
+		data, err := ioutil.ReadFile(filepath.Join(storageDir, name))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(string(data)).To(Equal(content))
+	}
+
+	// the savepoint is shipped only after Drainer writes it
+	g.Expect(ioutil.WriteFile(filepath.Join(logDir, "binlog-0000000000000000"), []byte("first"), 0644)).To(Succeed())
+	g.Expect(shipper.Ship(context.TODO())).To(Succeed())
+	expectShipped("binlog-0000000000000000", "first")
+	_, err = os.Stat(filepath.Join(storageDir, SavepointFile))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	// the growing binlog file is shipped again along with the new one
+	g.Expect(ioutil.WriteFile(filepath.Join(logDir, "binlog-0000000000000000"), []byte("first-grown"), 0644)).To(Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(logDir, "binlog-0000000000000001"), []byte("second"), 0644)).To(Succeed())
+	g.Expect(ioutil.WriteFile(savepoint, []byte("commitTS = 100\n"), 0644)).To(Succeed())
+	g.Expect(shipper.Ship(context.TODO())).To(Succeed())
+	expectShipped("binlog-0000000000000000", "first-grown")
+	expectShipped("binlog-0000000000000001", "second")
+	expectShipped(SavepointFile, "commitTS = 100\n")
+
+	// the binlog files not changed are not shipped again
+	g.Expect(os.Remove(filepath.Join(storageDir, "binlog-0000000000000000"))).To(Succeed())
+	g.Expect(shipper.Ship(context.TODO())).To(Succeed())
+	_, err = os.Stat(filepath.Join(storageDir, "binlog-0000000000000000"))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	// the change log is shipped once more when it stops
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	g.Expect(ioutil.WriteFile(savepoint, []byte("commitTS = 200\n"), 0644)).To(Succeed())
+	g.Expect(shipper.Run(ctx, time.Hour)).To(Succeed())
+	expectShipped(SavepointFile, "commitTS = 200\n")
+}
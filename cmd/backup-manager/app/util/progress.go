@@ -0,0 +1,176 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// brLogFieldRegex matches the `[key=value]` and `[key="value"]` fields of a BR log line
+	brLogFieldRegex = regexp.MustCompile(`\[([\w\-().]+)=("(?:[^"\\]|\\.)*"|[^\]]*)\]`)
+	// brLogMessageRegex matches the message following the level and the source of a BR log line
+	brLogMessageRegex = regexp.MustCompile(`\[(?:INFO|WARN)\] \[[^\]]*\] \[("(?:[^"\\]|\\.)*"|[^\]=]*)\]`)
+)
+
+// parseBRLogFields returns the message and the fields of a BR log line
+func parseBRLogFields(line string) (string, map[string]string) {
+	var msg string
+	if m := brLogMessageRegex.FindStringSubmatch(line); m != nil {
+		msg = unquoteBRLogValue(m[1])
+	}
+	fields := map[string]string{}
+	for _, m := range brLogFieldRegex.FindAllStringSubmatch(line, -1) {
+		fields[m[1]] = unquoteBRLogValue(m[2])
+	}
+	return msg, fields
+}
+
+func unquoteBRLogValue(v string) string {
+	if s, err := strconv.Unquote(v); err == nil {
+		return s
+	}
+	return v
+}
+
+// ParseBRProgress parses a line printed by BR and updates the progress,
+// it returns false if the line carries no progress.
+//
+// BR prints the progress of a step as
+//
+//	[INFO] [progress.go:51] ["Full backup"] [progress=45.00%] [count="9 / 20"] [speed="1 p/s"] [elapsed=9s] [remaining=11s]
+//
+// older versions print the step as a field
+//
+//	[INFO] [progress.go:42] [progress] [step="Full backup"] [progress=45.00%] [count="9 / 20"] ...
+//
+// and the summary of a finished step carries the data size as
+//
+//	[INFO] [collector.go:60] ["Full backup success summary"] ... [total-kv-size=10.5MB] ...
+func ParseBRProgress(line string, progress *v1alpha1.BRProgress, now time.Time) bool {
+	msg, fields := parseBRLogFields(line)
+	if size, ok := fields["total-kv-size"]; ok {
+		bytes, err := humanize.ParseBytes(size)
+		if err != nil {
+			return false
+		}
+		progress.ProcessedBytes = int64(bytes)
+		return true
+	}
+
+	percentage, ok := fields["progress"]
+	if !ok || !strings.HasSuffix(percentage, "%") {
+		return false
+	}
+	step := fields["step"]
+	if step == "" {
+		step = msg
+	}
+	progress.Step = step
+	progress.Percentage = percentage
+	if count, ok := fields["count"]; ok {
+		parts := strings.SplitN(count, "/", 2)
+		if len(parts) == 2 {
+			completed, err1 := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+			total, err2 := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+			if err1 == nil && err2 == nil {
+				progress.Completed = completed
+				progress.Total = total
+			}
+		}
+	}
+	progress.ETA = nil
+	if remaining, ok := fields["remaining"]; ok {
+		if d, err := time.ParseDuration(remaining); err == nil {
+			eta := metav1.NewTime(now.Add(d).Truncate(time.Second))
+			progress.ETA = &eta
+		}
+	}
+	return true
+}
+
+// ProgressTracker follows the output of BR, it reports the progress at most once per
+// interval unless the step changes, and keeps the last error line.
+type ProgressTracker struct {
+	interval time.Duration
+	report   func(*v1alpha1.BRProgress)
+	now      func() time.Time
+
+	progress     v1alpha1.BRProgress
+	lastReported time.Time
+	reportedStep string
+	lastError    string
+}
+
+// NewProgressTracker returns a ProgressTracker which passes the progress to report
+func NewProgressTracker(interval time.Duration, report func(*v1alpha1.BRProgress)) *ProgressTracker {
+	return &ProgressTracker{
+		interval: interval,
+		report:   report,
+		now:      time.Now,
+	}
+}
+
+// Observe handles a line of the standard output of BR
+func (t *ProgressTracker) Observe(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	if strings.Contains(line, "[ERROR]") || strings.Contains(line, "[FATAL]") {
+		t.lastError = line
+		return
+	}
+
+	now := t.now()
+	if !ParseBRProgress(line, &t.progress, now) {
+		return
+	}
+	if t.progress.Step == t.reportedStep && now.Sub(t.lastReported) < t.interval {
+		return
+	}
+	t.lastReported = now
+	t.reportedStep = t.progress.Step
+	t.report(t.Progress())
+}
+
+// ObserveError handles the standard error output of BR, its last line is kept
+// as the last error unless BR has printed an error log
+func (t *ProgressTracker) ObserveError(output string) {
+	if t.lastError != "" {
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	t.lastError = strings.TrimSpace(lines[len(lines)-1])
+}
+
+// Progress returns a copy of the latest progress
+func (t *ProgressTracker) Progress() *v1alpha1.BRProgress {
+	progress := t.progress.DeepCopy()
+	lastUpdateTime := metav1.NewTime(t.now())
+	progress.LastUpdateTime = &lastUpdateTime
+	return progress
+}
+
+// LastError returns the last error line printed by BR
+func (t *ProgressTracker) LastError() string {
+	return t.lastError
+}
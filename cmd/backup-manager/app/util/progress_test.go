@@ -0,0 +1,119 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseBRProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	eta := metav1.NewTime(now.Add(105 * time.Second))
+
+	tests := []struct {
+		name     string
+		line     string
+		ok       bool
+		expected v1alpha1.BRProgress
+	}{
+		{
+			name: "progress with the step as message",
+			line: `[2021/06/01 12:00:00.000 +00:00] [INFO] [progress.go:51] ["Full backup"] [progress=12.50%] [count="5 / 40"] [speed="0.3 p/s"] [elapsed=15s] [remaining=1m45s]`,
+			ok:   true,
+			expected: v1alpha1.BRProgress{
+				Step:       "Full backup",
+				Percentage: "12.50%",
+				Completed:  5,
+				Total:      40,
+				ETA:        &eta,
+			},
+		},
+		{
+			name: "progress with the step as field",
+			line: `[2021/06/01 12:00:00.000 +00:00] [INFO] [progress.go:42] [progress] [step="Full restore"] [progress=100.00%] [count="40 / 40"] [elapsed=1m] [remaining=0s]`,
+			ok:   true,
+			expected: v1alpha1.BRProgress{
+				Step:       "Full restore",
+				Percentage: "100.00%",
+				Completed:  40,
+				Total:      40,
+				ETA:        &metav1.Time{Time: now},
+			},
+		},
+		{
+			name: "summary with the data size",
+			line: `[2021/06/01 12:00:00.000 +00:00] [INFO] [collector.go:60] ["Full backup success summary"] [total-ranges=40] [total-kv=1000] [total-kv-size=10MB]`,
+			ok:   true,
+			expected: v1alpha1.BRProgress{
+				ProcessedBytes: 10000000,
+			},
+		},
+		{
+			name: "other log",
+			line: `[2021/06/01 12:00:00.000 +00:00] [INFO] [client.go:100] ["backup started"] [startKey=] [endKey=]`,
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := v1alpha1.BRProgress{}
+			g.Expect(ParseBRProgress(tt.line, &progress, now)).To(Equal(tt.ok))
+			g.Expect(progress).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestProgressTracker(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var reported []*v1alpha1.BRProgress
+	tracker := NewProgressTracker(30*time.Second, func(progress *v1alpha1.BRProgress) {
+		reported = append(reported, progress)
+	})
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	progressLine := func(step, percentage string) string {
+		return `[INFO] [progress.go:51] ["` + step + `"] [progress=` + percentage + `] [count="1 / 2"]`
+	}
+	tracker.Observe(progressLine("Full backup", "10.00%"))
+	now = now.Add(10 * time.Second)
+	tracker.Observe(progressLine("Full backup", "20.00%"))
+	// the progress is throttled unless the step changes
+	g.Expect(reported).To(HaveLen(1))
+	tracker.Observe(progressLine("Checksum", "0.00%"))
+	g.Expect(reported).To(HaveLen(2))
+	now = now.Add(30 * time.Second)
+	tracker.Observe(progressLine("Checksum", "50.00%"))
+	g.Expect(reported).To(HaveLen(3))
+	g.Expect(reported[2].Step).To(Equal("Checksum"))
+	g.Expect(reported[2].Percentage).To(Equal("50.00%"))
+	g.Expect(reported[2].LastUpdateTime.Time).To(Equal(now))
+
+	// the last error log wins over the standard error output
+	tracker.Observe(`[ERROR] [main.go:58] ["br failed"] [error="context canceled"]` + "\n")
+	tracker.ObserveError("Error: context canceled\n")
+	g.Expect(tracker.LastError()).To(Equal(`[ERROR] [main.go:58] ["br failed"] [error="context canceled"]`))
+
+	tracker = NewProgressTracker(30*time.Second, func(*v1alpha1.BRProgress) {})
+	tracker.ObserveError("usage: br\nError: unknown flag: --foo\n")
+	g.Expect(tracker.LastError()).To(Equal("Error: unknown flag: --foo"))
+}
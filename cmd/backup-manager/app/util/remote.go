@@ -17,21 +17,29 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
+	"os"
 	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-pipeline-go/pipeline"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"gocloud.dev/blob"
+	"gocloud.dev/blob/azureblob"
 	"gocloud.dev/blob/fileblob"
 	"gocloud.dev/blob/gcsblob"
 	"gocloud.dev/blob/s3blob"
 	"gocloud.dev/gcp"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/util"
@@ -39,6 +47,11 @@ import (
 
 const (
 	maxRetries = 3 // number of retries to make of operations
+
+	// azureStorageResource is the resource requested by azure AD tokens to access blobs
+	azureStorageResource = "https://storage.azure.com/"
+	// azureTokenRefreshMargin is how long before expiry an azure AD token is refreshed
+	azureTokenRefreshMargin = 2 * time.Minute
 )
 
 type s3Config struct {
@@ -64,6 +77,14 @@ type gcsConfig struct {
 	prefix       string
 }
 
+type azblobConfig struct {
+	account    string
+	container  string
+	accessTier string
+	endpoint   string
+	prefix     string
+}
+
 type localConfig struct {
 	mountPath string
 	prefix    string
@@ -73,12 +94,13 @@ type localConfig struct {
 type StorageBackend struct {
 	*blob.Bucket
 
-	s3    *s3Config
-	gcs   *gcsConfig
-	local *localConfig
+	s3     *s3Config
+	gcs    *gcsConfig
+	azblob *azblobConfig
+	local  *localConfig
 }
 
-// NewStorageBackend creates new storage backend, now supports S3/GCS/Local
+// NewStorageBackend creates new storage backend, now supports S3/GCS/Azblob/Local
 func NewStorageBackend(provider v1alpha1.StorageProvider) (*StorageBackend, error) {
 	var bucket *blob.Bucket
 	var err error
@@ -93,6 +115,9 @@ func NewStorageBackend(provider v1alpha1.StorageProvider) (*StorageBackend, erro
 	case v1alpha1.BackupStorageTypeGcs:
 		b.gcs = makeGcsConfig(provider.Gcs, true)
 		bucket, err = newGcsStorage(b.gcs)
+	case v1alpha1.BackupStorageTypeAzblob:
+		b.azblob = makeAzblobConfig(provider.Azblob)
+		bucket, err = newAzblobStorage(b.azblob)
 	case v1alpha1.BackupStorageTypeLocal:
 		b.local = makeLocalConfig(provider.Local)
 		bucket, err = newLocalStorage(b.local)
@@ -113,6 +138,8 @@ func (b *StorageBackend) StorageType() v1alpha1.BackupStorageType {
 		return v1alpha1.BackupStorageTypeS3
 	} else if b.gcs != nil {
 		return v1alpha1.BackupStorageTypeGcs
+	} else if b.azblob != nil {
+		return v1alpha1.BackupStorageTypeAzblob
 	} else if b.local != nil {
 		return v1alpha1.BackupStorageTypeLocal
 	}
@@ -143,14 +170,25 @@ func (b *StorageBackend) AsGCS() (*storage.Client, bool) {
 	return gcsClient, true
 }
 
+func (b *StorageBackend) AsAzblob() (*azblob.ContainerURL, bool) {
+	var containerURL *azblob.ContainerURL
+	if ok := b.As(&containerURL); !ok {
+		return nil, false
+	}
+
+	return containerURL, true
+}
+
 // GetBucket return bucket name
 //
-// If provider is S3/GCS, return bucket. Otherwise return empty string
+// If provider is S3/GCS, return bucket, if provider is Azblob, return container. Otherwise return empty string
 func (b *StorageBackend) GetBucket() string {
 	if b.s3 != nil {
 		return b.s3.bucket
 	} else if b.gcs != nil {
 		return b.gcs.bucket
+	} else if b.azblob != nil {
+		return b.azblob.container
 	}
 
 	return ""
@@ -162,6 +200,8 @@ func (b *StorageBackend) GetPrefix() string {
 		return b.s3.prefix
 	} else if b.gcs != nil {
 		return b.gcs.prefix
+	} else if b.azblob != nil {
+		return b.azblob.prefix
 	} else if b.local != nil {
 		return b.local.prefix
 	}
@@ -302,6 +342,10 @@ func genStorageArgs(provider v1alpha1.StorageProvider) ([]string, error) {
 		qs := makeGcsConfig(provider.Gcs, false)
 		s := newGcsStorageOption(qs)
 		return s, nil
+	case v1alpha1.BackupStorageTypeAzblob:
+		qs := makeAzblobConfig(provider.Azblob)
+		s := newAzblobStorageOption(qs)
+		return s, nil
 	case v1alpha1.BackupStorageTypeLocal:
 		localConfig := makeLocalConfig(provider.Local)
 		cmdOpts, err := newLocalStorageOption(localConfig)
@@ -419,6 +463,119 @@ func newGcsStorageOption(conf *gcsConfig) []string {
 	return gcsoptions
 }
 
+// newAzblobStorage initialize a new azure blob storage
+func newAzblobStorage(conf *azblobConfig) (*blob.Bucket, error) {
+	ctx := context.Background()
+
+	if conf.account == "" {
+		return nil, fmt.Errorf("the azure storage account is not set")
+	}
+	credential, err := newAzblobCredential(conf.account)
+	if err != nil {
+		return nil, err
+	}
+	p := azureblob.NewPipeline(credential, azblob.PipelineOptions{
+		Retry: azblob.RetryOptions{MaxTries: maxRetries},
+	})
+	if conf.endpoint != "" {
+		endpoint, err := url.Parse(conf.endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid azure blob endpoint %s, err: %v", conf.endpoint, err)
+		}
+		p = &endpointPipeline{Pipeline: p, endpoint: endpoint}
+	}
+
+	// Create a *blob.Bucket.
+	bucket, err := azureblob.OpenBucket(ctx, p, azureblob.AccountName(conf.account), conf.container, nil)
+	if err != nil {
+		return nil, err
+	}
+	return blob.PrefixedBucket(bucket, strings.Trim(conf.prefix, "/")+"/"), nil
+}
+
+// newAzblobCredential picks the credential from the environment variables in the order of
+// the shared key, the service principal and finally the managed identity of the pod
+func newAzblobCredential(account string) (azblob.Credential, error) {
+	if key := os.Getenv("AZURE_STORAGE_KEY"); key != "" {
+		return azblob.NewSharedKeyCredential(account, key)
+	}
+
+	var spt *adal.ServicePrincipalToken
+	var err error
+	clientID := os.Getenv("AZURE_CLIENT_ID")
+	clientSecret := os.Getenv("AZURE_CLIENT_SECRET")
+	tenantID := os.Getenv("AZURE_TENANT_ID")
+	if clientID != "" && clientSecret != "" && tenantID != "" {
+		oauthConfig, err := adal.NewOAuthConfig("https://login.microsoftonline.com/", tenantID)
+		if err != nil {
+			return nil, err
+		}
+		spt, err = adal.NewServicePrincipalToken(*oauthConfig, clientID, clientSecret, azureStorageResource)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		msiEndpoint, err := adal.GetMSIVMEndpoint()
+		if err != nil {
+			return nil, err
+		}
+		if clientID != "" {
+			spt, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, azureStorageResource, clientID)
+		} else {
+			spt, err = adal.NewServicePrincipalTokenFromMSI(msiEndpoint, azureStorageResource)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = spt.Refresh(); err != nil {
+		return nil, fmt.Errorf("get azure AD token failed, err: %v", err)
+	}
+
+	return azblob.NewTokenCredential(spt.OAuthToken(), func(tc azblob.TokenCredential) time.Duration {
+		if err := spt.Refresh(); err != nil {
+			klog.Errorf("refresh azure AD token failed, err: %v", err)
+			return azureTokenRefreshMargin
+		}
+		tc.SetToken(spt.OAuthToken())
+		return time.Until(spt.Token().Expires()) - azureTokenRefreshMargin
+	}), nil
+}
+
+// endpointPipeline sends the requests to the custom blob service endpoint instead
+// of https://<account>.blob.core.windows.net, e.g. to an Azurite emulator.
+// The path of the endpoint is prepended to the request path so that a path-style
+// endpoint like http://azurite:10000/devstoreaccount1 works.
+type endpointPipeline struct {
+	pipeline.Pipeline
+	endpoint *url.URL
+}
+
+func (p *endpointPipeline) Do(ctx context.Context, methodFactory pipeline.Factory, request pipeline.Request) (pipeline.Response, error) {
+	request.URL.Scheme = p.endpoint.Scheme
+	request.URL.Host = p.endpoint.Host
+	request.URL.Path = strings.TrimSuffix(p.endpoint.Path, "/") + request.URL.Path
+	request.Host = p.endpoint.Host
+	return p.Pipeline.Do(ctx, methodFactory, request)
+}
+
+// newAzblobStorageOption constructs the arg for --storage option and the remote path for br
+func newAzblobStorageOption(conf *azblobConfig) []string {
+	var azblobOptions []string
+	path := fmt.Sprintf("azblob://%s/", path.Join(conf.container, conf.prefix))
+	azblobOptions = append(azblobOptions, fmt.Sprintf("--storage=%s", path))
+	if conf.account != "" {
+		azblobOptions = append(azblobOptions, fmt.Sprintf("--azblob.account-name=%s", conf.account))
+	}
+	if conf.accessTier != "" {
+		azblobOptions = append(azblobOptions, fmt.Sprintf("--azblob.access-tier=%s", conf.accessTier))
+	}
+	if conf.endpoint != "" {
+		azblobOptions = append(azblobOptions, fmt.Sprintf("--azblob.endpoint=%s", conf.endpoint))
+	}
+	return azblobOptions
+}
+
 // makeS3Config constructs s3Config parameters
 func makeS3Config(s3 *v1alpha1.S3StorageProvider, fakeRegion bool) *s3Config {
 	conf := s3Config{}
@@ -468,6 +625,26 @@ func makeGcsConfig(gcs *v1alpha1.GcsStorageProvider, fakeRegion bool) *gcsConfig
 	return &conf
 }
 
+// makeAzblobConfig constructs azblobConfig parameters, the storage account
+// falls back to the one provided by the secret through AZURE_STORAGE_ACCOUNT
+func makeAzblobConfig(az *v1alpha1.AzblobStorageProvider) *azblobConfig {
+	conf := azblobConfig{}
+
+	path := strings.Trim(az.Container, "/") + "/" + strings.Trim(az.Prefix, "/")
+	fields := strings.SplitN(path, "/", 2)
+
+	conf.container = fields[0]
+	conf.account = az.StorageAccount
+	if conf.account == "" {
+		conf.account = os.Getenv("AZURE_STORAGE_ACCOUNT")
+	}
+	conf.accessTier = az.AccessTier
+	conf.endpoint = az.Endpoint
+	conf.prefix = fields[1]
+
+	return &conf
+}
+
 func makeLocalConfig(local *v1alpha1.LocalStorageProvider) *localConfig {
 	return &localConfig{
 		mountPath: local.VolumeMount.MountPath,
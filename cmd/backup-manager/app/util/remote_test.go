@@ -18,6 +18,10 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -269,6 +273,19 @@ func TestStorageBackendBasic(t *testing.T) {
 			expectedBucket:    "gcs-bucket",
 			expectedPrefix:    "gcs-prefix",
 		},
+		{
+			name: "basic azblob storage backend",
+			provider: v1alpha1.StorageProvider{
+				Azblob: &v1alpha1.AzblobStorageProvider{
+					StorageAccount: "account",
+					Container:      "azblob-container",
+					Prefix:         "azblob-prefix",
+				},
+			},
+			expectStorageType: v1alpha1.BackupStorageTypeAzblob,
+			expectedBucket:    "azblob-container",
+			expectedPrefix:    "azblob-prefix",
+		},
 		{
 			name: "basic local storage backend",
 			provider: v1alpha1.StorageProvider{
@@ -319,6 +336,10 @@ func TestStorageBackendBasic(t *testing.T) {
 			return nil, nil
 		})
 		defer gcsPatches.Reset()
+		azblobPatches := gomonkey.ApplyFunc(newAzblobStorage, func(conf *azblobConfig) (*blob.Bucket, error) {
+			return nil, nil
+		})
+		defer azblobPatches.Reset()
 		localPatches := gomonkey.ApplyFunc(newLocalStorage, func(conf *localConfig) (*blob.Bucket, error) {
 			return nil, nil
 		})
@@ -335,6 +356,97 @@ func TestStorageBackendBasic(t *testing.T) {
 	}
 }
 
+func TestAzblobStorageWithEndpoint(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// the well-known account and key of the Azurite emulator
+	os.Setenv("AZURE_STORAGE_KEY", "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==")
+	defer os.Unsetenv("AZURE_STORAGE_KEY")
+
+	// azurite is a stand-in of the Azurite emulator serving a container with path-style URLs
+	var mu sync.Mutex
+	blobs := map[string]bool{"backup/a": true, "backup/b": true}
+	azurite := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey devstoreaccount1:") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/devstoreaccount1/container" && r.URL.Query().Get("comp") == "list":
+			var names []string
+			for name := range blobs {
+				if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults ContainerName="container"><Blobs>`)
+			for _, name := range names {
+				fmt.Fprintf(w, `<Blob><Name>%s</Name><Properties><Last-Modified>Mon, 02 Jan 2006 15:04:05 GMT</Last-Modified><Etag>0x1</Etag><Content-Length>1</Content-Length></Properties></Blob>`, name)
+			}
+			fmt.Fprint(w, `</Blobs><NextMarker /></EnumerationResults>`)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/devstoreaccount1/container/"):
+			name := strings.TrimPrefix(r.URL.Path, "/devstoreaccount1/container/")
+			if !blobs[name] {
+				w.Header().Set("x-ms-error-code", "BlobNotFound")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(blobs, name)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer azurite.Close()
+
+	backend, err := NewStorageBackend(v1alpha1.StorageProvider{
+		Azblob: &v1alpha1.AzblobStorageProvider{
+			StorageAccount: "devstoreaccount1",
+			Container:      "container",
+			Prefix:         "backup",
+			Endpoint:       azurite.URL + "/devstoreaccount1",
+		},
+	})
+	g.Expect(err).Should(gomega.Succeed())
+	_, ok := backend.AsAzblob()
+	g.Expect(ok).Should(gomega.BeTrue())
+
+	objs, err := backend.ListPage(nil).Next(context.Background(), 10)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(objs).Should(gomega.HaveLen(2))
+	g.Expect(objs[0].Key).Should(gomega.Equal("a"))
+	g.Expect(objs[1].Key).Should(gomega.Equal("b"))
+
+	result := backend.BatchDeleteObjects(context.Background(), objs, v1alpha1.BatchDeleteOption{})
+	g.Expect(result.Errors).Should(gomega.BeEmpty())
+	g.Expect(result.Deleted).Should(gomega.ConsistOf("a", "b"))
+	g.Expect(blobs).Should(gomega.BeEmpty())
+}
+
+func TestGenAzblobStorageArgs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	args, err := genStorageArgs(v1alpha1.StorageProvider{
+		Azblob: &v1alpha1.AzblobStorageProvider{
+			StorageAccount: "devstoreaccount1",
+			Container:      "container/a",
+			Prefix:         "backup",
+			AccessTier:     "Cool",
+			Endpoint:       "http://azurite:10000/devstoreaccount1",
+		},
+	})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(args).Should(gomega.Equal([]string{
+		"--storage=azblob://container/a/backup/",
+		"--azblob.account-name=devstoreaccount1",
+		"--azblob.access-tier=Cool",
+		"--azblob.endpoint=http://azurite:10000/devstoreaccount1",
+	}))
+}
+
 func TestStorageBackendBatchDeleteObjects(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -103,6 +103,11 @@ func GetStoragePath(backup *v1alpha1.Backup) (string, error) {
 		bucket = backup.Spec.StorageProvider.Gcs.Bucket
 		url = fmt.Sprintf("gcs://%s/", path.Join(bucket, prefix))
 		return url, nil
+	case v1alpha1.BackupStorageTypeAzblob:
+		prefix = backup.Spec.StorageProvider.Azblob.Prefix
+		bucket = backup.Spec.StorageProvider.Azblob.Container
+		url = fmt.Sprintf("azblob://%s/", path.Join(bucket, prefix))
+		return url, nil
 	case v1alpha1.BackupStorageTypeLocal:
 		prefix = backup.Spec.StorageProvider.Local.Prefix
 		mountPath := backup.Spec.StorageProvider.Local.VolumeMount.MountPath
@@ -193,6 +198,19 @@ func ConstructBRGlobalOptionsForBackup(backup *v1alpha1.Backup) ([]string, error
 	return args, nil
 }
 
+// ConstructBRGlobalOptionsForVerify constructs BR global options for verifying a backup offline.
+func ConstructBRGlobalOptionsForVerify(backup *v1alpha1.Backup) ([]string, error) {
+	if backup.Spec.BR == nil {
+		return nil, fmt.Errorf("no config for br in Backup %s/%s", backup.Namespace, backup.Name)
+	}
+	args := constructBRGlobalOptions(backup.Spec.BR)
+	storageArgs, err := genStorageArgs(backup.Spec.StorageProvider)
+	if err != nil {
+		return nil, err
+	}
+	return append(args, storageArgs...), nil
+}
+
 // ConstructDumplingOptionsForBackup constructs dumpling options for backup
 func ConstructDumplingOptionsForBackup(backup *v1alpha1.Backup) []string {
 	var args []string
@@ -244,6 +244,20 @@ func TestGetRemotePath(t *testing.T) {
 			},
 			expect: "gcs://test1-demo1/",
 		},
+		{
+			name: "normal azblob",
+			backup: &v1alpha1.Backup{
+				Spec: v1alpha1.BackupSpec{
+					StorageProvider: v1alpha1.StorageProvider{
+						Azblob: &v1alpha1.AzblobStorageProvider{
+							Container: "test1-demo1",
+							Prefix:    "backup",
+						},
+					},
+				},
+			},
+			expect: "azblob://test1-demo1/backup/",
+		},
 		{
 			name: "unknow storage type",
 			backup: &v1alpha1.Backup{
@@ -0,0 +1,101 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog"
+)
+
+// Manager mainly used to manage backup verification related work
+type Manager struct {
+	backupLister  listers.BackupLister
+	StatusUpdater controller.BackupConditionUpdaterInterface
+	Options
+}
+
+// NewManager return a Manager
+func NewManager(
+	backupLister listers.BackupLister,
+	statusUpdater controller.BackupConditionUpdaterInterface,
+	verifyOpts Options) *Manager {
+	return &Manager{
+		backupLister,
+		statusUpdater,
+		verifyOpts,
+	}
+}
+
+// ProcessVerify used to verify the specific backup offline
+func (vm *Manager) ProcessVerify() error {
+	ctx, cancel := util.GetContextForTerminationSignals(fmt.Sprintf("verify %s", vm.ResourceName))
+	defer cancel()
+
+	backup, err := vm.backupLister.Backups(vm.Namespace).Get(vm.ResourceName)
+	if err != nil {
+		return fmt.Errorf("can't find backup %s CRD object, err: %v", vm, err)
+	}
+	requestTime, err := time.Parse(time.RFC3339, vm.RequestTime)
+	if err != nil {
+		return fmt.Errorf("backup %s, parse verify request time %s failed, err: %v", vm, vm.RequestTime, err)
+	}
+
+	return vm.performVerify(ctx, backup.DeepCopy(), metav1.Time{Time: requestTime})
+}
+
+func (vm *Manager) performVerify(ctx context.Context, backup *v1alpha1.Backup, requestTime metav1.Time) error {
+	if backup.Spec.BR == nil {
+		return fmt.Errorf("no br config in %s", vm)
+	}
+
+	started := metav1.Now()
+	status := &v1alpha1.BackupVerifyStatus{
+		Phase:       v1alpha1.BackupVerifyRunning,
+		RequestTime: requestTime,
+		TimeStarted: &started,
+	}
+	if err := vm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{Verify: status}); err != nil {
+		return err
+	}
+
+	summary, err := vm.verifyBackupMeta(ctx, backup)
+	if err == nil {
+		err = vm.verifyChecksum(ctx, backup)
+	}
+
+	completed := metav1.Now()
+	status = status.DeepCopy()
+	status.TimeCompleted = &completed
+	if err != nil {
+		klog.Errorf("verify backup %s failed, err: %s", vm, err)
+		status.Phase = v1alpha1.BackupVerifyFailed
+		status.Message = err.Error()
+		uerr := vm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{Verify: status})
+		return errorutils.NewAggregate([]error{err, uerr})
+	}
+
+	klog.Infof("verify backup %s success, %s", vm, summary)
+	status.Phase = v1alpha1.BackupVerifyPassed
+	status.Message = summary
+	return vm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{Verify: status})
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	kvbackup "github.com/pingcap/kvproto/pkg/backup"
+	backupUtil "github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"k8s.io/klog"
+)
+
+// listPageSize is the number of objects listed per request when checking the backup files
+const listPageSize = 1000
+
+// Options contains the input arguments to the verify command
+type Options struct {
+	backupUtil.GenericOptions
+	// RequestTime is the RFC3339 time of the verification request served by the verify job
+	RequestTime string
+}
+
+// verifyBackupMeta decodes the backupmeta and checks that every backup file it references
+// exists in the storage with the recorded size, it returns the summary of the checked files.
+func (vo *Options) verifyBackupMeta(ctx context.Context, backup *v1alpha1.Backup) (string, error) {
+	meta, err := backupUtil.GetBRMetaData(ctx, backup.Spec.StorageProvider)
+	if err != nil {
+		return "", fmt.Errorf("read backupmeta failed, err: %v", err)
+	}
+
+	s, err := backupUtil.NewStorageBackend(backup.Spec.StorageProvider)
+	if err != nil {
+		return "", err
+	}
+	defer s.Close()
+
+	sizes := map[string]int64{}
+	iter := s.ListPage(nil)
+	for {
+		objs, err := iter.Next(ctx, listPageSize)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("list backup files failed, err: %v", err)
+		}
+		for _, obj := range objs {
+			sizes[obj.Key] = obj.Size
+		}
+	}
+
+	if err := checkBackupFiles(meta, sizes); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d files of %s are intact", len(meta.Files), humanize.Bytes(backupUtil.GetBRArchiveSize(meta))), nil
+}
+
+// checkBackupFiles checks the files referenced by the backupmeta against the sizes of the stored objects,
+// the size of a file is only compared when the backupmeta records it.
+func checkBackupFiles(meta *kvbackup.BackupMeta, sizes map[string]int64) error {
+	var missing, mismatched []string
+	for _, file := range meta.Files {
+		size, ok := sizes[file.Name]
+		if !ok {
+			missing = append(missing, file.Name)
+			continue
+		}
+		if file.Size_ != 0 && uint64(size) != file.Size_ {
+			mismatched = append(mismatched, fmt.Sprintf("%s (%d bytes, expected %d)", file.Name, size, file.Size_))
+		}
+	}
+
+	var msgs []string
+	if len(missing) > 0 {
+		msgs = append(msgs, fmt.Sprintf("%d backup files are missing: %s", len(missing), summarizeFiles(missing)))
+	}
+	if len(mismatched) > 0 {
+		msgs = append(msgs, fmt.Sprintf("%d backup files have unexpected sizes: %s", len(mismatched), summarizeFiles(mismatched)))
+	}
+	if len(msgs) > 0 {
+		return fmt.Errorf("%s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// summarizeFiles joins the first few files to keep the message in status short
+func summarizeFiles(files []string) string {
+	const maxFiles = 3
+	if len(files) <= maxFiles {
+		return strings.Join(files, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(files[:maxFiles], ", "), len(files)-maxFiles)
+}
+
+// verifyChecksum runs `br debug checksum` to check the checksums of the backup files against the backupmeta
+func (vo *Options) verifyChecksum(ctx context.Context, backup *v1alpha1.Backup) error {
+	args, err := backupUtil.ConstructBRGlobalOptionsForVerify(backup)
+	if err != nil {
+		return err
+	}
+	fullArgs := append([]string{"debug", "checksum"}, args...)
+	klog.Infof("Running br command with args: %v", fullArgs)
+	bin := path.Join(util.BRBinPath, "br")
+	cmd := exec.CommandContext(ctx, bin, fullArgs...)
+
+	stdOut, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("backup %s, create stdout pipe failed, err: %v", vo, err)
+	}
+	stdErr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("backup %s, create stderr pipe failed, err: %v", vo, err)
+	}
+	err = cmd.Start()
+	if err != nil {
+		return fmt.Errorf("backup %s, execute br command failed, args: %s, err: %v", vo, fullArgs, err)
+	}
+	var lastError string
+	reader := bufio.NewReader(stdOut)
+	for {
+		line, err := reader.ReadString('\n')
+		if strings.Contains(line, "[ERROR]") || strings.Contains(line, "[FATAL]") {
+			lastError = strings.TrimSpace(line)
+		}
+
+		klog.Info(strings.Replace(line, "\n", "", -1))
+		if err != nil || io.EOF == err {
+			break
+		}
+	}
+	tmpErr, _ := ioutil.ReadAll(stdErr)
+	if len(tmpErr) > 0 {
+		klog.Info(string(tmpErr))
+		if lastError == "" {
+			lines := strings.Split(strings.TrimSpace(string(tmpErr)), "\n")
+			lastError = strings.TrimSpace(lines[len(lines)-1])
+		}
+	}
+	err = cmd.Wait()
+	if err != nil {
+		if lastError != "" {
+			return fmt.Errorf("checksum mismatched or unreadable backup files: %s", lastError)
+		}
+		return fmt.Errorf("backup %s, wait pipe message failed, err: %v", vo, err)
+	}
+
+	klog.Infof("Verify checksums of backup %s successfully", vo)
+	return nil
+}
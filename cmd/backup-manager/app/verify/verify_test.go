@@ -0,0 +1,67 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	kvbackup "github.com/pingcap/kvproto/pkg/backup"
+)
+
+func TestCheckBackupFiles(t *testing.T) {
+	g := NewGomegaWithT(t)
+	meta := &kvbackup.BackupMeta{
+		Files: []*kvbackup.File{
+			{Name: "1_write.sst", Size_: 100},
+			{Name: "2_write.sst", Size_: 200},
+			// older BR versions do not record the size
+			{Name: "3_default.sst"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		sizes    map[string]int64
+		expected string
+	}{
+		{
+			name:  "intact",
+			sizes: map[string]int64{"backupmeta": 10, "1_write.sst": 100, "2_write.sst": 200, "3_default.sst": 300},
+		},
+		{
+			name:     "missing and truncated files",
+			sizes:    map[string]int64{"1_write.sst": 100, "2_write.sst": 150},
+			expected: "1 backup files are missing: 3_default.sst; 1 backup files have unexpected sizes: 2_write.sst (150 bytes, expected 200)",
+		},
+		{
+			name:     "all files missing",
+			sizes:    map[string]int64{},
+			expected: "3 backup files are missing: 1_write.sst, 2_write.sst, 3_default.sst",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBackupFiles(meta, tt.sizes)
+			if tt.expected == "" {
+				g.Expect(err).Should(BeNil())
+				return
+			}
+			g.Expect(err).Should(MatchError(tt.expected))
+		})
+	}
+
+	g.Expect(summarizeFiles([]string{"a", "b", "c", "d", "e"})).To(Equal("a, b, c and 2 more"))
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/controller/dmcluster"
 	"github.com/pingcap/tidb-operator/pkg/controller/periodicity"
 	"github.com/pingcap/tidb-operator/pkg/controller/restore"
+	"github.com/pingcap/tidb-operator/pkg/controller/sharding"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbcluster"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbinitializer"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbmonitor"
@@ -121,12 +122,21 @@ func main() {
 		klog.Fatalf("failed to get the generic kube-apiserver client: %v", err)
 	}
 
+	namespaces, err := cliCfg.WatchNamespaces(kubeCli, ns)
+	if err != nil {
+		klog.Fatalf("failed to get the namespaces to watch: %v", err)
+	}
+	if namespaces != nil {
+		klog.Infof("watch namespaces %v", namespaces)
+	}
+
 	// note that kubeCli here must not be the hijacked one
-	var operatorUpgrader upgrader.Interface
-	if cliCfg.ClusterScoped {
-		operatorUpgrader = upgrader.NewUpgrader(kubeCli, cli, asCli, metav1.NamespaceAll)
-	} else {
-		operatorUpgrader = upgrader.NewUpgrader(kubeCli, cli, asCli, ns)
+	var operatorUpgraders []upgrader.Interface
+	if namespaces == nil {
+		operatorUpgraders = append(operatorUpgraders, upgrader.NewUpgrader(kubeCli, cli, asCli, metav1.NamespaceAll))
+	}
+	for _, namespace := range namespaces {
+		operatorUpgraders = append(operatorUpgraders, upgrader.NewUpgrader(kubeCli, cli, asCli, namespace))
 	}
 
 	if features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet) {
@@ -135,15 +145,18 @@ func main() {
 		kubeCli = helper.NewHijackClient(kubeCli, asCli)
 	}
 
-	deps := controller.NewDependencies(ns, cliCfg, cli, kubeCli, genericCli)
+	deps := controller.NewDependencies(namespaces, cliCfg, cli, kubeCli, genericCli)
 
-	onStarted := func(ctx context.Context) {
+	upgrade := func() {
 		// Upgrade before running any controller logic. If it fails, we wait
 		// for process supervisor to restart it again.
-		if err := operatorUpgrader.Upgrade(); err != nil {
-			klog.Fatalf("failed to upgrade: %v", err)
+		for _, operatorUpgrader := range operatorUpgraders {
+			if err := operatorUpgrader.Upgrade(); err != nil {
+				klog.Fatalf("failed to upgrade: %v", err)
+			}
 		}
-
+	}
+	startControllers := func(ctx context.Context) {
 		// Define some nested types to simplify the codebase
 		type Controller interface {
 			Run(int, <-chan struct{})
@@ -192,6 +205,10 @@ func main() {
 			go wait.Forever(func() { c.Run(cliCfg.Workers, ctx.Done()) }, cliCfg.WaitDuration)
 		}
 	}
+	onStarted := func(ctx context.Context) {
+		upgrade()
+		startControllers(ctx)
+	}
 	onStopped := func() {
 		klog.Fatal("leader election lost")
 	}
@@ -200,9 +217,8 @@ func main() {
 	if helmRelease != "" {
 		endPointsName += "-" + helmRelease
 	}
-	// leader election for multiple tidb-controller-manager instances
-	go wait.Forever(func() {
-		leaderelection.RunOrDie(context.TODO(), leaderelection.LeaderElectionConfig{
+	newLeaderElectionConfig := func(callbacks leaderelection.LeaderCallbacks) leaderelection.LeaderElectionConfig {
+		return leaderelection.LeaderElectionConfig{
 			Lock: &resourcelock.EndpointsLock{
 				EndpointsMeta: metav1.ObjectMeta{
 					Namespace: ns,
@@ -217,12 +233,52 @@ func main() {
 			LeaseDuration: cliCfg.LeaseDuration,
 			RenewDeadline: cliCfg.RenewDeadline,
 			RetryPeriod:   cliCfg.RetryPeriod,
-			Callbacks: leaderelection.LeaderCallbacks{
+			Callbacks:     callbacks,
+		}
+	}
+	shardingStopCh, shardingDone := make(chan struct{}), make(chan struct{})
+	if cliCfg.Sharding {
+		// all tidb-controller-manager instances are active, each of them syncs the objects of its own shard
+		sharder := sharding.NewLeaseSharder(kubeCli, ns, endPointsName, hostName, cliCfg.LeaseDuration, cliCfg.RetryPeriod)
+		deps.Sharder = sharder
+		go func() {
+			sharder.Run(shardingStopCh)
+			close(shardingDone)
+		}()
+		go func() {
+			// the upgrade is run under the leader election lock, so it is never run by two instances at the
+			// same time, the first instance migrates the objects and the later ones find nothing to migrate.
+			// Each instance releases the lock after the upgrade and starts its controllers then.
+			upgraded := make(chan struct{})
+			ctx, cancel := context.WithCancel(context.TODO())
+			config := newLeaderElectionConfig(leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(context.Context) {
+					upgrade()
+					close(upgraded)
+					cancel()
+				},
+				OnStoppedLeading: func() {
+					select {
+					case <-upgraded:
+					default:
+						klog.Fatal("leader election lost during the upgrade")
+					}
+				},
+			})
+			config.ReleaseOnCancel = true
+			leaderelection.RunOrDie(ctx, config)
+			startControllers(context.TODO())
+		}()
+	} else {
+		close(shardingDone)
+		// leader election for multiple tidb-controller-manager instances
+		go wait.Forever(func() {
+			leaderelection.RunOrDie(context.TODO(), newLeaderElectionConfig(leaderelection.LeaderCallbacks{
 				OnStartedLeading: onStarted,
 				OnStoppedLeading: onStopped,
-			},
-		})
-	}, cliCfg.WaitDuration)
+			}))
+		}, cliCfg.WaitDuration)
+	}
 
 	srv := createHTTPServer()
 	sc := make(chan os.Signal, 1)
@@ -236,6 +292,9 @@ func main() {
 	go func() {
 		sig := <-sc
 		klog.Infof("got signal %s to exit", sig)
+		// release the shard lease so the objects of this instance are taken over immediately
+		close(shardingStopCh)
+		<-shardingDone
 		if err2 := srv.Shutdown(context.Background()); err2 != nil {
 			klog.Fatal("fail to shutdown the HTTP server", err2)
 		}
@@ -33,5 +33,19 @@ bash "${CODEGEN_PKG}"/generate-groups.sh "deepcopy,client,informer,lister" \
     pingcap:v1alpha1 \
     --output-base $SCRIPT_ROOT \
     --go-header-file ./hack/boilerplate/boilerplate.generatego.txt
+# v1beta1 is served by the conversion webhook only, no clients are generated for it
+bash "${CODEGEN_PKG}"/generate-groups.sh "deepcopy" \
+    github.com/pingcap/tidb-operator/pkg/client \
+    github.com/pingcap/tidb-operator/pkg/apis \
+    pingcap:v1beta1 \
+    --output-base $SCRIPT_ROOT \
+    --go-header-file ./hack/boilerplate/boilerplate.generatego.txt
+# the conversions between v1beta1 and v1alpha1 used by the conversion webhook
+go install k8s.io/code-generator/cmd/conversion-gen
+"$(go env GOPATH)"/bin/conversion-gen \
+    --input-dirs github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1beta1 \
+    -O zz_generated.conversion \
+    --output-base $SCRIPT_ROOT \
+    --go-header-file ./hack/boilerplate/boilerplate.generatego.txt
 # then we merge generated code with our code base and clean up
 cp -r github.com/pingcap/tidb-operator/pkg $SCRIPT_ROOT && rm -rf github.com
@@ -36,10 +36,17 @@ object_acl = ${GCS_OBJECT_ACL}
 bucket_acl = ${GCS_BUCKET_ACL}
 location =  ${GCS_LOCATION}
 storage_class = ${GCS_STORAGE_CLASS:-"COLDLINE"}
-[azure]
+[azblob]
 type = azureblob
-account = ${AZUREBLOB_ACCOUNT}
-key = ${AZUREBLOB_KEY}
+account = ${AZURE_STORAGE_ACCOUNT:-$AZUREBLOB_ACCOUNT}
+key = ${AZURE_STORAGE_KEY:-$AZUREBLOB_KEY}
+endpoint = ${AZURE_STORAGE_ENDPOINT}
+access_tier = ${AZURE_ACCESS_TIER}
+use_msi = ${AZURE_USE_MSI:-false}
+msi_client_id = ${AZURE_CLIENT_ID}
+tenant = ${AZURE_TENANT_ID}
+client_id = ${AZURE_CLIENT_ID}
+client_secret = ${AZURE_CLIENT_SECRET}
 EOF
 
 if [[ -n "${GCS_SERVICE_ACCOUNT_JSON_KEY:-}" ]]; then
@@ -83,6 +90,16 @@ case "$1" in
         echo "$BACKUP_BIN $E2E_ARGS clean $@"
         exec $BACKUP_BIN $E2E_ARGS clean "$@"
         ;;
+    verify)
+        shift 1
+        echo "$BACKUP_BIN $E2E_ARGS verify $@"
+        exec $BACKUP_BIN $E2E_ARGS verify "$@"
+        ;;
+    ship-log)
+        shift 1
+        echo "$BACKUP_BIN $E2E_ARGS ship-log $@"
+        exec $BACKUP_BIN $E2E_ARGS ship-log "$@"
+        ;;
     *)
         echo "Usage: $0 {backup|restore|clean}"
         echo "Now runs your command."
@@ -89,6 +89,12 @@ const (
 	AnnTiKVPartition string = "tidb.pingcap.com/tikv-partition"
 	// AnnForceUpgradeKey is tc annotation key to indicate whether force upgrade should be done
 	AnnForceUpgradeKey = "tidb.pingcap.com/force-upgrade"
+	// AnnDeletionProtection is tc annotation key to indicate whether the deletion of the tc and its statefulsets and pvcs
+	// should be denied by the admission webhook
+	AnnDeletionProtection = "tidb.pingcap.com/deletion-protection"
+	// AnnUncheckedConfigKeys is tc annotation key of the comma separated config keys that the admission webhook
+	// does not validate, e.g. "tikv.raftstore.new-item,pd", each of which covers the key and its children, "*" covers all
+	AnnUncheckedConfigKeys = "tidb.pingcap.com/unchecked-config-keys"
 	// AnnPDDeferDeleting is pd pod annotation key  in pod for defer for deleting pod
 	AnnPDDeferDeleting = "tidb.pingcap.com/pd-defer-deleting"
 	// AnnSysctlInit is pod annotation key to indicate whether configuring sysctls with init container
@@ -97,6 +103,10 @@ const (
 	AnnEvictLeaderBeginTime = "tidb.pingcap.com/evictLeaderBeginTime"
 	// AnnStsLastSyncTimestamp is sts annotation key to indicate the last timestamp the operator sync the sts
 	AnnStsLastSyncTimestamp = "tidb.pingcap.com/sync-timestamp"
+	// AnnBackupVerifyRequestTime is backup annotation key of the RFC3339 time at which the backup is requested to be verified
+	AnnBackupVerifyRequestTime = "tidb.pingcap.com/verify-request-time"
+	// AnnBackupParent is backup annotation key of the name of the backup which an incremental backup is chained from
+	AnnBackupParent = "tidb.pingcap.com/parent-backup"
 
 	// AnnForceUpgradeVal is tc annotation value to indicate whether force upgrade should be done
 	AnnForceUpgradeVal = "true"
@@ -115,6 +125,9 @@ const (
 	AnnDMMasterDeleteSlots = "dm-master.tidb.pingcap.com/delete-slots"
 	// AnnDMWorkerDeleteSlots is annotation key of dm-worker delete slots.
 	AnnDMWorkerDeleteSlots = "dm-worker.tidb.pingcap.com/delete-slots"
+	// AnnTiDBPickedDeleteSlots is tidb statefulset annotation key of the delete slots picked by the operator
+	// to scale in the idle pods, they are kept in the delete slots of the statefulset besides the ones of the tc.
+	AnnTiDBPickedDeleteSlots = "tidb.tidb.pingcap.com/picked-delete-slots"
 
 	// AnnSkipTLSWhenConnectTiDB describes whether skip TLS when connecting to TiDB Server
 	AnnSkipTLSWhenConnectTiDB = "tidb.tidb.pingcap.com/skip-tls-when-connect-tidb"
@@ -144,6 +157,8 @@ const (
 	BackupJobLabelVal string = "backup"
 	// BackupScheduleJobLabelVal is backup schedule job label value
 	BackupScheduleJobLabelVal string = "backup-schedule"
+	// VerifyJobLabelVal is backup verify job label value
+	VerifyJobLabelVal string = "verify"
 	// InitJobLabelVal is TiDB initializer job label value
 	InitJobLabelVal string = "initializer"
 	// TiDBOperator is ManagedByLabelKey label value
@@ -299,6 +314,11 @@ func (l Label) BackupJob() Label {
 	return l.Component(BackupJobLabelVal)
 }
 
+// VerifyJob assigns verify to component key in label
+func (l Label) VerifyJob() Label {
+	return l.Component(VerifyJobLabelVal)
+}
+
 // RestoreJob assigns restore to component key in label
 func (l Label) RestoreJob() Label {
 	return l.Component(RestoreJobLabelVal)
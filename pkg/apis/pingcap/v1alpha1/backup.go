@@ -15,6 +15,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +45,11 @@ func (bk *Backup) GetBackupJobName() string {
 	return fmt.Sprintf("backup-%s", bk.GetName())
 }
 
+// GetVerifyJobName return the verify job name
+func (bk *Backup) GetVerifyJobName() string {
+	return fmt.Sprintf("verify-%s", bk.GetName())
+}
+
 // GetTidbEndpointHash return the hash string base on tidb cluster's host and port
 func (bk *Backup) GetTidbEndpointHash() string {
 	return HashContents([]byte(bk.Spec.From.GetTidbEndpoint()))
@@ -183,3 +189,37 @@ func IsCleanCandidate(backup *Backup) bool {
 func NeedNotClean(backup *Backup) bool {
 	return backup.Spec.CleanPolicy == CleanPolicyTypeOnFailure && !IsBackupFailed(backup)
 }
+
+// GetBackupVerifyRequestTime returns the time of the latest verification request of a
+// completed Backup, either the completion of a Backup with Spec.Verify set or the time in
+// the verify request annotation, it returns nil if the Backup is not requested to be verified.
+func GetBackupVerifyRequestTime(backup *Backup) *metav1.Time {
+	if backup.Spec.BR == nil || !IsBackupComplete(backup) {
+		return nil
+	}
+	var requestTime *metav1.Time
+	if backup.Spec.Verify {
+		requestTime = backup.Status.TimeCompleted.DeepCopy()
+	}
+	if v, ok := backup.Annotations[label.AnnBackupVerifyRequestTime]; ok {
+		t, err := time.Parse(time.RFC3339, v)
+		if err == nil && (requestTime == nil || requestTime.Time.Before(t)) {
+			requestTime = &metav1.Time{Time: t}
+		}
+	}
+	return requestTime
+}
+
+// IsBackupVerifyRequested returns true if a Backup has a verification request that is not served yet
+func IsBackupVerifyRequested(backup *Backup) bool {
+	requestTime := GetBackupVerifyRequestTime(backup)
+	if requestTime == nil {
+		return false
+	}
+	return backup.Status.Verify == nil || backup.Status.Verify.RequestTime.Before(requestTime)
+}
+
+// IsBackupVerifying returns true if the verification of a Backup is running
+func IsBackupVerifying(backup *Backup) bool {
+	return backup.Status.Verify != nil && backup.Status.Verify.Phase == BackupVerifyRunning
+}
@@ -15,6 +15,8 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	corev1 "k8s.io/api/core/v1"
@@ -118,3 +120,20 @@ func IsRestoreFailed(restore *Restore) bool {
 	_, condition := GetRestoreCondition(&restore.Status, RestoreFailed)
 	return condition != nil && condition.Status == corev1.ConditionTrue
 }
+
+// pitrTimeFormat is the format of the datetime target of a point-in-time recovery
+const pitrTimeFormat = "2006-01-02 15:04:05"
+
+// GetRestoredTSO returns the target TSO of the point-in-time recovery, the datetime target
+// is parsed in the given location.
+func (p *PitrSpec) GetRestoredTSO(loc *time.Location) (uint64, error) {
+	if ts, err := strconv.ParseUint(p.RestoredTs, 10, 64); err == nil {
+		return ts, nil
+	}
+	t, err := time.ParseInLocation(pitrTimeFormat, p.RestoredTs, loc)
+	if err != nil {
+		return 0, fmt.Errorf("%s is neither a TSO nor a datetime in the format of %q", p.RestoredTs, pitrTimeFormat)
+	}
+	// the physical part of a TSO is the milliseconds since epoch shifted left by 18 bits
+	return uint64(t.UnixNano()/int64(time.Millisecond)) << 18, nil
+}
@@ -747,6 +747,12 @@ func (tc *TidbCluster) IsPVReclaimEnabled() bool {
 	return *enabled
 }
 
+// IsDynamicConfigurationEnabled returns whether the config items that can be changed
+// online are applied without restarting the components
+func (tc *TidbCluster) IsDynamicConfigurationEnabled() bool {
+	return tc.Spec.EnableDynamicConfiguration != nil && *tc.Spec.EnableDynamicConfiguration
+}
+
 func (tc *TidbCluster) IsTiDBBinlogEnabled() bool {
 	var binlogEnabled *bool
 	if tc.Spec.TiDB != nil {
@@ -844,6 +850,11 @@ func (tc *TidbCluster) SkipTLSWhenConnectTiDB() bool {
 	return ok
 }
 
+// IsDeletionProtected returns whether the deletion of the tc and its statefulsets and pvcs is denied
+func (tc *TidbCluster) IsDeletionProtected() bool {
+	return tc.Annotations[label.AnnDeletionProtection] == "true"
+}
+
 // TODO: We Should better do not specified the default value ourself if user not specified the item.
 func (tc *TidbCluster) TiCDCTimezone() string {
 	if tc.Spec.TiCDC != nil && tc.Spec.TiCDC.Config != nil {
@@ -17,6 +17,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -251,10 +252,11 @@ type TidbClusterSpec struct {
 	Services []Service `json:"services,omitempty"`
 	// TODO: really deprecate this in code
 
-	// EnableDynamicConfiguration indicates whether to append `--advertise-status-addr` to the startup parameters of TiKV.
+	// EnableDynamicConfiguration indicates whether to append `--advertise-status-addr` to the startup parameters of TiKV,
+	// and to apply the config items of PD, TiKV and TiDB that can be changed online through their APIs without
+	// restarting them. If any other item changes, the ConfigUpdateStrategy of the component is used.
 	// +optional
 	EnableDynamicConfiguration *bool `json:"enableDynamicConfiguration,omitempty"`
-	// TODO: rename this into tikv-specific config name
 
 	// ClusterDomain is the Kubernetes Cluster Domain of TiDB cluster
 	// Optional: Defaults to ""
@@ -285,6 +287,54 @@ type TidbClusterSpec struct {
 	// +listType=map
 	// +listMapKey=topologyKey
 	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// MaintenanceWindows restricts disruptive operations, i.e. rolling upgrades, scale-in and
+	// recovery from failover, to the specified time windows.
+	// Emergency operations such as failover itself are not restricted.
+	// Optional: Defaults to nil, which means disruptive operations are allowed at any time
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// MaintenanceWindow describes a recurring time window in which disruptive operations are allowed
+// +k8s:openapi-gen=true
+type MaintenanceWindow struct {
+	// Schedule is the start time of the window in standard cron format, e.g. "0 2 * * 6"
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window lasts after it opens, in Go time duration format, e.g. "4h"
+	Duration string `json:"duration"`
+}
+
+// MaintenanceOperationType represents a kind of disruptive operation
+// which is restricted by maintenance windows
+type MaintenanceOperationType string
+
+const (
+	// MaintenanceOperationUpgrade is the rolling upgrade of a component
+	MaintenanceOperationUpgrade MaintenanceOperationType = "Upgrade"
+	// MaintenanceOperationScaleIn is the scale-in of a component
+	MaintenanceOperationScaleIn MaintenanceOperationType = "ScaleIn"
+	// MaintenanceOperationFailoverRecovery is the removal of replicas added by failover
+	MaintenanceOperationFailoverRecovery MaintenanceOperationType = "FailoverRecovery"
+)
+
+// PendingMaintenanceOperation is a disruptive operation waiting for the next maintenance window
+type PendingMaintenanceOperation struct {
+	MemberType MemberType               `json:"memberType"`
+	Operation  MaintenanceOperationType `json:"operation"`
+}
+
+// MaintenanceStatus is the status of maintenance windows of a tidb cluster
+type MaintenanceStatus struct {
+	// InWindow indicates whether the cluster is in a maintenance window now
+	InWindow bool `json:"inWindow"`
+	// NextWindowTime is the time when the next maintenance window opens
+	// +optional
+	NextWindowTime *metav1.Time `json:"nextWindowTime,omitempty"`
+	// PendingOperations are the disruptive operations waiting for the next maintenance window
+	// +optional
+	PendingOperations []PendingMaintenanceOperation `json:"pendingOperations,omitempty"`
 }
 
 // TidbClusterStatus represents the current status of a tidb cluster.
@@ -297,9 +347,67 @@ type TidbClusterStatus struct {
 	TiFlash    TiFlashStatus             `json:"tiflash,omitempty"`
 	TiCDC      TiCDCStatus               `json:"ticdc,omitempty"`
 	AutoScaler *TidbClusterAutoScalerRef `json:"auto-scaler,omitempty"`
+	// Maintenance is the status of maintenance windows, only set when
+	// `spec.maintenanceWindows` is configured.
+	// +optional
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
 	// Represents the latest available observations of a tidb cluster's state.
 	// +optional
 	Conditions []TidbClusterCondition `json:"conditions,omitempty"`
+	// OperationHistory is a bounded list of the scale, upgrade and failover
+	// operations performed on the cluster, oldest first.
+	// +optional
+	OperationHistory []TidbClusterOperation `json:"operationHistory,omitempty"`
+}
+
+// TidbClusterOperationType is the type of an operation performed on a tidb cluster.
+type TidbClusterOperationType string
+
+const (
+	// OperationTypeScaleOut means a member was added to a component
+	OperationTypeScaleOut TidbClusterOperationType = "ScaleOut"
+	// OperationTypeScaleIn means a member was removed from a component
+	OperationTypeScaleIn TidbClusterOperationType = "ScaleIn"
+	// OperationTypeUpgrade means a member was upgraded to the latest revision
+	OperationTypeUpgrade TidbClusterOperationType = "Upgrade"
+	// OperationTypeFailover means a replacement member was added for a failed one
+	OperationTypeFailover TidbClusterOperationType = "Failover"
+)
+
+// TidbClusterOperationOutcome is the outcome of an operation performed on a tidb cluster.
+type TidbClusterOperationOutcome string
+
+const (
+	// OperationRunning means the operation has started and is not finished yet
+	OperationRunning TidbClusterOperationOutcome = "Running"
+	// OperationSucceeded means the operation finished successfully
+	OperationSucceeded TidbClusterOperationOutcome = "Succeeded"
+	// OperationFailed means the operation was abandoned or failed
+	OperationFailed TidbClusterOperationOutcome = "Failed"
+)
+
+// TidbClusterOperation records a single operation performed on a tidb cluster.
+type TidbClusterOperation struct {
+	// Type of the operation
+	Type TidbClusterOperationType `json:"type"`
+	// Component is the member type the operation was performed on
+	Component MemberType `json:"component"`
+	// PodName is the pod the operation was performed on
+	// +optional
+	PodName string `json:"podName,omitempty"`
+	// Generation is the spec generation of the TidbCluster that triggered the operation
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	// StartTime is the time the operation started
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is the time the operation finished, nil while it is running
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// Outcome of the operation
+	Outcome TidbClusterOperationOutcome `json:"outcome"`
+	// A human readable message describing the operation
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // TidbClusterCondition describes the state of a tidb cluster at a certain point.
@@ -1009,6 +1117,18 @@ type PDStatus struct {
 	FailureMembers  map[string]PDFailureMember `json:"failureMembers,omitempty"`
 	UnjoinedMembers map[string]UnjoinedMember  `json:"unjoinedMembers,omitempty"`
 	Image           string                     `json:"image,omitempty"`
+	// OnlineConfig is the config items last applied online
+	// +optional
+	OnlineConfig *OnlineConfigStatus `json:"onlineConfig,omitempty"`
+}
+
+// OnlineConfigStatus is the config items applied online through the config API of
+// a component, without restarting it
+type OnlineConfigStatus struct {
+	// Items are the keys of the applied config items, e.g. raftstore.sync-log
+	Items []string `json:"items,omitempty"`
+	// LastAppliedTime is the time the items were applied
+	LastAppliedTime metav1.Time `json:"lastAppliedTime,omitempty"`
 }
 
 // PDMember is PD member
@@ -1021,6 +1141,8 @@ type PDMember struct {
 	Health    bool   `json:"health"`
 	// Last time the health transitioned from one to another.
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Node hosting pod of this PD member.
+	NodeName string `json:"node,omitempty"`
 }
 
 // PDFailureMember is the pd failure member information
@@ -1049,6 +1171,9 @@ type TiDBStatus struct {
 	FailureMembers           map[string]TiDBFailureMember `json:"failureMembers,omitempty"`
 	ResignDDLOwnerRetryCount int32                        `json:"resignDDLOwnerRetryCount,omitempty"`
 	Image                    string                       `json:"image,omitempty"`
+	// OnlineConfig is the config items last applied online
+	// +optional
+	OnlineConfig *OnlineConfigStatus `json:"onlineConfig,omitempty"`
 }
 
 // TiDBMember is TiDB member
@@ -1059,6 +1184,18 @@ type TiDBMember struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	// Node hosting pod of this TiDB member.
 	NodeName string `json:"node,omitempty"`
+	// Version is the server version of the TiDB member
+	// +optional
+	Version string `json:"version,omitempty"`
+	// GitHash is the git hash of the TiDB member
+	// +optional
+	GitHash string `json:"gitHash,omitempty"`
+	// IsDDLOwner indicates whether the TiDB member is the DDL owner
+	// +optional
+	IsDDLOwner bool `json:"isDDLOwner,omitempty"`
+	// Connections is the number of the client connections of the TiDB member
+	// +optional
+	Connections int32 `json:"connections,omitempty"`
 }
 
 // TiDBFailureMember is the tidb failure member information
@@ -1078,6 +1215,27 @@ type TiKVStatus struct {
 	TombstoneStores map[string]TiKVStore        `json:"tombstoneStores,omitempty"`
 	FailureStores   map[string]TiKVFailureStore `json:"failureStores,omitempty"`
 	Image           string                      `json:"image,omitempty"`
+	// EvictLeader contains the stores whose region leaders are evicted because their nodes are draining,
+	// the key is the name of the Pod.
+	// +optional
+	EvictLeader map[string]*EvictLeaderStatus `json:"evictLeader,omitempty"`
+	// OnlineConfig is the config items last applied online
+	// +optional
+	OnlineConfig *OnlineConfigStatus `json:"onlineConfig,omitempty"`
+	// StoreSummary is the summary of the stores managed by the cluster
+	// +optional
+	StoreSummary *TiKVStoreSummary `json:"storeSummary,omitempty"`
+}
+
+// EvictLeaderStatus represents the leader eviction of a TiKV store whose node is draining
+type EvictLeaderStatus struct {
+	StoreID string `json:"storeID"`
+	// NodeName is the name of the draining node
+	NodeName string `json:"nodeName"`
+	// PodCreateTime is the creation time of the Pod when the eviction begins,
+	// which is used to detect whether the Pod has been recreated
+	PodCreateTime metav1.Time `json:"podCreateTime,omitempty"`
+	BeginTime     metav1.Time `json:"beginTime,omitempty"`
 }
 
 // TiFlashStatus is TiFlash status
@@ -1118,6 +1276,65 @@ type TiKVStore struct {
 	State       string `json:"state"`
 	// Last time the health transitioned from one to another.
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Node hosting pod of this TiKV store.
+	NodeName string `json:"node,omitempty"`
+	// RegionCount is the number of region peers on the store
+	// +optional
+	RegionCount int32 `json:"regionCount,omitempty"`
+	// Capacity is the capacity of the store reported by TiKV
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// Available is the available space of the store
+	// +optional
+	Available *resource.Quantity `json:"available,omitempty"`
+	// UsedSize is the space used by the data of the store
+	// +optional
+	UsedSize *resource.Quantity `json:"usedSize,omitempty"`
+	// LeaderWeight is the leader weight of the store used by PD scheduling.
+	// It's a float number, but the apimachinery discourages float, so we store it as string.
+	// +optional
+	LeaderWeight string `json:"leaderWeight,omitempty"`
+	// RegionWeight is the region weight of the store used by PD scheduling, stored as string like LeaderWeight
+	// +optional
+	RegionWeight string `json:"regionWeight,omitempty"`
+	// Labels are the labels of the store
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// StartTime is the time the store started, which is stable until the store restarts
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Uptime is the duration since the store started in minutes, e.g. "3h25m0s"
+	// +optional
+	Uptime string `json:"uptime,omitempty"`
+	// LastHeartbeatTime is the time of the last heartbeat of the store received by PD in minutes,
+	// the heartbeats within a minute are not updated to the status
+	// +optional
+	LastHeartbeatTime *metav1.Time `json:"lastHeartbeatTime,omitempty"`
+}
+
+// TiKVStoreSummary is the summary of the stores of a TiKV cluster
+type TiKVStoreSummary struct {
+	// StoreCount is the number of stores
+	StoreCount int32 `json:"storeCount"`
+	// UpStoreCount is the number of stores in Up state
+	UpStoreCount int32 `json:"upStoreCount"`
+	// RegionCount is the total number of region peers
+	RegionCount int32 `json:"regionCount"`
+	// LeaderCount is the total number of region leaders
+	LeaderCount int32 `json:"leaderCount"`
+	// Capacity is the total capacity of the stores
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// Available is the total available space of the stores
+	// +optional
+	Available *resource.Quantity `json:"available,omitempty"`
+	// UsedSize is the total space used by the data of the stores
+	// +optional
+	UsedSize *resource.Quantity `json:"usedSize,omitempty"`
+	// LeaderImbalance is the difference between the max and the min leader count of the stores in Up state
+	LeaderImbalance int32 `json:"leaderImbalance"`
+	// RegionImbalance is the difference between the max and the min region count of the stores in Up state
+	RegionImbalance int32 `json:"regionImbalance"`
 }
 
 // TiKVFailureStore is the tikv failure store information
@@ -1226,6 +1443,8 @@ const (
 	BackupStorageTypeS3 BackupStorageType = "s3"
 	// BackupStorageTypeGcs represents the google cloud storage
 	BackupStorageTypeGcs BackupStorageType = "gcs"
+	// BackupStorageTypeAzblob represents the azure blob storage
+	BackupStorageTypeAzblob BackupStorageType = "azblob"
 	// BackupStorageTypeLocal represents local volume storage type
 	BackupStorageTypeLocal BackupStorageType = "local"
 	// BackupStorageTypeUnknown represents the unknown storage type
@@ -1246,9 +1465,10 @@ const (
 // StorageProvider defines the configuration for storing a backup in backend storage.
 // +k8s:openapi-gen=true
 type StorageProvider struct {
-	S3    *S3StorageProvider    `json:"s3,omitempty"`
-	Gcs   *GcsStorageProvider   `json:"gcs,omitempty"`
-	Local *LocalStorageProvider `json:"local,omitempty"`
+	S3     *S3StorageProvider     `json:"s3,omitempty"`
+	Gcs    *GcsStorageProvider    `json:"gcs,omitempty"`
+	Azblob *AzblobStorageProvider `json:"azblob,omitempty"`
+	Local  *LocalStorageProvider  `json:"local,omitempty"`
 }
 
 // LocalStorageProvider defines local storage options, which can be any k8s supported mounted volume
@@ -1312,6 +1532,32 @@ type GcsStorageProvider struct {
 	Prefix string `json:"prefix,omitempty"`
 }
 
+// +k8s:openapi-gen=true
+// AzblobStorageProvider represents the azure blob storage for storing backups.
+type AzblobStorageProvider struct {
+	// StorageAccount is the name of the azure storage account.
+	// It can be omitted if the secret provides AZURE_STORAGE_ACCOUNT.
+	StorageAccount string `json:"storageAccount,omitempty"`
+	// Path is the full path where the backup is saved.
+	// The format of the path must be: "<container-name>/<path-to-backup-file>"
+	Path string `json:"path,omitempty"`
+	// Container in which to store the backup data.
+	Container string `json:"container,omitempty"`
+	// AccessTier of the uploaded objects, one of Hot, Cool or Archive.
+	// If empty, the default access tier of the storage account is used.
+	AccessTier string `json:"accessTier,omitempty"`
+	// Endpoint of the blob service, e.g. the address of an Azurite emulator.
+	// If empty, https://<storageAccount>.blob.core.windows.net is used.
+	Endpoint string `json:"endpoint,omitempty"`
+	// SecretName is the name of secret which stores the azure credentials,
+	// either AZURE_STORAGE_KEY for a shared key or AZURE_CLIENT_ID,
+	// AZURE_CLIENT_SECRET and AZURE_TENANT_ID for a service principal.
+	// If empty, the managed identity of the pod is used.
+	SecretName string `json:"secretName,omitempty"`
+	// Prefix of the data path.
+	Prefix string `json:"prefix,omitempty"`
+}
+
 // BackupType represents the backup type.
 // +k8s:openapi-gen=true
 type BackupType string
@@ -1459,6 +1705,12 @@ type BackupSpec struct {
 
 	// PriorityClassName of Backup Job Pods
 	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Verify checks the backupmeta and the checksums of the backup files offline once the backup completes.
+	// A completed backup can also be verified again by setting the `tidb.pingcap.com/verify-request-time`
+	// annotation to the RFC3339 request time. Only BR backups can be verified.
+	// +optional
+	Verify bool `json:"verify,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -1491,6 +1743,10 @@ type BRConfig struct {
 	RateLimit *uint `json:"rateLimit,omitempty"`
 	// TimeAgo is the history version of the backup task, e.g. 1m, 1h
 	TimeAgo string `json:"timeAgo,omitempty"`
+	// LastBackupTS is the commit TS of the parent backup, BR takes an incremental backup of the changes
+	// after it if set. It is only used by Backup.
+	// +optional
+	LastBackupTS string `json:"lastBackupTS,omitempty"`
 	// Checksum specifies whether to run checksum after backup
 	Checksum *bool `json:"checksum,omitempty"`
 	// SendCredToTikv specifies whether to send credentials to TiKV
@@ -1548,11 +1804,75 @@ type BackupStatus struct {
 	BackupSize int64 `json:"backupSize"`
 	// CommitTs is the snapshot time point of tidb cluster.
 	CommitTs string `json:"commitTs"`
+	// ParentBackup is the name of the backup which this incremental backup is chained from,
+	// empty for a full backup.
+	// +optional
+	ParentBackup string `json:"parentBackup,omitempty"`
+	// Progress is the latest progress reported by BR while the backup is running.
+	// +optional
+	Progress *BRProgress `json:"progress,omitempty"`
+	// LastError is the last error line printed by BR when the backup failed.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// Verify is the result of the latest verification of the backup.
+	// +optional
+	Verify *BackupVerifyStatus `json:"verify,omitempty"`
 	// Phase is a user readable state inferred from the underlying Backup conditions
 	Phase      BackupConditionType `json:"phase"`
 	Conditions []BackupCondition   `json:"conditions"`
 }
 
+// BackupVerifyPhase is the phase of the verification of a backup.
+type BackupVerifyPhase string
+
+const (
+	// BackupVerifyRunning means the verify job has been created for the request.
+	BackupVerifyRunning BackupVerifyPhase = "Running"
+	// BackupVerifyPassed means the backupmeta and all backup files are intact.
+	BackupVerifyPassed BackupVerifyPhase = "Passed"
+	// BackupVerifyFailed means the backup is not restorable or the verification could not finish.
+	BackupVerifyFailed BackupVerifyPhase = "Failed"
+)
+
+// BackupVerifyStatus is the result of the verification of a backup.
+type BackupVerifyStatus struct {
+	// Phase is the phase of the verification.
+	Phase BackupVerifyPhase `json:"phase"`
+	// RequestTime is the time of the verification request served by this verification.
+	RequestTime metav1.Time `json:"requestTime"`
+	// TimeStarted is the time at which the verification was started.
+	// +optional
+	TimeStarted *metav1.Time `json:"timeStarted,omitempty"`
+	// TimeCompleted is the time at which the verification passed or failed.
+	// +optional
+	TimeCompleted *metav1.Time `json:"timeCompleted,omitempty"`
+	// Message is the summary of the checked files if passed, or the details of the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BRProgress is the progress of a BR backup or restore parsed from the output of BR.
+// +k8s:openapi-gen=true
+type BRProgress struct {
+	// Step is the step that BR is running, e.g. "Full backup" or "Checksum".
+	Step string `json:"step,omitempty"`
+	// Percentage of the step that has been done, e.g. "45.00%".
+	Percentage string `json:"percentage,omitempty"`
+	// Completed is the number of units processed in the step, the unit
+	// depends on the step, e.g. ranges for backup and files for restore.
+	Completed int64 `json:"completed,omitempty"`
+	// Total is the number of units to process in the step.
+	Total int64 `json:"total,omitempty"`
+	// ProcessedBytes is the size of the key-value data processed so far.
+	ProcessedBytes int64 `json:"processedBytes,omitempty"`
+	// ETA is the estimated time at which the step finishes.
+	// +optional
+	ETA *metav1.Time `json:"eta,omitempty"`
+	// LastUpdateTime is the time at which the progress was reported.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -1593,6 +1913,10 @@ type BackupScheduleSpec struct {
 	MaxBackups *int32 `json:"maxBackups,omitempty"`
 	// MaxReservedTime is to specify how long backups we want to keep.
 	MaxReservedTime *string `json:"maxReservedTime,omitempty"`
+	// TieredRetention keeps backups by the daily, weekly and monthly tiers their timestamps fall in.
+	// If it is set, MaxBackups and MaxReservedTime are ignored.
+	// +optional
+	TieredRetention *TieredRetentionPolicy `json:"tieredRetention,omitempty"`
 	// BackupTemplate is the specification of the backup structure to get scheduled.
 	BackupTemplate BackupSpec `json:"backupTemplate"`
 	// The storageClassName of the persistent volume for Backup data storage if not storage class name set in BackupSpec.
@@ -1604,6 +1928,24 @@ type BackupScheduleSpec struct {
 	// ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.
 	// +optional
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// VerifySchedule specifies the cron string used for verifying the newest completed backup
+	// of this schedule, only BR backups can be verified.
+	// +optional
+	VerifySchedule string `json:"verifySchedule,omitempty"`
+	// FullBackupInterval makes the schedule take incremental BR backups chained from the previous
+	// completed backup, a full backup is taken when the full backup of the chain is older than this
+	// duration, e.g. 168h. Full backups are always taken if not set. The retention policy never cleans
+	// a backup which a retained incremental backup is chained from.
+	// +optional
+	FullBackupInterval *string `json:"fullBackupInterval,omitempty"`
+	// ConcurrencyPolicy specifies how to treat a scheduled backup when the last backup is still running.
+	// Defaults to Forbid.
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicyType `json:"concurrencyPolicy,omitempty"`
+	// UnhealthyClusterPolicy specifies how to treat a scheduled backup when the TidbCluster of the
+	// BR backup is not Ready or is upgrading. Defaults to Ignore.
+	// +optional
+	UnhealthyClusterPolicy UnhealthyClusterPolicyType `json:"unhealthyClusterPolicy,omitempty"`
 }
 
 // BackupScheduleStatus represents the current state of a BackupSchedule.
@@ -1614,6 +1956,93 @@ type BackupScheduleStatus struct {
 	LastBackupTime *metav1.Time `json:"lastBackupTime"`
 	// AllBackupCleanTime represents the time when all backup entries are cleaned up
 	AllBackupCleanTime *metav1.Time `json:"allBackupCleanTime"`
+	// LastVerifyTime represents the last time the verification of a backup was requested.
+	// +optional
+	LastVerifyTime *metav1.Time `json:"lastVerifyTime,omitempty"`
+	// RetainedBackups are the backups kept by the tiered retention policy and the tiers they belong to.
+	// +optional
+	RetainedBackups []RetainedBackup `json:"retainedBackups,omitempty"`
+	// SkippedBackups are the most recent scheduled backups skipped because the cluster is unhealthy.
+	// +optional
+	SkippedBackups []SkippedBackup `json:"skippedBackups,omitempty"`
+}
+
+// TieredRetentionPolicy is a grandfather-father-son retention policy of a BackupSchedule.
+// For each tier, the newest completed backup of each of the most recent days, weeks or months
+// which have a completed backup is kept, so a backup can belong to several tiers. Weeks start
+// on Monday, days, weeks and months are in the time zone of the controller manager.
+// +k8s:openapi-gen=true
+type TieredRetentionPolicy struct {
+	// Daily is the number of daily backups to keep
+	// +optional
+	Daily *int32 `json:"daily,omitempty"`
+	// Weekly is the number of weekly backups to keep
+	// +optional
+	Weekly *int32 `json:"weekly,omitempty"`
+	// Monthly is the number of monthly backups to keep
+	// +optional
+	Monthly *int32 `json:"monthly,omitempty"`
+}
+
+// RetentionTier is a tier of the tiered retention policy a backup is kept for.
+type RetentionTier string
+
+const (
+	// RetentionTierDaily means the backup is the newest one of a retained day
+	RetentionTierDaily RetentionTier = "Daily"
+	// RetentionTierWeekly means the backup is the newest one of a retained week
+	RetentionTierWeekly RetentionTier = "Weekly"
+	// RetentionTierMonthly means the backup is the newest one of a retained month
+	RetentionTierMonthly RetentionTier = "Monthly"
+	// RetentionTierChain means the backup is kept for the retained incremental backups chained from it
+	RetentionTierChain RetentionTier = "Chain"
+)
+
+// RetainedBackup is a backup kept by the tiered retention policy.
+type RetainedBackup struct {
+	// Name is the name of the Backup
+	Name string `json:"name"`
+	// Tiers are the tiers the backup is kept for
+	Tiers []RetentionTier `json:"tiers"`
+}
+
+// ConcurrencyPolicyType represents how a BackupSchedule treats a scheduled backup when the last backup is still running
+type ConcurrencyPolicyType string
+
+const (
+	// ConcurrencyPolicyTypeForbid represents that the scheduled backup waits for the last backup,
+	// the newest missed schedule is taken once the last backup finishes
+	ConcurrencyPolicyTypeForbid ConcurrencyPolicyType = "Forbid"
+	// ConcurrencyPolicyTypeReplace represents that the last backup is deleted and replaced by the scheduled backup,
+	// which starts after the job of the last backup terminates. The last backup is waited for instead if other
+	// backups are chained from it.
+	ConcurrencyPolicyTypeReplace ConcurrencyPolicyType = "Replace"
+	// ConcurrencyPolicyTypeAllow represents that the scheduled backup runs along with the last backup
+	ConcurrencyPolicyTypeAllow ConcurrencyPolicyType = "Allow"
+)
+
+// UnhealthyClusterPolicyType represents how a BackupSchedule treats a scheduled backup when the cluster is unhealthy
+type UnhealthyClusterPolicyType string
+
+const (
+	// UnhealthyClusterPolicyTypeIgnore represents that the scheduled backup is taken regardless of the cluster health
+	UnhealthyClusterPolicyTypeIgnore UnhealthyClusterPolicyType = "Ignore"
+	// UnhealthyClusterPolicyTypeSkip represents that the scheduled backup is skipped and recorded in the status
+	UnhealthyClusterPolicyTypeSkip UnhealthyClusterPolicyType = "Skip"
+	// UnhealthyClusterPolicyTypeDelay represents that the scheduled backup waits for the cluster to be healthy,
+	// the newest missed schedule is taken once the cluster is healthy
+	UnhealthyClusterPolicyTypeDelay UnhealthyClusterPolicyType = "Delay"
+)
+
+// SkippedBackup is a scheduled backup skipped by a BackupSchedule.
+type SkippedBackup struct {
+	// ScheduledTime is the time the backup was scheduled at
+	ScheduledTime metav1.Time `json:"scheduledTime"`
+	// Reason is a brief CamelCase string why the backup was skipped
+	Reason string `json:"reason"`
+	// Message is a human readable message indicating details about the skip
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
@@ -1732,6 +2161,21 @@ type RestoreSpec struct {
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 	// TableFilter means Table filter expression for 'db.table' matching. BR supports this from v4.0.3.
 	TableFilter []string `json:"tableFilter,omitempty"`
+	// IncrementalPrefixes are the prefixes of the incremental BR backups restored in order after
+	// the full backup, they are read from the same storage as the full backup.
+	// +optional
+	IncrementalPrefixes []string `json:"incrementalPrefixes,omitempty"`
+	// BackupName is the name of a completed BR Backup in the same namespace to restore. The full and
+	// incremental backups it is chained from by status.parentBackup are restored in order before it,
+	// from the storage of the restore with the prefixes of the backups. IncrementalPrefixes must not be
+	// set along with it.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+	// Pitr recovers the cluster to a point in time by replaying the change log after the backup is restored.
+	// The nearest completed BR backup in the same bucket taken before the target is restored along with
+	// the backups it is chained from unless BackupName is set.
+	// +optional
+	Pitr *PitrSpec `json:"pitr,omitempty"`
 
 	// PodSecurityContext of the component
 	// +optional
@@ -1741,6 +2185,23 @@ type RestoreSpec struct {
 	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
+// PitrSpec describes the point-in-time recovery from a BR backup and the change log shipped after it.
+// +k8s:openapi-gen=true
+type PitrSpec struct {
+	// RestoredTs is the point in time to recover the cluster to, a TSO or a datetime in the format of
+	// "2006-01-02 15:04:05" in the time zone of the restore job. The restored backup must be taken before it.
+	RestoredTs string `json:"restoredTs"`
+	// LogPrefix is the prefix of the change log in the same storage as the backup. The change log is the
+	// binlog files written by Drainer with the `file` dest type, along with the savepoint file of Drainer
+	// which tells how far the log reaches, as shipped by the `changeLog` sidecar of the tidb-drainer chart.
+	LogPrefix string `json:"logPrefix"`
+	// LogToolImage specifies the tidb-binlog image providing Reparo to replay the change log,
+	// e.g. pingcap/tidb-binlog:v4.0.8. If it does not contain tag, Pod will use image 'LogToolImage:${TiKV_Version}'.
+	// Defaults to pingcap/tidb-binlog.
+	// +optional
+	LogToolImage string `json:"logToolImage,omitempty"`
+}
+
 // RestoreStatus represents the current status of a tidb cluster restore.
 type RestoreStatus struct {
 	// TimeStarted is the time at which the restore was started.
@@ -1749,6 +2210,16 @@ type RestoreStatus struct {
 	TimeCompleted metav1.Time `json:"timeCompleted"`
 	// CommitTs is the snapshot time point of tidb cluster.
 	CommitTs string `json:"commitTs"`
+	// Progress is the latest progress reported by BR while the restore is running.
+	// +optional
+	Progress *BRProgress `json:"progress,omitempty"`
+	// LastError is the last error line printed by BR when the restore failed.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// PitrRestoredTs is the TSO the cluster is recovered to by the change log, it is before the target
+	// of the point-in-time recovery if the change log does not reach the target.
+	// +optional
+	PitrRestoredTs string `json:"pitrRestoredTs,omitempty"`
 	// Phase is a user readable state inferred from the underlying Restore conditions
 	Phase      RestoreConditionType `json:"phase"`
 	Conditions []RestoreCondition   `json:"conditions"`
@@ -0,0 +1,377 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type configValueType string
+
+const (
+	configAny    configValueType = "any"
+	configString configValueType = "string"
+	configBool   configValueType = "bool"
+	configInt    configValueType = "integer"
+	configUint   configValueType = "non-negative integer"
+	configFloat  configValueType = "float"
+	configArray  configValueType = "array"
+	configTable  configValueType = "table"
+	// configMap is a table with arbitrary keys
+	configMap configValueType = "map"
+)
+
+// configSchema describes the keys and value types of a component config, it
+// is built from the typed config structs
+type configSchema struct {
+	valueType configValueType
+	fields    map[string]*configSchema
+}
+
+// versionedConfigKey is a config key that is only supported by some versions
+// of a component, it covers the key and its children. Keys missing from the
+// typed config structs can be added here to be treated as known.
+type versionedConfigKey struct {
+	key string
+	// since is the first version that supports the key, empty means all
+	since string
+	// removedIn is the first version that does not support the key anymore,
+	// empty means none
+	removedIn string
+}
+
+var (
+	pdConfigSchema   = newConfigSchema(reflect.TypeOf(v1alpha1.PDConfig{}))
+	tikvConfigSchema = newConfigSchema(reflect.TypeOf(v1alpha1.TiKVConfig{}))
+	tidbConfigSchema = newConfigSchema(reflect.TypeOf(v1alpha1.TiDBConfig{}))
+
+	pdVersionedConfigKeys = []versionedConfigKey{
+		{key: "dashboard", since: "v4.0.0"},
+		{key: "replication-mode", since: "v4.0.0"},
+	}
+	tikvVersionedConfigKeys = []versionedConfigKey{
+		{key: "backup", since: "v3.1.0"},
+		{key: "readpool.unified", since: "v4.0.0"},
+		{key: "storage.block-cache", since: "v4.0.0"},
+		{key: "raftstore.sync-log", removedIn: "v5.0.0"},
+	}
+	tidbVersionedConfigKeys = []versionedConfigKey{
+		{key: "isolation-read", since: "v4.0.0"},
+		{key: "enable-telemetry", since: "v4.0.2"},
+	}
+
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+func newConfigSchema(t reflect.Type) *configSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == durationType {
+		// written either as a number or a string like "10s"
+		return &configSchema{valueType: configAny}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &configSchema{valueType: configString}
+	case reflect.Bool:
+		return &configSchema{valueType: configBool}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &configSchema{valueType: configInt}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &configSchema{valueType: configUint}
+	case reflect.Float32, reflect.Float64:
+		return &configSchema{valueType: configFloat}
+	case reflect.Slice, reflect.Array:
+		return &configSchema{valueType: configArray}
+	case reflect.Map:
+		return &configSchema{valueType: configMap}
+	case reflect.Struct:
+		s := &configSchema{valueType: configTable, fields: map[string]*configSchema{}}
+		addStructFields(s, t)
+		return s
+	default:
+		return &configSchema{valueType: configAny}
+	}
+}
+
+func addStructFields(s *configSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := tagName(f.Tag.Get("toml"))
+		if name == "" {
+			name = tagName(f.Tag.Get("json"))
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && ft.Kind() == reflect.Struct {
+				addStructFields(s, ft)
+				continue
+			}
+			name = f.Name
+		}
+		s.fields[name] = newConfigSchema(f.Type)
+	}
+}
+
+func tagName(tag string) string {
+	return strings.Split(tag, ",")[0]
+}
+
+// configValidator validates the config of one component
+type configValidator struct {
+	component string
+	schema    *configSchema
+	versioned []versionedConfigKey
+	// version is nil if the image tag is not a semantic version
+	version   *semver.Version
+	unchecked []string
+
+	errs     field.ErrorList
+	warnings []string
+}
+
+// ValidateTidbClusterConfig validates the config of PD, TiKV and TiDB against
+// their typed config structs and the version-specific key lists. A value of
+// wrong type is an error, an unknown key and a key not supported by the
+// version of the component are returned as warnings, since the typed structs
+// lag behind the components. Keys listed in the annotation
+// AnnUncheckedConfigKeys are skipped.
+func ValidateTidbClusterConfig(tc *v1alpha1.TidbCluster) (field.ErrorList, []string) {
+	return validateTidbClusterConfig(nil, tc)
+}
+
+// ValidateUpdateTidbClusterConfig is ValidateTidbClusterConfig for the
+// configs changed by an update, so that an existing cluster is not blocked
+// by configs accepted before
+func ValidateUpdateTidbClusterConfig(old, tc *v1alpha1.TidbCluster) (field.ErrorList, []string) {
+	return validateTidbClusterConfig(old, tc)
+}
+
+func validateTidbClusterConfig(old, tc *v1alpha1.TidbCluster) (field.ErrorList, []string) {
+	allErrs := field.ErrorList{}
+	var warnings []string
+	unchecked := uncheckedConfigKeys(tc.Annotations)
+	path := field.NewPath("spec")
+
+	if tc.Spec.PD != nil && tc.Spec.PD.Config != nil {
+		var oldConfig *config.GenericConfig
+		if old != nil && old.Spec.PD != nil && old.Spec.PD.Config != nil {
+			oldConfig = old.Spec.PD.Config.GenericConfig
+		}
+		errs, ws := validateComponentConfig("pd", pdConfigSchema, pdVersionedConfigKeys, tc.PDVersion(), unchecked,
+			old != nil, oldConfig, tc.Spec.PD.Config.GenericConfig, path.Child("pd", "config"))
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, ws...)
+	}
+	if tc.Spec.TiKV != nil && tc.Spec.TiKV.Config != nil {
+		var oldConfig *config.GenericConfig
+		if old != nil && old.Spec.TiKV != nil && old.Spec.TiKV.Config != nil {
+			oldConfig = old.Spec.TiKV.Config.GenericConfig
+		}
+		errs, ws := validateComponentConfig("tikv", tikvConfigSchema, tikvVersionedConfigKeys, tc.TiKVVersion(), unchecked,
+			old != nil, oldConfig, tc.Spec.TiKV.Config.GenericConfig, path.Child("tikv", "config"))
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, ws...)
+	}
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.Config != nil {
+		var oldConfig *config.GenericConfig
+		if old != nil && old.Spec.TiDB != nil && old.Spec.TiDB.Config != nil {
+			oldConfig = old.Spec.TiDB.Config.GenericConfig
+		}
+		errs, ws := validateComponentConfig("tidb", tidbConfigSchema, tidbVersionedConfigKeys, imageVersion(tc.TiDBImage()), unchecked,
+			old != nil, oldConfig, tc.Spec.TiDB.Config.GenericConfig, path.Child("tidb", "config"))
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, ws...)
+	}
+	return allErrs, warnings
+}
+
+func validateComponentConfig(component string, schema *configSchema, versioned []versionedConfigKey, version string, unchecked []string,
+	update bool, old, conf *config.GenericConfig, path *field.Path) (field.ErrorList, []string) {
+	if conf == nil || (update && apiequality.Semantic.DeepEqual(old, conf)) {
+		return nil, nil
+	}
+	v := &configValidator{
+		component: component,
+		schema:    schema,
+		versioned: versioned,
+		unchecked: unchecked,
+	}
+	if ver, err := semver.NewVersion(version); err == nil {
+		v.version = ver
+	}
+	v.validateTable(v.schema, "", conf.Inner(), path)
+	return v.errs, v.warnings
+}
+
+func (v *configValidator) validateTable(schema *configSchema, prefix string, table map[string]interface{}, path *field.Path) {
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		v.validateValue(schema.fields[k], key, table[k], path)
+	}
+}
+
+func (v *configValidator) validateValue(schema *configSchema, key string, value interface{}, path *field.Path) {
+	if v.isUnchecked(key) {
+		return
+	}
+	versioned := v.checkVersion(key)
+	if schema == nil {
+		if !versioned {
+			v.warnings = append(v.warnings, fmt.Sprintf("%s: unknown %s config key %q, it may be misspelled or not known by the operator yet",
+				path.String(), v.component, key))
+		}
+		return
+	}
+
+	if !matchConfigValueType(schema.valueType, value) {
+		v.errs = append(v.errs, field.Invalid(path.Key(key), value, fmt.Sprintf("should be %s, but is %T", schema.valueType, value)))
+		return
+	}
+	if table, ok := value.(map[string]interface{}); ok && schema.valueType == configTable {
+		v.validateTable(schema, key, table, path)
+	}
+}
+
+// checkVersion warns if key is not supported by the version of the component,
+// and returns whether the key is listed in the version-specific keys
+func (v *configValidator) checkVersion(key string) bool {
+	for _, vk := range v.versioned {
+		if key != vk.key && !strings.HasPrefix(key, vk.key+".") {
+			continue
+		}
+		if key != vk.key || v.version == nil {
+			return true
+		}
+		if vk.since != "" && v.version.LessThan(semver.MustParse(vk.since)) {
+			v.warnings = append(v.warnings, fmt.Sprintf("%s config key %q is supported since %s, but the version is %s",
+				v.component, key, vk.since, v.version.Original()))
+		}
+		if vk.removedIn != "" && !v.version.LessThan(semver.MustParse(vk.removedIn)) {
+			v.warnings = append(v.warnings, fmt.Sprintf("%s config key %q is not supported since %s, but the version is %s",
+				v.component, key, vk.removedIn, v.version.Original()))
+		}
+		return true
+	}
+	return false
+}
+
+func (v *configValidator) isUnchecked(key string) bool {
+	for _, u := range v.unchecked {
+		if u == "*" || u == v.component {
+			return true
+		}
+		ukey := strings.TrimPrefix(u, v.component+".")
+		if ukey == u {
+			continue
+		}
+		if key == ukey || strings.HasPrefix(key, ukey+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func matchConfigValueType(t configValueType, value interface{}) bool {
+	if t == configAny {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return false
+	}
+	switch t {
+	case configString:
+		// sizes and durations are typed as string, but the components accept
+		// integers for them too
+		return v.Kind() == reflect.String || isInt(v)
+	case configBool:
+		return v.Kind() == reflect.Bool
+	case configInt:
+		return isInt(v)
+	case configUint:
+		return isInt(v) && (isUint(v) || v.Int() >= 0)
+	case configFloat:
+		return v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 || isInt(v)
+	case configArray:
+		return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+	case configTable, configMap:
+		return v.Kind() == reflect.Map
+	}
+	return false
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return isUint(v)
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func uncheckedConfigKeys(anns map[string]string) []string {
+	var keys []string
+	for _, k := range strings.Split(anns[label.AnnUncheckedConfigKeys], ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// imageVersion returns the tag of the image, or "latest" if there is none
+func imageVersion(image string) string {
+	colonIdx := strings.LastIndexByte(image, ':')
+	if colonIdx >= 0 {
+		return image[colonIdx+1:]
+	}
+	return "latest"
+}
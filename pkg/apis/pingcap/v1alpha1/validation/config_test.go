@@ -0,0 +1,171 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestValidateTidbClusterConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     string
+		annotations map[string]string
+		config      func(tc *v1alpha1.TidbCluster)
+		errs        []string
+		warnings    []string
+	}{
+		{
+			name:    "valid config",
+			version: "v4.0.9",
+			config: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("raftstore.raft-base-tick-interval", "1s")
+				tc.Spec.TiKV.Config.Set("storage.block-cache.capacity", "1GB")
+				tc.Spec.TiKV.Config.Set("rocksdb.max-background-jobs", int64(8))
+				tc.Spec.PD.Config.Set("schedule.leader-schedule-limit", int64(4))
+				tc.Spec.PD.Config.Set("replication.location-labels", []interface{}{"zone", "host"})
+				tc.Spec.TiDB.Config.Set("log.slow-threshold", int64(300))
+				tc.Spec.TiDB.Config.Set("labels", map[string]interface{}{"zone": "z1"})
+			},
+		},
+		{
+			name:    "wrong types",
+			version: "v4.0.9",
+			config: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("raftstore.sync-log", "true")
+				tc.Spec.PD.Config.Set("schedule.leader-schedule-limit", int64(-1))
+				tc.Spec.TiDB.Config.Set("log", "info")
+			},
+			errs: []string{
+				"spec.pd.config[schedule.leader-schedule-limit]",
+				"spec.tikv.config[raftstore.sync-log]",
+				"spec.tidb.config[log]",
+			},
+		},
+		{
+			name:    "unknown keys",
+			version: "v4.0.9",
+			config: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("raftstore.not-exist", int64(1))
+				tc.Spec.PD.Config.Set("not-exist", true)
+			},
+			warnings: []string{
+				`spec.pd.config: unknown pd config key "not-exist", it may be misspelled or not known by the operator yet`,
+				`spec.tikv.config: unknown tikv config key "raftstore.not-exist", it may be misspelled or not known by the operator yet`,
+			},
+		},
+		{
+			name:    "keys not supported by the version",
+			version: "v3.0.8",
+			config: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("storage.block-cache.capacity", "1GB")
+				tc.Spec.TiDB.Config.Set("isolation-read.engines", []interface{}{"tikv"})
+			},
+			warnings: []string{
+				`tikv config key "storage.block-cache" is supported since v4.0.0, but the version is v3.0.8`,
+				`tidb config key "isolation-read" is supported since v4.0.0, but the version is v3.0.8`,
+			},
+		},
+		{
+			name:    "removed keys",
+			version: "v5.0.0",
+			config: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("raftstore.sync-log", true)
+			},
+			warnings: []string{
+				`tikv config key "raftstore.sync-log" is not supported since v5.0.0, but the version is v5.0.0`,
+			},
+		},
+		{
+			name:    "non semantic version",
+			version: "nightly",
+			config: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("storage.block-cache.capacity", "1GB")
+			},
+		},
+		{
+			name:        "unchecked keys",
+			version:     "v4.0.9",
+			annotations: map[string]string{label.AnnUncheckedConfigKeys: "tikv.raftstore, pd"},
+			config: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("raftstore.sync-log", "true")
+				tc.Spec.TiKV.Config.Set("raftstore.not-exist", int64(1))
+				tc.Spec.PD.Config.Set("not-exist", true)
+				tc.Spec.TiDB.Config.Set("not-exist", true)
+			},
+			warnings: []string{
+				`spec.tidb.config: unknown tidb config key "not-exist", it may be misspelled or not known by the operator yet`,
+			},
+		},
+		{
+			name:        "all keys unchecked",
+			version:     "v4.0.9",
+			annotations: map[string]string{label.AnnUncheckedConfigKeys: "*"},
+			config: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("raftstore.sync-log", "true")
+				tc.Spec.TiDB.Config.Set("not-exist", true)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			tc := newTidbCluster()
+			tc.Spec.Version = tt.version
+			tc.Spec.PD.BaseImage = "pingcap/pd"
+			tc.Spec.TiKV.BaseImage = "pingcap/tikv"
+			tc.Spec.TiDB.BaseImage = "pingcap/tidb"
+			tc.Annotations = tt.annotations
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			tc.Spec.TiKV.Config = v1alpha1.NewTiKVConfig()
+			tc.Spec.TiDB.Config = v1alpha1.NewTiDBConfig()
+			tt.config(tc)
+
+			errs, warnings := ValidateTidbClusterConfig(tc)
+			g.Expect(errs).To(HaveLen(len(tt.errs)))
+			for i, err := range errs {
+				g.Expect(err.Field).To(Equal(tt.errs[i]))
+			}
+			g.Expect(warnings).To(ConsistOf(tt.warnings))
+		})
+	}
+}
+
+func TestValidateUpdateTidbClusterConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	old := newTidbCluster()
+	old.Spec.Version = "v4.0.9"
+	old.Spec.TiKV.Config = v1alpha1.NewTiKVConfig()
+	old.Spec.TiKV.Config.Set("raftstore.not-exist", int64(1))
+	old.Spec.TiDB.Config = v1alpha1.NewTiDBConfig()
+	old.Spec.TiDB.Config.Set("not-exist", true)
+
+	// unchanged configs are not validated again
+	tc := old.DeepCopy()
+	errs, warnings := ValidateUpdateTidbClusterConfig(old, tc)
+	g.Expect(errs).To(BeEmpty())
+	g.Expect(warnings).To(BeEmpty())
+
+	tc.Spec.TiDB.Config.Set("log.slow-threshold", "300")
+	errs, warnings = ValidateUpdateTidbClusterConfig(old, tc)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.tidb.config[log.slow-threshold]"))
+	g.Expect(warnings).To(ConsistOf(`spec.tidb.config: unknown tidb config key "not-exist", it may be misspelled or not known by the operator yet`))
+}
@@ -21,6 +21,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,7 +29,9 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/prometheus/common/model"
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -119,6 +122,19 @@ func validateTiDBClusterSpec(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path
 	if spec.PDAddresses != nil {
 		allErrs = append(allErrs, validatePDAddresses(spec.PDAddresses, fldPath.Child("pdAddresses"))...)
 	}
+	allErrs = append(allErrs, validateMaintenanceWindows(spec.MaintenanceWindows, fldPath.Child("maintenanceWindows"))...)
+	return allErrs
+}
+
+func validateMaintenanceWindows(windows []v1alpha1.MaintenanceWindow, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, w := range windows {
+		idxPath := fldPath.Index(i)
+		if _, err := cron.ParseStandard(w.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("schedule"), w.Schedule, fmt.Sprintf("must be a valid cron expression, err: %v", err)))
+		}
+		allErrs = append(allErrs, validateTimeDurationStr(&w.Duration, idxPath.Child("duration"))...)
+	}
 	return allErrs
 }
 
@@ -438,6 +454,8 @@ func ValidateCreateTidbCluster(tc *v1alpha1.TidbCluster) field.ErrorList {
 	// basic validation
 	allErrs = append(allErrs, ValidateTidbCluster(tc)...)
 	allErrs = append(allErrs, validateNewTidbClusterSpec(&tc.Spec, field.NewPath("spec"))...)
+	configErrs, _ := ValidateTidbClusterConfig(tc)
+	allErrs = append(allErrs, configErrs...)
 	return allErrs
 }
 
@@ -453,6 +471,8 @@ func ValidateUpdateTidbCluster(old, tc *v1alpha1.TidbCluster) field.ErrorList {
 	}
 	allErrs = append(allErrs, validateUpdatePDConfig(old.Spec.PD.Config, tc.Spec.PD.Config, field.NewPath("spec.pd.config"))...)
 	allErrs = append(allErrs, disallowUsingLegacyAPIInNewCluster(old, tc)...)
+	configErrs, _ := ValidateUpdateTidbClusterConfig(old, tc)
+	allErrs = append(allErrs, configErrs...)
 
 	return allErrs
 }
@@ -676,3 +696,386 @@ func validateStorageInfo(storage string, fldPath *field.Path) field.ErrorList {
 
 	return allErrs
 }
+
+// ValidateBackup validates a Backup
+func ValidateBackup(backup *v1alpha1.Backup) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateBackupSpec(&backup.Spec, field.NewPath("spec"))...)
+	if v, ok := backup.Annotations[label.AnnBackupVerifyRequestTime]; ok {
+		fldPath := field.NewPath("metadata", "annotations").Key(label.AnnBackupVerifyRequestTime)
+		if _, err := time.Parse(time.RFC3339, v); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, v, "must be a RFC3339 time"))
+		}
+		if backup.Spec.BR == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "only BR backups can be verified"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateUpdateBackup validates a Backup against the existing one to be updated,
+// see validateUpdate for which updates are validated.
+func ValidateUpdateBackup(old, backup *v1alpha1.Backup) field.ErrorList {
+	changed := !apiequality.Semantic.DeepEqual(old.Spec, backup.Spec) ||
+		old.Annotations[label.AnnBackupVerifyRequestTime] != backup.Annotations[label.AnnBackupVerifyRequestTime]
+	if !changed || backup.DeletionTimestamp != nil {
+		return field.ErrorList{}
+	}
+	return validateUpdate(ValidateBackup(old), ValidateBackup(backup))
+}
+
+// ValidateRestore validates a Restore
+func ValidateRestore(restore *v1alpha1.Restore) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	spec := &restore.Spec
+	if spec.BR == nil {
+		// restore with lightning, the target cluster must be accessed by tidb
+		allErrs = append(allErrs, validateTiDBAccessConfig(spec.To, true, fldPath.Child("to"))...)
+	} else {
+		allErrs = append(allErrs, validateTiDBAccessConfig(spec.To, false, fldPath.Child("to"))...)
+		allErrs = append(allErrs, validateBRConfig(spec.BR, spec.Type, fldPath.Child("br"))...)
+		allErrs = append(allErrs, validateBRBackupType(spec.Type, fldPath.Child("backupType"))...)
+		if spec.BR.LastBackupTS != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("br", "lastBackupTS"), "only used by Backup"))
+		}
+	}
+	allErrs = append(allErrs, validateTimeDurationStr(spec.TikvGCLifeTime, fldPath.Child("tikvGCLifeTime"))...)
+	allErrs = append(allErrs, validateStorageProvider(&spec.StorageProvider, spec.BR != nil, fldPath)...)
+	allErrs = append(allErrs, validateQuantityStr(spec.StorageSize, fldPath.Child("storageSize"))...)
+	if len(spec.IncrementalPrefixes) > 0 && spec.BR == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("incrementalPrefixes"), "only BR backups can be restored incrementally"))
+	}
+	if spec.BackupName != "" {
+		if spec.BR == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("backupName"), "only BR backups can be restored by name"))
+		}
+		if len(spec.IncrementalPrefixes) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("incrementalPrefixes"), "the incremental backups are resolved from backupName"))
+		}
+	}
+	if spec.Pitr != nil {
+		allErrs = append(allErrs, validatePitrSpec(spec, fldPath.Child("pitr"))...)
+	}
+	return allErrs
+}
+
+func validatePitrSpec(spec *v1alpha1.RestoreSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.BR == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "point-in-time recovery needs a BR backup"))
+	}
+	if spec.To == nil {
+		// the change log is replayed through tidb
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "to"), "point-in-time recovery needs to access tidb"))
+	}
+	if _, err := spec.Pitr.GetRestoredTSO(time.UTC); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("restoredTs"), spec.Pitr.RestoredTs, "must be a TSO or a datetime in the format of \"2006-01-02 15:04:05\""))
+	}
+	if spec.Pitr.LogPrefix == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("logPrefix"), "the prefix of the change log must be specified"))
+	}
+	return allErrs
+}
+
+// ValidateUpdateRestore validates a Restore against the existing one to be updated,
+// see validateUpdate for which updates are validated.
+func ValidateUpdateRestore(old, restore *v1alpha1.Restore) field.ErrorList {
+	if apiequality.Semantic.DeepEqual(old.Spec, restore.Spec) || restore.DeletionTimestamp != nil {
+		return field.ErrorList{}
+	}
+	return validateUpdate(ValidateRestore(old), ValidateRestore(restore))
+}
+
+// ValidateBackupSchedule validates a BackupSchedule
+func ValidateBackupSchedule(bs *v1alpha1.BackupSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	spec := &bs.Spec
+	if _, err := cron.ParseStandard(spec.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"), spec.Schedule, fmt.Sprintf("must be a valid cron expression, err: %v", err)))
+	}
+	if spec.MaxBackups != nil && *spec.MaxBackups < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackups"), *spec.MaxBackups, "must be greater than or equal to 0"))
+	}
+	allErrs = append(allErrs, validateTimeDurationStr(spec.MaxReservedTime, fldPath.Child("maxReservedTime"))...)
+	if spec.TieredRetention != nil {
+		allErrs = append(allErrs, validateTieredRetentionPolicy(spec.TieredRetention, fldPath.Child("tieredRetention"))...)
+	}
+	allErrs = append(allErrs, validateQuantityStr(spec.StorageSize, fldPath.Child("storageSize"))...)
+	allErrs = append(allErrs, validateBackupSpec(&spec.BackupTemplate, fldPath.Child("backupTemplate"))...)
+	if spec.VerifySchedule != "" {
+		if _, err := cron.ParseStandard(spec.VerifySchedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("verifySchedule"), spec.VerifySchedule, fmt.Sprintf("must be a valid cron expression, err: %v", err)))
+		}
+		if spec.BackupTemplate.BR == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("verifySchedule"), "only BR backups can be verified"))
+		}
+	}
+	if spec.FullBackupInterval != nil {
+		allErrs = append(allErrs, validateTimeDurationStr(spec.FullBackupInterval, fldPath.Child("fullBackupInterval"))...)
+		if spec.BackupTemplate.BR == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("fullBackupInterval"), "only BR backups can be incremental"))
+		} else if spec.BackupTemplate.BR.LastBackupTS != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("backupTemplate", "br", "lastBackupTS"), "is set by the schedule for incremental backups"))
+		}
+	}
+	switch spec.ConcurrencyPolicy {
+	case "", v1alpha1.ConcurrencyPolicyTypeForbid, v1alpha1.ConcurrencyPolicyTypeReplace, v1alpha1.ConcurrencyPolicyTypeAllow:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("concurrencyPolicy"), spec.ConcurrencyPolicy,
+			[]string{string(v1alpha1.ConcurrencyPolicyTypeForbid), string(v1alpha1.ConcurrencyPolicyTypeReplace), string(v1alpha1.ConcurrencyPolicyTypeAllow)}))
+	}
+	switch spec.UnhealthyClusterPolicy {
+	case "", v1alpha1.UnhealthyClusterPolicyTypeIgnore:
+	case v1alpha1.UnhealthyClusterPolicyTypeSkip, v1alpha1.UnhealthyClusterPolicyTypeDelay:
+		if spec.BackupTemplate.BR == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("unhealthyClusterPolicy"), "only the TidbCluster of BR backups can be checked"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("unhealthyClusterPolicy"), spec.UnhealthyClusterPolicy,
+			[]string{string(v1alpha1.UnhealthyClusterPolicyTypeIgnore), string(v1alpha1.UnhealthyClusterPolicyTypeSkip), string(v1alpha1.UnhealthyClusterPolicyTypeDelay)}))
+	}
+	return allErrs
+}
+
+// ValidateUpdateBackupSchedule validates a BackupSchedule against the existing one to be updated,
+// see validateUpdate for which updates are validated.
+func ValidateUpdateBackupSchedule(old, bs *v1alpha1.BackupSchedule) field.ErrorList {
+	if apiequality.Semantic.DeepEqual(old.Spec, bs.Spec) || bs.DeletionTimestamp != nil {
+		return field.ErrorList{}
+	}
+	return validateUpdate(ValidateBackupSchedule(old), ValidateBackupSchedule(bs))
+}
+
+// validateUpdate returns the errors of the updated object which the existing object does not have.
+// The status and finalizer updates by the controller are not validated at all, and the errors of
+// the existing object are tolerated, so that the objects created before a validation rule is added
+// or tightened can still be updated and deleted.
+func validateUpdate(oldErrs, allErrs field.ErrorList) field.ErrorList {
+	existing := map[string]bool{}
+	for _, err := range oldErrs {
+		existing[err.Error()] = true
+	}
+	newErrs := field.ErrorList{}
+	for _, err := range allErrs {
+		if !existing[err.Error()] {
+			newErrs = append(newErrs, err)
+		}
+	}
+	return newErrs
+}
+
+func validateTieredRetentionPolicy(policy *v1alpha1.TieredRetentionPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	var total int32
+	for _, tier := range []struct {
+		name  string
+		value *int32
+	}{
+		{"daily", policy.Daily},
+		{"weekly", policy.Weekly},
+		{"monthly", policy.Monthly},
+	} {
+		if tier.value == nil {
+			continue
+		}
+		if *tier.value < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(tier.name), *tier.value, "must be greater than or equal to 0"))
+			continue
+		}
+		total += *tier.value
+	}
+	if len(allErrs) == 0 && total == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one tier must keep backups"))
+	}
+	return allErrs
+}
+
+func validateBackupSpec(spec *v1alpha1.BackupSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.BR == nil {
+		// backup with dumpling, the source cluster must be accessed by tidb
+		allErrs = append(allErrs, validateTiDBAccessConfig(spec.From, true, fldPath.Child("from"))...)
+	} else {
+		allErrs = append(allErrs, validateTiDBAccessConfig(spec.From, false, fldPath.Child("from"))...)
+		allErrs = append(allErrs, validateBRConfig(spec.BR, spec.Type, fldPath.Child("br"))...)
+		allErrs = append(allErrs, validateBRBackupType(spec.Type, fldPath.Child("backupType"))...)
+	}
+	allErrs = append(allErrs, validateTimeDurationStr(spec.TikvGCLifeTime, fldPath.Child("tikvGCLifeTime"))...)
+	allErrs = append(allErrs, validateStorageProvider(&spec.StorageProvider, spec.BR != nil, fldPath)...)
+	allErrs = append(allErrs, validateQuantityStr(spec.StorageSize, fldPath.Child("storageSize"))...)
+	if spec.Verify && spec.BR == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("verify"), "only BR backups can be verified"))
+	}
+	return allErrs
+}
+
+// validateTiDBAccessConfig validates the config to access tidb, the host and the secret storing the password
+// are required if it is set or required.
+func validateTiDBAccessConfig(config *v1alpha1.TiDBAccessConfig, required bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config == nil {
+		if required {
+			allErrs = append(allErrs, field.Required(fldPath, "must be set to access the tidb cluster"))
+		}
+		return allErrs
+	}
+	if config.Host == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("host"), "must be set to access the tidb cluster"))
+	}
+	allErrs = append(allErrs, validateSecretName(config.SecretName, true, fldPath.Child("secretName"))...)
+	if config.TLSClientSecretName != nil {
+		allErrs = append(allErrs, validateSecretName(*config.TLSClientSecretName, true, fldPath.Child("tlsClientSecretName"))...)
+	}
+	return allErrs
+}
+
+func validateBRConfig(br *v1alpha1.BRConfig, backupType v1alpha1.BackupType, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if br.Cluster == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cluster"), "cluster should be configured for BR"))
+	}
+	if (backupType == v1alpha1.BackupTypeDB || backupType == v1alpha1.BackupTypeTable) && br.DB == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("db"), fmt.Sprintf("DB should be configured for BR with backup type %s", backupType)))
+	}
+	if backupType == v1alpha1.BackupTypeTable && br.Table == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("table"), "table should be configured for BR with backup type table"))
+	}
+	if br.TimeAgo != "" {
+		allErrs = append(allErrs, validateTimeDurationStr(&br.TimeAgo, fldPath.Child("timeAgo"))...)
+	}
+	if br.LastBackupTS != "" {
+		if _, err := strconv.ParseUint(br.LastBackupTS, 10, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("lastBackupTS"), br.LastBackupTS, "must be a TSO"))
+		}
+	}
+	return allErrs
+}
+
+func validateBRBackupType(backupType v1alpha1.BackupType, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch backupType {
+	case "", v1alpha1.BackupTypeFull, v1alpha1.BackupTypeDB, v1alpha1.BackupTypeTable:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, backupType, []string{
+			string(v1alpha1.BackupTypeFull), string(v1alpha1.BackupTypeDB), string(v1alpha1.BackupTypeTable),
+		}))
+	}
+	return allErrs
+}
+
+// validateStorageProvider validates that at most one storage backend is set and the set one is valid,
+// the bucket is required by BR.
+func validateStorageProvider(provider *v1alpha1.StorageProvider, isBR bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	var set []string
+	if provider.S3 != nil {
+		set = append(set, "s3")
+		allErrs = append(allErrs, validateS3StorageProvider(provider.S3, isBR, fldPath.Child("s3"))...)
+	}
+	if provider.Gcs != nil {
+		set = append(set, "gcs")
+		allErrs = append(allErrs, validateGcsStorageProvider(provider.Gcs, isBR, fldPath.Child("gcs"))...)
+	}
+	if provider.Azblob != nil {
+		set = append(set, "azblob")
+		allErrs = append(allErrs, validateAzblobStorageProvider(provider.Azblob, isBR, fldPath.Child("azblob"))...)
+	}
+	if provider.Local != nil {
+		set = append(set, "local")
+		allErrs = append(allErrs, validateLocalStorageProvider(provider.Local, fldPath.Child("local"))...)
+	}
+	if len(set) > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("only one storage provider may be set, but got %s", strings.Join(set, ", "))))
+	}
+	return allErrs
+}
+
+func validateS3StorageProvider(s3 *v1alpha1.S3StorageProvider, isBR bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if isBR && s3.Bucket == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("bucket"), "bucket should be configured for BR"))
+	}
+	if s3.Endpoint != "" {
+		u, err := url.Parse(s3.Endpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoint"), s3.Endpoint, "must be a valid URL with the scheme and the host, e.g. http://minio:9000"))
+		}
+	}
+	allErrs = append(allErrs, validateSecretName(s3.SecretName, false, fldPath.Child("secretName"))...)
+	return allErrs
+}
+
+func validateGcsStorageProvider(gcs *v1alpha1.GcsStorageProvider, isBR bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if gcs.ProjectId == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("projectId"), "projectId should be configured"))
+	}
+	if isBR && gcs.Bucket == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("bucket"), "bucket should be configured for BR"))
+	}
+	allErrs = append(allErrs, validateSecretName(gcs.SecretName, false, fldPath.Child("secretName"))...)
+	return allErrs
+}
+
+func validateAzblobStorageProvider(azblob *v1alpha1.AzblobStorageProvider, isBR bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if isBR && azblob.Container == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("container"), "container should be configured for BR"))
+	}
+	if azblob.StorageAccount == "" && azblob.SecretName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("storageAccount"), "storageAccount should be configured when using the managed identity"))
+	}
+	switch azblob.AccessTier {
+	case "", "Hot", "Cool", "Archive":
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("accessTier"), azblob.AccessTier, []string{"Hot", "Cool", "Archive"}))
+	}
+	if azblob.Endpoint != "" {
+		u, err := url.Parse(azblob.Endpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoint"), azblob.Endpoint, "must be a valid URL with the scheme and the host, e.g. http://azurite:10000/devstoreaccount1"))
+		}
+	}
+	allErrs = append(allErrs, validateSecretName(azblob.SecretName, false, fldPath.Child("secretName"))...)
+	return allErrs
+}
+
+func validateLocalStorageProvider(local *v1alpha1.LocalStorageProvider, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if local.VolumeMount.Name != local.Volume.Name {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("volumeMount", "name"), local.VolumeMount.Name, "must be the same as the name of the volume"))
+	}
+	if local.VolumeMount.MountPath == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("volumeMount", "mountPath"), ""))
+	} else if strings.Contains(local.VolumeMount.MountPath, ":") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("volumeMount", "mountPath"), local.VolumeMount.MountPath, "must not contain ':'"))
+	}
+	return allErrs
+}
+
+func validateSecretName(name string, required bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if name == "" {
+		if required {
+			allErrs = append(allErrs, field.Required(fldPath, "the secret must be specified"))
+		}
+		return allErrs
+	}
+	for _, msg := range apivalidation.NameIsDNSSubdomain(name, false) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, msg))
+	}
+	return allErrs
+}
+
+func validateQuantityStr(quantity string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if quantity == "" {
+		return allErrs
+	}
+	if _, err := resource.ParseQuantity(quantity); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, quantity, fmt.Sprintf("must be a valid quantity, err: %v", err)))
+	}
+	return allErrs
+}
@@ -656,3 +656,363 @@ func TestValidatePDAddresses(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateMaintenanceWindows(t *testing.T) {
+	successCases := [][]v1alpha1.MaintenanceWindow{
+		nil,
+		{
+			{Schedule: "0 2 * * 6", Duration: "4h"},
+			{Schedule: "@daily", Duration: "30m"},
+		},
+	}
+
+	for _, c := range successCases {
+		errs := validateMaintenanceWindows(c, field.NewPath("maintenanceWindows"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := [][]v1alpha1.MaintenanceWindow{
+		{
+			{Schedule: "0 2 * *", Duration: "4h"},
+		},
+		{
+			{Schedule: "0 2 * * 6", Duration: "4"},
+		},
+		{
+			{Schedule: "0 2 * * 6", Duration: "-1h"},
+		},
+	}
+
+	for _, c := range errorCases {
+		errs := validateMaintenanceWindows(c, field.NewPath("maintenanceWindows"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidateBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		update         func(*v1alpha1.BackupSpec)
+		expectedFields []string
+	}{
+		{
+			name:   "valid BR backup",
+			update: func(spec *v1alpha1.BackupSpec) {},
+		},
+		{
+			name: "valid dumpling backup",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.BR = nil
+				spec.From = &v1alpha1.TiDBAccessConfig{Host: "demo-tidb", SecretName: "demo-secret"}
+			},
+		},
+		{
+			name: "multiple storage providers",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.Gcs = &v1alpha1.GcsStorageProvider{ProjectId: "project", Bucket: "bucket"}
+			},
+			expectedFields: []string{"spec"},
+		},
+		{
+			name: "BR without cluster",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.BR.Cluster = ""
+			},
+			expectedFields: []string{"spec.br.cluster"},
+		},
+		{
+			name: "BR with invalid backup type and time ago",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.Type = "unknown"
+				spec.BR.TimeAgo = "1"
+			},
+			expectedFields: []string{"spec.br.timeAgo", "spec.backupType"},
+		},
+		{
+			name: "BR with invalid last backup TS",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.BR.LastBackupTS = "2021-03-01"
+			},
+			expectedFields: []string{"spec.br.lastBackupTS"},
+		},
+		{
+			name: "dumpling without the tidb secret",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.BR = nil
+				spec.From = &v1alpha1.TiDBAccessConfig{Host: "demo-tidb"}
+			},
+			expectedFields: []string{"spec.from.secretName"},
+		},
+		{
+			name: "dumpling without the source cluster",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.BR = nil
+			},
+			expectedFields: []string{"spec.from"},
+		},
+		{
+			name: "verify dumpling backup",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.BR = nil
+				spec.From = &v1alpha1.TiDBAccessConfig{Host: "demo-tidb", SecretName: "demo-secret"}
+				spec.Verify = true
+			},
+			expectedFields: []string{"spec.verify"},
+		},
+		{
+			name: "invalid s3 and durations",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.S3.Bucket = ""
+				spec.S3.Endpoint = "minio:9000"
+				spec.S3.SecretName = "S3_Secret"
+				spec.TikvGCLifeTime = pointer.StringPtr("72")
+				spec.StorageSize = "10G1"
+			},
+			expectedFields: []string{"spec.tikvGCLifeTime", "spec.s3.bucket", "spec.s3.endpoint", "spec.s3.secretName", "spec.storageSize"},
+		},
+		{
+			name: "valid azblob with managed identity",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.S3 = nil
+				spec.Azblob = &v1alpha1.AzblobStorageProvider{StorageAccount: "account", Container: "backup", AccessTier: "Cool"}
+			},
+		},
+		{
+			name: "invalid azblob",
+			update: func(spec *v1alpha1.BackupSpec) {
+				spec.S3 = nil
+				spec.Azblob = &v1alpha1.AzblobStorageProvider{AccessTier: "Cold", Endpoint: "azurite:10000"}
+			},
+			expectedFields: []string{"spec.azblob.container", "spec.azblob.storageAccount", "spec.azblob.accessTier", "spec.azblob.endpoint"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := newBackup()
+			tt.update(&backup.Spec)
+			errs := ValidateBackup(backup)
+			var fields []string
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
+
+func TestValidateUpdateBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// created before the endpoint is validated
+	old := newBackup()
+	old.Spec.S3.Endpoint = "minio:9000"
+	g.Expect(ValidateBackup(old)).To(HaveLen(1))
+
+	// status and finalizer updates are not validated
+	backup := old.DeepCopy()
+	backup.Status.Phase = v1alpha1.BackupComplete
+	backup.Finalizers = nil
+	g.Expect(ValidateUpdateBackup(old, backup)).To(BeEmpty())
+
+	// the existing errors are tolerated
+	backup.Spec.S3.Prefix = "backup"
+	g.Expect(ValidateUpdateBackup(old, backup)).To(BeEmpty())
+
+	// the new errors are rejected
+	backup.Spec.S3.Endpoint = "minio"
+	errs := ValidateUpdateBackup(old, backup)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.s3.endpoint"))
+
+	// deleting objects are not validated
+	now := metav1.Now()
+	backup.DeletionTimestamp = &now
+	g.Expect(ValidateUpdateBackup(old, backup)).To(BeEmpty())
+}
+
+func TestValidateRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	restore := &v1alpha1.Restore{
+		Spec: v1alpha1.RestoreSpec{
+			BR: &v1alpha1.BRConfig{Cluster: "demo"},
+			StorageProvider: v1alpha1.StorageProvider{
+				Local: &v1alpha1.LocalStorageProvider{
+					Volume:      corev1.Volume{Name: "nfs"},
+					VolumeMount: corev1.VolumeMount{Name: "nfs", MountPath: "/nfs"},
+				},
+			},
+		},
+	}
+	g.Expect(ValidateRestore(restore)).To(BeEmpty())
+
+	restore.Spec.Type = v1alpha1.BackupTypeTable
+	restore.Spec.Local.VolumeMount.MountPath = "/nfs:/backup"
+	errs := ValidateRestore(restore)
+	g.Expect(errs).To(HaveLen(3))
+	g.Expect(errs[0].Field).To(Equal("spec.br.db"))
+	g.Expect(errs[1].Field).To(Equal("spec.br.table"))
+	g.Expect(errs[2].Field).To(Equal("spec.local.volumeMount.mountPath"))
+
+	restore.Spec.Type = ""
+	restore.Spec.Local.VolumeMount.MountPath = "/nfs"
+	restore.Spec.IncrementalPrefixes = []string{"incr-1", "incr-2"}
+	g.Expect(ValidateRestore(restore)).To(BeEmpty())
+	restore.Spec.BackupName = "demo-backup"
+	errs = ValidateRestore(restore)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.incrementalPrefixes"))
+	restore.Spec.IncrementalPrefixes = nil
+	g.Expect(ValidateRestore(restore)).To(BeEmpty())
+	restore.Spec.BackupName = ""
+	restore.Spec.IncrementalPrefixes = []string{"incr-1", "incr-2"}
+
+	restore.Spec.BR.LastBackupTS = "421762809912885249"
+	errs = ValidateRestore(restore)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.br.lastBackupTS"))
+
+	restore.Spec.BR.LastBackupTS = ""
+	restore.Spec.Pitr = &v1alpha1.PitrSpec{RestoredTs: "2021-01-02T15:04:05"}
+	errs = ValidateRestore(restore)
+	g.Expect(errs).To(HaveLen(3))
+	g.Expect(errs[0].Field).To(Equal("spec.to"))
+	g.Expect(errs[1].Field).To(Equal("spec.pitr.restoredTs"))
+	g.Expect(errs[2].Field).To(Equal("spec.pitr.logPrefix"))
+
+	restore.Spec.To = &v1alpha1.TiDBAccessConfig{Host: "demo-tidb", SecretName: "demo-tidb-secret"}
+	restore.Spec.Pitr = &v1alpha1.PitrSpec{RestoredTs: "2021-01-02 15:04:05", LogPrefix: "binlog"}
+	g.Expect(ValidateRestore(restore)).To(BeEmpty())
+	restore.Spec.Pitr.RestoredTs = "421762809912885249"
+	g.Expect(ValidateRestore(restore)).To(BeEmpty())
+	restore.Spec.To = nil
+	restore.Spec.Pitr = nil
+
+	restore.Spec.BR = nil
+	restore.Spec.IncrementalPrefixes = nil
+	errs = ValidateRestore(restore)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.to"))
+}
+
+func TestValidateBackupSchedule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bs := &v1alpha1.BackupSchedule{
+		Spec: v1alpha1.BackupScheduleSpec{
+			Schedule:        "0 */2 * * *",
+			MaxReservedTime: pointer.StringPtr("72h"),
+			BackupTemplate:  newBackup().Spec,
+		},
+	}
+	g.Expect(ValidateBackupSchedule(bs)).To(BeEmpty())
+
+	bs.Spec.Schedule = "0 */2 * *"
+	bs.Spec.MaxBackups = pointer.Int32Ptr(-1)
+	bs.Spec.MaxReservedTime = pointer.StringPtr("3d")
+	bs.Spec.BackupTemplate.BR.Cluster = ""
+	errs := ValidateBackupSchedule(bs)
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	g.Expect(fields).To(Equal([]string{
+		"spec.schedule",
+		"spec.maxBackups",
+		"spec.maxReservedTime",
+		"spec.backupTemplate.br.cluster",
+	}))
+
+	bs.Spec.Schedule = "0 */2 * * *"
+	bs.Spec.MaxBackups = nil
+	bs.Spec.MaxReservedTime = nil
+	bs.Spec.BackupTemplate.BR.Cluster = "demo"
+	bs.Spec.VerifySchedule = "0 3 * * 0"
+	g.Expect(ValidateBackupSchedule(bs)).To(BeEmpty())
+
+	bs.Spec.VerifySchedule = "0 3 * *"
+	errs = ValidateBackupSchedule(bs)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.verifySchedule"))
+
+	bs.Spec.VerifySchedule = ""
+	bs.Spec.FullBackupInterval = pointer.StringPtr("168h")
+	g.Expect(ValidateBackupSchedule(bs)).To(BeEmpty())
+
+	bs.Spec.FullBackupInterval = pointer.StringPtr("7d")
+	bs.Spec.BackupTemplate.BR.LastBackupTS = "421762809912885249"
+	errs = ValidateBackupSchedule(bs)
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs[0].Field).To(Equal("spec.fullBackupInterval"))
+	g.Expect(errs[1].Field).To(Equal("spec.backupTemplate.br.lastBackupTS"))
+
+	bs.Spec.FullBackupInterval = nil
+	bs.Spec.BackupTemplate.BR.LastBackupTS = ""
+	bs.Spec.TieredRetention = &v1alpha1.TieredRetentionPolicy{
+		Daily:   pointer.Int32Ptr(7),
+		Weekly:  pointer.Int32Ptr(4),
+		Monthly: pointer.Int32Ptr(12),
+	}
+	g.Expect(ValidateBackupSchedule(bs)).To(BeEmpty())
+
+	bs.Spec.TieredRetention.Weekly = pointer.Int32Ptr(-1)
+	errs = ValidateBackupSchedule(bs)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.tieredRetention.weekly"))
+
+	bs.Spec.TieredRetention = &v1alpha1.TieredRetentionPolicy{Daily: pointer.Int32Ptr(0)}
+	errs = ValidateBackupSchedule(bs)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.tieredRetention"))
+
+	bs.Spec.TieredRetention = nil
+	bs.Spec.ConcurrencyPolicy = v1alpha1.ConcurrencyPolicyTypeReplace
+	bs.Spec.UnhealthyClusterPolicy = v1alpha1.UnhealthyClusterPolicyTypeSkip
+	g.Expect(ValidateBackupSchedule(bs)).To(BeEmpty())
+
+	bs.Spec.ConcurrencyPolicy = "Queue"
+	bs.Spec.UnhealthyClusterPolicy = "Wait"
+	errs = ValidateBackupSchedule(bs)
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs[0].Field).To(Equal("spec.concurrencyPolicy"))
+	g.Expect(errs[1].Field).To(Equal("spec.unhealthyClusterPolicy"))
+}
+
+func TestValidateBackupVerifyRequest(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := newBackup()
+	backup.Annotations = map[string]string{label.AnnBackupVerifyRequestTime: "2021-03-01T08:00:00Z"}
+	g.Expect(ValidateBackup(backup)).To(BeEmpty())
+
+	backup.Annotations[label.AnnBackupVerifyRequestTime] = "2021-03-01 08:00:00"
+	errs := ValidateBackup(backup)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("metadata.annotations[tidb.pingcap.com/verify-request-time]"))
+}
+
+func newBackup() *v1alpha1.Backup {
+	return &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "demo-backup",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.BackupSpec{
+			BR: &v1alpha1.BRConfig{
+				Cluster: "demo",
+			},
+			StorageProvider: v1alpha1.StorageProvider{
+				S3: &v1alpha1.S3StorageProvider{
+					Provider: v1alpha1.S3StorageProviderTypeAWS,
+					Bucket:   "bucket",
+					Endpoint: "http://minio:9000",
+				},
+			},
+		},
+	}
+}
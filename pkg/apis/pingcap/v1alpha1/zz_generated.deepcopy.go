@@ -79,6 +79,22 @@ func (in *AutoRule) DeepCopy() *AutoRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzblobStorageProvider) DeepCopyInto(out *AzblobStorageProvider) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzblobStorageProvider.
+func (in *AzblobStorageProvider) DeepCopy() *AzblobStorageProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(AzblobStorageProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BRConfig) DeepCopyInto(out *BRConfig) {
 	*out = *in
@@ -125,6 +141,30 @@ func (in *BRConfig) DeepCopy() *BRConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BRProgress) DeepCopyInto(out *BRProgress) {
+	*out = *in
+	if in.ETA != nil {
+		in, out := &in.ETA, &out.ETA
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BRProgress.
+func (in *BRProgress) DeepCopy() *BRProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(BRProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backup) DeepCopyInto(out *Backup) {
 	*out = *in
@@ -277,6 +317,11 @@ func (in *BackupScheduleSpec) DeepCopyInto(out *BackupScheduleSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TieredRetention != nil {
+		in, out := &in.TieredRetention, &out.TieredRetention
+		*out = new(TieredRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
 	if in.StorageClassName != nil {
 		in, out := &in.StorageClassName, &out.StorageClassName
@@ -288,6 +333,11 @@ func (in *BackupScheduleSpec) DeepCopyInto(out *BackupScheduleSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.FullBackupInterval != nil {
+		in, out := &in.FullBackupInterval, &out.FullBackupInterval
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -312,6 +362,24 @@ func (in *BackupScheduleStatus) DeepCopyInto(out *BackupScheduleStatus) {
 		in, out := &in.AllBackupCleanTime, &out.AllBackupCleanTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastVerifyTime != nil {
+		in, out := &in.LastVerifyTime, &out.LastVerifyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RetainedBackups != nil {
+		in, out := &in.RetainedBackups, &out.RetainedBackups
+		*out = make([]RetainedBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SkippedBackups != nil {
+		in, out := &in.SkippedBackups, &out.SkippedBackups
+		*out = make([]SkippedBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -412,6 +480,16 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 	*out = *in
 	in.TimeStarted.DeepCopyInto(&out.TimeStarted)
 	in.TimeCompleted.DeepCopyInto(&out.TimeCompleted)
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(BRProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(BackupVerifyStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]BackupCondition, len(*in))
@@ -432,6 +510,31 @@ func (in *BackupStatus) DeepCopy() *BackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerifyStatus) DeepCopyInto(out *BackupVerifyStatus) {
+	*out = *in
+	in.RequestTime.DeepCopyInto(&out.RequestTime)
+	if in.TimeStarted != nil {
+		in, out := &in.TimeStarted, &out.TimeStarted
+		*out = (*in).DeepCopy()
+	}
+	if in.TimeCompleted != nil {
+		in, out := &in.TimeCompleted, &out.TimeCompleted
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerifyStatus.
+func (in *BackupVerifyStatus) DeepCopy() *BackupVerifyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerifyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
@@ -1451,6 +1554,24 @@ func (in *DumplingConfig) DeepCopy() *DumplingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictLeaderStatus) DeepCopyInto(out *EvictLeaderStatus) {
+	*out = *in
+	in.PodCreateTime.DeepCopyInto(&out.PodCreateTime)
+	in.BeginTime.DeepCopyInto(&out.BeginTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictLeaderStatus.
+func (in *EvictLeaderStatus) DeepCopy() *EvictLeaderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictLeaderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Experimental) DeepCopyInto(out *Experimental) {
 	*out = *in
@@ -2265,6 +2386,47 @@ func (in *LogTailerSpec) DeepCopy() *LogTailerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceStatus) DeepCopyInto(out *MaintenanceStatus) {
+	*out = *in
+	if in.NextWindowTime != nil {
+		in, out := &in.NextWindowTime, &out.NextWindowTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingOperations != nil {
+		in, out := &in.PendingOperations, &out.PendingOperations
+		*out = make([]PendingMaintenanceOperation, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceStatus.
+func (in *MaintenanceStatus) DeepCopy() *MaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MasterConfig) DeepCopyInto(out *MasterConfig) {
 	*out = *in
@@ -2564,6 +2726,28 @@ func (in *Networks) DeepCopy() *Networks {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineConfigStatus) DeepCopyInto(out *OnlineConfigStatus) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastAppliedTime.DeepCopyInto(&out.LastAppliedTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineConfigStatus.
+func (in *OnlineConfigStatus) DeepCopy() *OnlineConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenTracing) DeepCopyInto(out *OpenTracing) {
 	*out = *in
@@ -3456,6 +3640,11 @@ func (in *PDStatus) DeepCopyInto(out *PDStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.OnlineConfig != nil {
+		in, out := &in.OnlineConfig, &out.OnlineConfig
+		*out = new(OnlineConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3517,6 +3706,22 @@ func (in PDStoreLabels) DeepCopy() PDStoreLabels {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingMaintenanceOperation) DeepCopyInto(out *PendingMaintenanceOperation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingMaintenanceOperation.
+func (in *PendingMaintenanceOperation) DeepCopy() *PendingMaintenanceOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingMaintenanceOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Performance) DeepCopyInto(out *Performance) {
 	*out = *in
@@ -3644,6 +3849,22 @@ func (in *PessimisticTxn) DeepCopy() *PessimisticTxn {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PitrSpec) DeepCopyInto(out *PitrSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PitrSpec.
+func (in *PitrSpec) DeepCopy() *PitrSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PitrSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanCache) DeepCopyInto(out *PlanCache) {
 	*out = *in
@@ -4315,6 +4536,16 @@ func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IncrementalPrefixes != nil {
+		in, out := &in.IncrementalPrefixes, &out.IncrementalPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pitr != nil {
+		in, out := &in.Pitr, &out.Pitr
+		*out = new(PitrSpec)
+		**out = **in
+	}
 	if in.PodSecurityContext != nil {
 		in, out := &in.PodSecurityContext, &out.PodSecurityContext
 		*out = new(v1.PodSecurityContext)
@@ -4338,6 +4569,11 @@ func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
 	*out = *in
 	in.TimeStarted.DeepCopyInto(&out.TimeStarted)
 	in.TimeCompleted.DeepCopyInto(&out.TimeCompleted)
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(BRProgress)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]RestoreCondition, len(*in))
@@ -4358,6 +4594,27 @@ func (in *RestoreStatus) DeepCopy() *RestoreStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedBackup) DeepCopyInto(out *RetainedBackup) {
+	*out = *in
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]RetentionTier, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedBackup.
+func (in *RetainedBackup) DeepCopy() *RetainedBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3StorageProvider) DeepCopyInto(out *S3StorageProvider) {
 	*out = *in
@@ -4566,6 +4823,23 @@ func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkippedBackup) DeepCopyInto(out *SkippedBackup) {
+	*out = *in
+	in.ScheduledTime.DeepCopyInto(&out.ScheduledTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkippedBackup.
+func (in *SkippedBackup) DeepCopy() *SkippedBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(SkippedBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
@@ -4683,6 +4957,11 @@ func (in *StorageProvider) DeepCopyInto(out *StorageProvider) {
 		*out = new(GcsStorageProvider)
 		**out = **in
 	}
+	if in.Azblob != nil {
+		in, out := &in.Azblob, &out.Azblob
+		*out = new(AzblobStorageProvider)
+		**out = **in
+	}
 	if in.Local != nil {
 		in, out := &in.Local, &out.Local
 		*out = new(LocalStorageProvider)
@@ -5436,6 +5715,11 @@ func (in *TiDBStatus) DeepCopyInto(out *TiDBStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.OnlineConfig != nil {
+		in, out := &in.OnlineConfig, &out.OnlineConfig
+		*out = new(OnlineConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -7489,6 +7773,31 @@ func (in *TiKVStatus) DeepCopyInto(out *TiKVStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.EvictLeader != nil {
+		in, out := &in.EvictLeader, &out.EvictLeader
+		*out = make(map[string]*EvictLeaderStatus, len(*in))
+		for key, val := range *in {
+			var outVal *EvictLeaderStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(EvictLeaderStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.OnlineConfig != nil {
+		in, out := &in.OnlineConfig, &out.OnlineConfig
+		*out = new(OnlineConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StoreSummary != nil {
+		in, out := &in.StoreSummary, &out.StoreSummary
+		*out = new(TiKVStoreSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -7613,6 +7922,36 @@ func (in *TiKVStorageReadPoolConfig) DeepCopy() *TiKVStorageReadPoolConfig {
 func (in *TiKVStore) DeepCopyInto(out *TiKVStore) {
 	*out = *in
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Available != nil {
+		in, out := &in.Available, &out.Available
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.UsedSize != nil {
+		in, out := &in.UsedSize, &out.UsedSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastHeartbeatTime != nil {
+		in, out := &in.LastHeartbeatTime, &out.LastHeartbeatTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -7626,6 +7965,37 @@ func (in *TiKVStore) DeepCopy() *TiKVStore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVStoreSummary) DeepCopyInto(out *TiKVStoreSummary) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Available != nil {
+		in, out := &in.Available, &out.Available
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.UsedSize != nil {
+		in, out := &in.UsedSize, &out.UsedSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVStoreSummary.
+func (in *TiKVStoreSummary) DeepCopy() *TiKVStoreSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVStoreSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiKVTitanCfConfig) DeepCopyInto(out *TiKVTitanCfConfig) {
 	*out = *in
@@ -8021,6 +8391,27 @@ func (in *TidbClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterOperation) DeepCopyInto(out *TidbClusterOperation) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterOperation.
+func (in *TidbClusterOperation) DeepCopy() *TidbClusterOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TidbClusterRef) DeepCopyInto(out *TidbClusterRef) {
 	*out = *in
@@ -8169,6 +8560,11 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = make([]TopologySpreadConstraint, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -8196,6 +8592,11 @@ func (in *TidbClusterStatus) DeepCopyInto(out *TidbClusterStatus) {
 		*out = new(TidbClusterAutoScalerRef)
 		**out = **in
 	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]TidbClusterCondition, len(*in))
@@ -8203,6 +8604,13 @@ func (in *TidbClusterStatus) DeepCopyInto(out *TidbClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make([]TidbClusterOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -8602,6 +9010,37 @@ func (in *TidbMonitorStatus) DeepCopy() *TidbMonitorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TieredRetentionPolicy) DeepCopyInto(out *TieredRetentionPolicy) {
+	*out = *in
+	if in.Daily != nil {
+		in, out := &in.Daily, &out.Daily
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Weekly != nil {
+		in, out := &in.Weekly, &out.Weekly
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Monthly != nil {
+		in, out := &in.Monthly, &out.Monthly
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TieredRetentionPolicy.
+func (in *TieredRetentionPolicy) DeepCopy() *TieredRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TieredRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TikvAutoScalerSpec) DeepCopyInto(out *TikvAutoScalerSpec) {
 	*out = *in
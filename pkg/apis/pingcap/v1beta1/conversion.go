@@ -0,0 +1,101 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+)
+
+// The conversions of the fields existing in both versions are generated by conversion-gen in
+// zz_generated.conversion.go, only the fields removed in v1beta1 are converted manually here.
+
+const (
+	// AnnV1alpha1Spec is the annotation to keep the legacy fields of the v1alpha1 TidbCluster spec
+	// which are removed in v1beta1, they are restored when the TidbCluster is converted back.
+	AnnV1alpha1Spec = "pingcap.com/v1alpha1-spec"
+)
+
+// legacyTidbClusterSpec is the fields of the v1alpha1 TidbCluster spec removed in v1beta1
+type legacyTidbClusterSpec struct {
+	Services        []v1alpha1.Service                   `json:"services,omitempty"`
+	PVReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"pvReclaimPolicy,omitempty"`
+}
+
+// Convert_v1alpha1_TidbCluster_To_v1beta1_TidbCluster converts a v1alpha1 TidbCluster to v1beta1,
+// the legacy fields are kept in the AnnV1alpha1Spec annotation
+func Convert_v1alpha1_TidbCluster_To_v1beta1_TidbCluster(in *v1alpha1.TidbCluster, out *TidbCluster, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_TidbCluster_To_v1beta1_TidbCluster(in, out, s); err != nil {
+		return err
+	}
+	legacy := legacyTidbClusterSpec{
+		Services:        in.Spec.Services,
+		PVReclaimPolicy: in.Spec.PVReclaimPolicy,
+	}
+	if legacy.Services == nil && legacy.PVReclaimPolicy == nil {
+		return nil
+	}
+	data, err := json.Marshal(legacy)
+	if err != nil {
+		return fmt.Errorf("marshal the legacy spec of tidbcluster %s/%s failed, err: %v", in.Namespace, in.Name, err)
+	}
+	// the annotations are shared with the input
+	annotations := make(map[string]string, len(in.Annotations)+1)
+	for k, v := range in.Annotations {
+		annotations[k] = v
+	}
+	annotations[AnnV1alpha1Spec] = string(data)
+	out.Annotations = annotations
+	return nil
+}
+
+// Convert_v1beta1_TidbCluster_To_v1alpha1_TidbCluster converts a v1beta1 TidbCluster to v1alpha1,
+// the legacy fields are restored from the AnnV1alpha1Spec annotation
+func Convert_v1beta1_TidbCluster_To_v1alpha1_TidbCluster(in *TidbCluster, out *v1alpha1.TidbCluster, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_TidbCluster_To_v1alpha1_TidbCluster(in, out, s); err != nil {
+		return err
+	}
+	data, ok := in.Annotations[AnnV1alpha1Spec]
+	if !ok {
+		return nil
+	}
+	legacy := legacyTidbClusterSpec{}
+	if err := json.Unmarshal([]byte(data), &legacy); err != nil {
+		return fmt.Errorf("unmarshal annotation %s of tidbcluster %s/%s failed, err: %v", AnnV1alpha1Spec, in.Namespace, in.Name, err)
+	}
+	out.Spec.Services = legacy.Services
+	out.Spec.PVReclaimPolicy = legacy.PVReclaimPolicy
+	var annotations map[string]string
+	for k, v := range in.Annotations {
+		if k == AnnV1alpha1Spec {
+			continue
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[k] = v
+	}
+	out.Annotations = annotations
+	return nil
+}
+
+// Convert_v1alpha1_TidbClusterSpec_To_v1beta1_TidbClusterSpec converts a v1alpha1 TidbClusterSpec to v1beta1,
+// the legacy fields are converted along with the TidbCluster
+func Convert_v1alpha1_TidbClusterSpec_To_v1beta1_TidbClusterSpec(in *v1alpha1.TidbClusterSpec, out *TidbClusterSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TidbClusterSpec_To_v1beta1_TidbClusterSpec(in, out, s)
+}
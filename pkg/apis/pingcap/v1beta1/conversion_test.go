@@ -0,0 +1,160 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newScheme(g *WithT) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	g.Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(AddToScheme(scheme)).To(Succeed())
+	return scheme
+}
+
+func newFuzzer() *fuzz.Fuzzer {
+	return fuzz.New().NilChance(0.2).MaxDepth(8).Funcs(
+		func(e *config.GenericConfig, c fuzz.Continue) {
+			e.MP = map[string]interface{}{c.RandString(): c.RandString()}
+		},
+		func(e *resource.Quantity, c fuzz.Continue) {
+			*e = *resource.NewQuantity(c.Int63n(1024), resource.BinarySI)
+		},
+		func(e *metav1.TypeMeta, c fuzz.Continue) {
+			// the type meta is set by the webhook
+		},
+	)
+}
+
+// TestRoundTrip converts the fuzzed objects to the other version and back, the fields added to
+// one version without the conversion would be lost and fail the test.
+func TestRoundTrip(t *testing.T) {
+	g := NewGomegaWithT(t)
+	scheme := newScheme(g)
+	f := newFuzzer()
+
+	tests := []struct {
+		name  string
+		hub   func() runtime.Object
+		spoke func() runtime.Object
+	}{
+		{
+			name:  "TidbCluster",
+			hub:   func() runtime.Object { return &v1alpha1.TidbCluster{} },
+			spoke: func() runtime.Object { return &TidbCluster{} },
+		},
+		{
+			name:  "TidbClusterList",
+			hub:   func() runtime.Object { return &v1alpha1.TidbClusterList{} },
+			spoke: func() runtime.Object { return &TidbClusterList{} },
+		},
+		{
+			name:  "TidbClusterAutoScaler",
+			hub:   func() runtime.Object { return &v1alpha1.TidbClusterAutoScaler{} },
+			spoke: func() runtime.Object { return &TidbClusterAutoScaler{} },
+		},
+		{
+			name:  "TidbClusterAutoScalerList",
+			hub:   func() runtime.Object { return &v1alpha1.TidbClusterAutoScalerList{} },
+			spoke: func() runtime.Object { return &TidbClusterAutoScalerList{} },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				in := tt.hub()
+				f.Fuzz(in)
+				mid, out := tt.spoke(), tt.hub()
+				g.Expect(scheme.Convert(in, mid, nil)).To(Succeed())
+				g.Expect(scheme.Convert(mid, out, nil)).To(Succeed())
+				g.Expect(apiequality.Semantic.DeepEqual(in, out)).To(BeTrue(), "v1alpha1 round trip of %+v, got %+v", in, out)
+
+				in = tt.spoke()
+				f.Fuzz(in)
+				if tc, ok := in.(*TidbCluster); ok {
+					delete(tc.Annotations, AnnV1alpha1Spec)
+				}
+				mid, out = tt.hub(), tt.spoke()
+				g.Expect(scheme.Convert(in, mid, nil)).To(Succeed())
+				g.Expect(scheme.Convert(mid, out, nil)).To(Succeed())
+				g.Expect(apiequality.Semantic.DeepEqual(in, out)).To(BeTrue(), "v1beta1 round trip of %+v, got %+v", in, out)
+			}
+		})
+	}
+}
+
+func TestConvertTidbClusterLegacyFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+	scheme := newScheme(g)
+
+	retain := corev1.PersistentVolumeReclaimRetain
+	in := &v1alpha1.TidbCluster{}
+	in.Name = "demo"
+	in.Spec.Services = []v1alpha1.Service{{Name: "tidb", Type: "NodePort"}}
+	in.Spec.PVReclaimPolicy = &retain
+	in.Status.AutoScaler = &v1alpha1.TidbClusterAutoScalerRef{Name: "auto", Namespace: "default"}
+
+	beta := &TidbCluster{}
+	g.Expect(scheme.Convert(in, beta, nil)).To(Succeed())
+	g.Expect(beta.Annotations).To(HaveKeyWithValue(AnnV1alpha1Spec, `{"services":[{"name":"tidb","type":"NodePort"}],"pvReclaimPolicy":"Retain"}`))
+	data, err := json.Marshal(beta)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`"autoScaler":{"name":"auto","namespace":"default"}`))
+	g.Expect(string(data)).NotTo(ContainSubstring(`"services"`))
+	g.Expect(string(data)).NotTo(ContainSubstring(`"pvReclaimPolicy"`))
+	// the input is not modified
+	g.Expect(in.Annotations).To(BeNil())
+
+	out := &v1alpha1.TidbCluster{}
+	g.Expect(scheme.Convert(beta, out, nil)).To(Succeed())
+	g.Expect(out.Annotations).To(BeNil())
+	g.Expect(out.Spec.Services).To(Equal(in.Spec.Services))
+	g.Expect(out.Spec.PVReclaimPolicy).To(Equal(&retain))
+
+	beta.Annotations[AnnV1alpha1Spec] = "invalid"
+	g.Expect(scheme.Convert(beta, out, nil)).NotTo(Succeed())
+}
+
+func TestConvertAutoRule(t *testing.T) {
+	g := NewGomegaWithT(t)
+	scheme := newScheme(g)
+
+	minThreshold := 0.2
+	in := &v1alpha1.TidbClusterAutoScaler{}
+	in.Spec.TiKV = &v1alpha1.TikvAutoScalerSpec{
+		BasicAutoScalerSpec: v1alpha1.BasicAutoScalerSpec{
+			Rules: map[corev1.ResourceName]v1alpha1.AutoRule{
+				corev1.ResourceCPU: {MaxThreshold: 0.8, MinThreshold: &minThreshold, ResourceTypes: []string{"c1"}},
+			},
+		},
+	}
+
+	beta := &TidbClusterAutoScaler{}
+	g.Expect(scheme.Convert(in, beta, nil)).To(Succeed())
+	data, err := json.Marshal(beta.Spec.TiKV)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(Equal(`{"rules":{"cpu":{"maxThreshold":0.8,"minThreshold":0.2,"resourceTypes":["c1"]}}}`))
+}
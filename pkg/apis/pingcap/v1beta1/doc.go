@@ -0,0 +1,22 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1
+
+// Package v1beta1 is the v1beta1 version of the API. It cleans up the legacy fields of v1alpha1, the
+// objects are stored in v1alpha1 and converted by the conversion webhook of the tidb-admission-webhook.
+// TidbCluster and TidbClusterAutoScaler are served in v1beta1, the component specs and the other kinds
+// are shared with v1alpha1 for now.
+// +groupName=pingcap.com
+package v1beta1
@@ -0,0 +1,315 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TidbCluster is the control script's spec
+type TidbCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec defines the behavior of a tidb cluster
+	Spec TidbClusterSpec `json:"spec"`
+
+	// Most recently observed status of the tidb cluster
+	Status TidbClusterStatus `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TidbClusterList is TidbCluster list
+type TidbClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbCluster `json:"items"`
+}
+
+// TidbClusterSpec describes the attributes that a user creates on a tidb cluster.
+// The legacy fields of v1alpha1 are removed:
+//   - `services`, use `service` of each component instead
+//   - `pvReclaimPolicy`, use `reclaimPolicy` of the StorageClass instead
+type TidbClusterSpec struct {
+	// Discovery spec
+	Discovery v1alpha1.DiscoverySpec `json:"discovery,omitempty"`
+
+	// Specify a Service Account
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// PD cluster spec
+	// +optional
+	PD *v1alpha1.PDSpec `json:"pd,omitempty"`
+
+	// TiDB cluster spec
+	// +optional
+	TiDB *v1alpha1.TiDBSpec `json:"tidb,omitempty"`
+
+	// TiKV cluster spec
+	// +optional
+	TiKV *v1alpha1.TiKVSpec `json:"tikv,omitempty"`
+
+	// TiFlash cluster spec
+	// +optional
+	TiFlash *v1alpha1.TiFlashSpec `json:"tiflash,omitempty"`
+
+	// TiCDC cluster spec
+	// +optional
+	TiCDC *v1alpha1.TiCDCSpec `json:"ticdc,omitempty"`
+
+	// Pump cluster spec
+	// +optional
+	Pump *v1alpha1.PumpSpec `json:"pump,omitempty"`
+
+	// Helper spec
+	// +optional
+	Helper *v1alpha1.HelperSpec `json:"helper,omitempty"`
+
+	// Indicates that the tidb cluster is paused and will not be processed by
+	// the controller.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// TiDB cluster version
+	// +optional
+	Version string `json:"version"`
+
+	// SchedulerName of TiDB cluster Pods
+	// +kubebuilder:default=tidb-scheduler
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// ImagePullPolicy of TiDB cluster Pods
+	// +kubebuilder:default=IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// ConfigUpdateStrategy determines how the configuration change is applied to the cluster.
+	// UpdateStrategyInPlace will update the ConfigMap of configuration in-place and an extra rolling-update of the
+	// cluster component is needed to reload the configuration change.
+	// UpdateStrategyRollingUpdate will create a new ConfigMap with the new configuration and rolling-update the
+	// related components to use the new ConfigMap, that is, the new configuration will be applied automatically.
+	// +kubebuilder:validation:Enum=InPlace,RollingUpdate
+	// +kubebuilder:default=InPlacne
+	ConfigUpdateStrategy v1alpha1.ConfigUpdateStrategy `json:"configUpdateStrategy,omitempty"`
+
+	// Whether enable PVC reclaim for orphan PVC left by statefulset scale-in
+	// Optional: Defaults to false
+	// +optional
+	EnablePVReclaim *bool `json:"enablePVReclaim,omitempty"`
+
+	// Whether enable the TLS connection between TiDB server components
+	// Optional: Defaults to nil
+	// +optional
+	TLSCluster *v1alpha1.TLSCluster `json:"tlsCluster,omitempty"`
+
+	// Whether Hostnetwork is enabled for TiDB cluster Pods
+	// Optional: Defaults to false
+	// +optional
+	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// Affinity of TiDB cluster Pods.
+	// Will be overwritten by each cluster component's specific affinity setting, e.g. `spec.tidb.affinity`
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName of TiDB cluster Pods
+	// Optional: Defaults to omitted
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+
+	// Base node selectors of TiDB cluster Pods, components may add or override selectors upon this respectively
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Base annotations for TiDB cluster, all Pods in the cluster should have these annotations.
+	// Can be overrode by annotations in the specific component spec.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Base labels for TiDB cluster, all Pods in the cluster should have these labels.
+	// Can be overrode by labels in the specific component spec.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Base tolerations of TiDB cluster Pods, components may add more tolerations upon this respectively
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Time zone of TiDB cluster Pods
+	// Optional: Defaults to UTC
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// EnableDynamicConfiguration indicates whether to append `--advertise-status-addr` to the startup parameters of TiKV,
+	// and to apply the config items of PD, TiKV and TiDB that can be changed online through their APIs without
+	// restarting them. If any other item changes, the ConfigUpdateStrategy of the component is used.
+	// +optional
+	EnableDynamicConfiguration *bool `json:"enableDynamicConfiguration,omitempty"`
+
+	// ClusterDomain is the Kubernetes Cluster Domain of TiDB cluster
+	// Optional: Defaults to ""
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// Cluster is the external cluster, if configured, the components in this TidbCluster will join to this configured cluster.
+	// +optional
+	Cluster *v1alpha1.TidbClusterRef `json:"cluster,omitempty"`
+
+	// PDAddresses are the external PD addresses, if configured, the PDs in this TidbCluster will join to the configured PD cluster.
+	// +optional
+	PDAddresses []string `json:"pdAddresses,omitempty"`
+
+	// StatefulSetUpdateStrategy of TiDB cluster StatefulSets
+	// +optional
+	StatefulSetUpdateStrategy apps.StatefulSetUpdateStrategyType `json:"statefulSetUpdateStrategy,omitempty"`
+
+	// PodSecurityContext of the component
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// TopologySpreadConstraints describes how a group of pods ought to spread across topology
+	// domains. Scheduler will schedule pods in a way which abides by the constraints.
+	// This field is is only honored by clusters that enables the EvenPodsSpread feature.
+	// All topologySpreadConstraints are ANDed.
+	// +optional
+	// +listType=map
+	// +listMapKey=topologyKey
+	TopologySpreadConstraints []v1alpha1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// MaintenanceWindows restricts disruptive operations, i.e. rolling upgrades, scale-in and
+	// recovery from failover, to the specified time windows.
+	// Emergency operations such as failover itself are not restricted.
+	// Optional: Defaults to nil, which means disruptive operations are allowed at any time
+	// +optional
+	MaintenanceWindows []v1alpha1.MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// TidbClusterStatus represents the current status of a tidb cluster.
+// The `auto-scaler` of v1alpha1 is renamed to `autoScaler`.
+type TidbClusterStatus struct {
+	ClusterID  string                             `json:"clusterID,omitempty"`
+	PD         v1alpha1.PDStatus                  `json:"pd,omitempty"`
+	TiKV       v1alpha1.TiKVStatus                `json:"tikv,omitempty"`
+	TiDB       v1alpha1.TiDBStatus                `json:"tidb,omitempty"`
+	Pump       v1alpha1.PumpStatus                `json:"pump,omitempty"`
+	TiFlash    v1alpha1.TiFlashStatus             `json:"tiflash,omitempty"`
+	TiCDC      v1alpha1.TiCDCStatus               `json:"ticdc,omitempty"`
+	AutoScaler *v1alpha1.TidbClusterAutoScalerRef `json:"autoScaler,omitempty"`
+	// Maintenance is the status of maintenance windows, only set when
+	// `spec.maintenanceWindows` is configured.
+	// +optional
+	Maintenance *v1alpha1.MaintenanceStatus `json:"maintenance,omitempty"`
+	// Represents the latest available observations of a tidb cluster's state.
+	// +optional
+	Conditions []v1alpha1.TidbClusterCondition `json:"conditions,omitempty"`
+	// OperationHistory is a bounded list of the scale, upgrade and failover
+	// operations performed on the cluster, oldest first.
+	// +optional
+	OperationHistory []v1alpha1.TidbClusterOperation `json:"operationHistory,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TidbClusterAutoScaler is the control script's spec
+type TidbClusterAutoScaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec describes the state of the TidbClusterAutoScaler
+	Spec TidbClusterAutoScalerSpec `json:"spec"`
+
+	// Status describe the status of the TidbClusterAutoScaler
+	Status v1alpha1.TidbClusterAutoScalerStatus `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TidbClusterAutoScalerList is TidbClusterAutoScaler list
+type TidbClusterAutoScalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbClusterAutoScaler `json:"items"`
+}
+
+// TidbClusterAutoScalerSpec describes the state of the TidbClusterAutoScaler
+type TidbClusterAutoScalerSpec struct {
+	// TidbClusterRef describe the target TidbCluster
+	Cluster v1alpha1.TidbClusterRef `json:"cluster"`
+
+	// TiKV represents the auto-scaling spec for tikv
+	// +optional
+	TiKV *TikvAutoScalerSpec `json:"tikv,omitempty"`
+
+	// TiDB represents the auto-scaling spec for tidb
+	// +optional
+	TiDB *TidbAutoScalerSpec `json:"tidb,omitempty"`
+}
+
+// TikvAutoScalerSpec describes the spec for tikv auto-scaling
+type TikvAutoScalerSpec struct {
+	BasicAutoScalerSpec `json:",inline"`
+}
+
+// TidbAutoScalerSpec describes the spec for tidb auto-scaling
+type TidbAutoScalerSpec struct {
+	BasicAutoScalerSpec `json:",inline"`
+}
+
+// BasicAutoScalerSpec describes the basic spec for auto-scaling
+type BasicAutoScalerSpec struct {
+	// Rules defines the rules for auto-scaling with PD API
+	Rules map[corev1.ResourceName]AutoRule `json:"rules,omitempty"`
+
+	// ScaleInIntervalSeconds represents the duration seconds between each auto-scaling-in
+	// If not set, the default ScaleInIntervalSeconds will be set to 500
+	// +optional
+	ScaleInIntervalSeconds *int32 `json:"scaleInIntervalSeconds,omitempty"`
+
+	// ScaleOutIntervalSeconds represents the duration seconds between each auto-scaling-out
+	// If not set, the default ScaleOutIntervalSeconds will be set to 300
+	// +optional
+	ScaleOutIntervalSeconds *int32 `json:"scaleOutIntervalSeconds,omitempty"`
+
+	// External makes the auto-scaler controller able to query the external service
+	// to fetch the recommended replicas for TiKV/TiDB
+	// +optional
+	External *v1alpha1.ExternalConfig `json:"external,omitempty"`
+
+	// Resources represent the resource type definitions that can be used for TiDB/TiKV
+	// The key is resource_type name of the resource
+	// +optional
+	Resources map[string]v1alpha1.AutoResource `json:"resources,omitempty"`
+}
+
+// AutoRule describes the rules for auto-scaling with PD API.
+// The snake_case fields of v1alpha1 are renamed to camelCase.
+type AutoRule struct {
+	// MaxThreshold defines the threshold to scale out
+	MaxThreshold float64 `json:"maxThreshold"`
+	// MinThreshold defines the threshold to scale in, not applicable to `storage` rule
+	MinThreshold *float64 `json:"minThreshold,omitempty"`
+	// ResourceTypes defines the resource types that can be used for scaling
+	ResourceTypes []string `json:"resourceTypes,omitempty"`
+}
@@ -0,0 +1,507 @@
+// +build !ignore_autogenerated
+
+// Copyright PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	unsafe "unsafe"
+
+	v1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AutoRule)(nil), (*v1alpha1.AutoRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AutoRule_To_v1alpha1_AutoRule(a.(*AutoRule), b.(*v1alpha1.AutoRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.AutoRule)(nil), (*AutoRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AutoRule_To_v1beta1_AutoRule(a.(*v1alpha1.AutoRule), b.(*AutoRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BasicAutoScalerSpec)(nil), (*v1alpha1.BasicAutoScalerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BasicAutoScalerSpec_To_v1alpha1_BasicAutoScalerSpec(a.(*BasicAutoScalerSpec), b.(*v1alpha1.BasicAutoScalerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.BasicAutoScalerSpec)(nil), (*BasicAutoScalerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_BasicAutoScalerSpec_To_v1beta1_BasicAutoScalerSpec(a.(*v1alpha1.BasicAutoScalerSpec), b.(*BasicAutoScalerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TidbAutoScalerSpec)(nil), (*v1alpha1.TidbAutoScalerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TidbAutoScalerSpec_To_v1alpha1_TidbAutoScalerSpec(a.(*TidbAutoScalerSpec), b.(*v1alpha1.TidbAutoScalerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TidbAutoScalerSpec)(nil), (*TidbAutoScalerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TidbAutoScalerSpec_To_v1beta1_TidbAutoScalerSpec(a.(*v1alpha1.TidbAutoScalerSpec), b.(*TidbAutoScalerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TidbClusterAutoScaler)(nil), (*v1alpha1.TidbClusterAutoScaler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TidbClusterAutoScaler_To_v1alpha1_TidbClusterAutoScaler(a.(*TidbClusterAutoScaler), b.(*v1alpha1.TidbClusterAutoScaler), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TidbClusterAutoScaler)(nil), (*TidbClusterAutoScaler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TidbClusterAutoScaler_To_v1beta1_TidbClusterAutoScaler(a.(*v1alpha1.TidbClusterAutoScaler), b.(*TidbClusterAutoScaler), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TidbClusterAutoScalerList)(nil), (*v1alpha1.TidbClusterAutoScalerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TidbClusterAutoScalerList_To_v1alpha1_TidbClusterAutoScalerList(a.(*TidbClusterAutoScalerList), b.(*v1alpha1.TidbClusterAutoScalerList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TidbClusterAutoScalerList)(nil), (*TidbClusterAutoScalerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TidbClusterAutoScalerList_To_v1beta1_TidbClusterAutoScalerList(a.(*v1alpha1.TidbClusterAutoScalerList), b.(*TidbClusterAutoScalerList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TidbClusterAutoScalerSpec)(nil), (*v1alpha1.TidbClusterAutoScalerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TidbClusterAutoScalerSpec_To_v1alpha1_TidbClusterAutoScalerSpec(a.(*TidbClusterAutoScalerSpec), b.(*v1alpha1.TidbClusterAutoScalerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TidbClusterAutoScalerSpec)(nil), (*TidbClusterAutoScalerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TidbClusterAutoScalerSpec_To_v1beta1_TidbClusterAutoScalerSpec(a.(*v1alpha1.TidbClusterAutoScalerSpec), b.(*TidbClusterAutoScalerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TidbClusterList)(nil), (*v1alpha1.TidbClusterList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TidbClusterList_To_v1alpha1_TidbClusterList(a.(*TidbClusterList), b.(*v1alpha1.TidbClusterList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TidbClusterList)(nil), (*TidbClusterList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TidbClusterList_To_v1beta1_TidbClusterList(a.(*v1alpha1.TidbClusterList), b.(*TidbClusterList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TidbClusterSpec)(nil), (*v1alpha1.TidbClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TidbClusterSpec_To_v1alpha1_TidbClusterSpec(a.(*TidbClusterSpec), b.(*v1alpha1.TidbClusterSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TidbClusterStatus)(nil), (*v1alpha1.TidbClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TidbClusterStatus_To_v1alpha1_TidbClusterStatus(a.(*TidbClusterStatus), b.(*v1alpha1.TidbClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TidbClusterStatus)(nil), (*TidbClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TidbClusterStatus_To_v1beta1_TidbClusterStatus(a.(*v1alpha1.TidbClusterStatus), b.(*TidbClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TikvAutoScalerSpec)(nil), (*v1alpha1.TikvAutoScalerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TikvAutoScalerSpec_To_v1alpha1_TikvAutoScalerSpec(a.(*TikvAutoScalerSpec), b.(*v1alpha1.TikvAutoScalerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TikvAutoScalerSpec)(nil), (*TikvAutoScalerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TikvAutoScalerSpec_To_v1beta1_TikvAutoScalerSpec(a.(*v1alpha1.TikvAutoScalerSpec), b.(*TikvAutoScalerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha1.TidbClusterSpec)(nil), (*TidbClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TidbClusterSpec_To_v1beta1_TidbClusterSpec(a.(*v1alpha1.TidbClusterSpec), b.(*TidbClusterSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha1.TidbCluster)(nil), (*TidbCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TidbCluster_To_v1beta1_TidbCluster(a.(*v1alpha1.TidbCluster), b.(*TidbCluster), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*TidbCluster)(nil), (*v1alpha1.TidbCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TidbCluster_To_v1alpha1_TidbCluster(a.(*TidbCluster), b.(*v1alpha1.TidbCluster), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1beta1_AutoRule_To_v1alpha1_AutoRule(in *AutoRule, out *v1alpha1.AutoRule, s conversion.Scope) error {
+	out.MaxThreshold = in.MaxThreshold
+	out.MinThreshold = (*float64)(unsafe.Pointer(in.MinThreshold))
+	out.ResourceTypes = *(*[]string)(unsafe.Pointer(&in.ResourceTypes))
+	return nil
+}
+
+// Convert_v1beta1_AutoRule_To_v1alpha1_AutoRule is an autogenerated conversion function.
+func Convert_v1beta1_AutoRule_To_v1alpha1_AutoRule(in *AutoRule, out *v1alpha1.AutoRule, s conversion.Scope) error {
+	return autoConvert_v1beta1_AutoRule_To_v1alpha1_AutoRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_AutoRule_To_v1beta1_AutoRule(in *v1alpha1.AutoRule, out *AutoRule, s conversion.Scope) error {
+	out.MaxThreshold = in.MaxThreshold
+	out.MinThreshold = (*float64)(unsafe.Pointer(in.MinThreshold))
+	out.ResourceTypes = *(*[]string)(unsafe.Pointer(&in.ResourceTypes))
+	return nil
+}
+
+// Convert_v1alpha1_AutoRule_To_v1beta1_AutoRule is an autogenerated conversion function.
+func Convert_v1alpha1_AutoRule_To_v1beta1_AutoRule(in *v1alpha1.AutoRule, out *AutoRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AutoRule_To_v1beta1_AutoRule(in, out, s)
+}
+
+func autoConvert_v1beta1_BasicAutoScalerSpec_To_v1alpha1_BasicAutoScalerSpec(in *BasicAutoScalerSpec, out *v1alpha1.BasicAutoScalerSpec, s conversion.Scope) error {
+	out.Rules = *(*map[v1.ResourceName]v1alpha1.AutoRule)(unsafe.Pointer(&in.Rules))
+	out.ScaleInIntervalSeconds = (*int32)(unsafe.Pointer(in.ScaleInIntervalSeconds))
+	out.ScaleOutIntervalSeconds = (*int32)(unsafe.Pointer(in.ScaleOutIntervalSeconds))
+	out.External = (*v1alpha1.ExternalConfig)(unsafe.Pointer(in.External))
+	out.Resources = *(*map[string]v1alpha1.AutoResource)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_v1beta1_BasicAutoScalerSpec_To_v1alpha1_BasicAutoScalerSpec is an autogenerated conversion function.
+func Convert_v1beta1_BasicAutoScalerSpec_To_v1alpha1_BasicAutoScalerSpec(in *BasicAutoScalerSpec, out *v1alpha1.BasicAutoScalerSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_BasicAutoScalerSpec_To_v1alpha1_BasicAutoScalerSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_BasicAutoScalerSpec_To_v1beta1_BasicAutoScalerSpec(in *v1alpha1.BasicAutoScalerSpec, out *BasicAutoScalerSpec, s conversion.Scope) error {
+	out.Rules = *(*map[v1.ResourceName]AutoRule)(unsafe.Pointer(&in.Rules))
+	out.ScaleInIntervalSeconds = (*int32)(unsafe.Pointer(in.ScaleInIntervalSeconds))
+	out.ScaleOutIntervalSeconds = (*int32)(unsafe.Pointer(in.ScaleOutIntervalSeconds))
+	out.External = (*v1alpha1.ExternalConfig)(unsafe.Pointer(in.External))
+	out.Resources = *(*map[string]v1alpha1.AutoResource)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_v1alpha1_BasicAutoScalerSpec_To_v1beta1_BasicAutoScalerSpec is an autogenerated conversion function.
+func Convert_v1alpha1_BasicAutoScalerSpec_To_v1beta1_BasicAutoScalerSpec(in *v1alpha1.BasicAutoScalerSpec, out *BasicAutoScalerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BasicAutoScalerSpec_To_v1beta1_BasicAutoScalerSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_TidbAutoScalerSpec_To_v1alpha1_TidbAutoScalerSpec(in *TidbAutoScalerSpec, out *v1alpha1.TidbAutoScalerSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_BasicAutoScalerSpec_To_v1alpha1_BasicAutoScalerSpec(&in.BasicAutoScalerSpec, &out.BasicAutoScalerSpec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_TidbAutoScalerSpec_To_v1alpha1_TidbAutoScalerSpec is an autogenerated conversion function.
+func Convert_v1beta1_TidbAutoScalerSpec_To_v1alpha1_TidbAutoScalerSpec(in *TidbAutoScalerSpec, out *v1alpha1.TidbAutoScalerSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_TidbAutoScalerSpec_To_v1alpha1_TidbAutoScalerSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_TidbAutoScalerSpec_To_v1beta1_TidbAutoScalerSpec(in *v1alpha1.TidbAutoScalerSpec, out *TidbAutoScalerSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha1_BasicAutoScalerSpec_To_v1beta1_BasicAutoScalerSpec(&in.BasicAutoScalerSpec, &out.BasicAutoScalerSpec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_TidbAutoScalerSpec_To_v1beta1_TidbAutoScalerSpec is an autogenerated conversion function.
+func Convert_v1alpha1_TidbAutoScalerSpec_To_v1beta1_TidbAutoScalerSpec(in *v1alpha1.TidbAutoScalerSpec, out *TidbAutoScalerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TidbAutoScalerSpec_To_v1beta1_TidbAutoScalerSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_TidbCluster_To_v1alpha1_TidbCluster(in *TidbCluster, out *v1alpha1.TidbCluster, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_TidbClusterSpec_To_v1alpha1_TidbClusterSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_TidbClusterStatus_To_v1alpha1_TidbClusterStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_TidbCluster_To_v1beta1_TidbCluster(in *v1alpha1.TidbCluster, out *TidbCluster, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_TidbClusterSpec_To_v1beta1_TidbClusterSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_TidbClusterStatus_To_v1beta1_TidbClusterStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1beta1_TidbClusterAutoScaler_To_v1alpha1_TidbClusterAutoScaler(in *TidbClusterAutoScaler, out *v1alpha1.TidbClusterAutoScaler, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_TidbClusterAutoScalerSpec_To_v1alpha1_TidbClusterAutoScalerSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	out.Status = in.Status
+	return nil
+}
+
+// Convert_v1beta1_TidbClusterAutoScaler_To_v1alpha1_TidbClusterAutoScaler is an autogenerated conversion function.
+func Convert_v1beta1_TidbClusterAutoScaler_To_v1alpha1_TidbClusterAutoScaler(in *TidbClusterAutoScaler, out *v1alpha1.TidbClusterAutoScaler, s conversion.Scope) error {
+	return autoConvert_v1beta1_TidbClusterAutoScaler_To_v1alpha1_TidbClusterAutoScaler(in, out, s)
+}
+
+func autoConvert_v1alpha1_TidbClusterAutoScaler_To_v1beta1_TidbClusterAutoScaler(in *v1alpha1.TidbClusterAutoScaler, out *TidbClusterAutoScaler, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_TidbClusterAutoScalerSpec_To_v1beta1_TidbClusterAutoScalerSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	out.Status = in.Status
+	return nil
+}
+
+// Convert_v1alpha1_TidbClusterAutoScaler_To_v1beta1_TidbClusterAutoScaler is an autogenerated conversion function.
+func Convert_v1alpha1_TidbClusterAutoScaler_To_v1beta1_TidbClusterAutoScaler(in *v1alpha1.TidbClusterAutoScaler, out *TidbClusterAutoScaler, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TidbClusterAutoScaler_To_v1beta1_TidbClusterAutoScaler(in, out, s)
+}
+
+func autoConvert_v1beta1_TidbClusterAutoScalerList_To_v1alpha1_TidbClusterAutoScalerList(in *TidbClusterAutoScalerList, out *v1alpha1.TidbClusterAutoScalerList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1alpha1.TidbClusterAutoScaler)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_TidbClusterAutoScalerList_To_v1alpha1_TidbClusterAutoScalerList is an autogenerated conversion function.
+func Convert_v1beta1_TidbClusterAutoScalerList_To_v1alpha1_TidbClusterAutoScalerList(in *TidbClusterAutoScalerList, out *v1alpha1.TidbClusterAutoScalerList, s conversion.Scope) error {
+	return autoConvert_v1beta1_TidbClusterAutoScalerList_To_v1alpha1_TidbClusterAutoScalerList(in, out, s)
+}
+
+func autoConvert_v1alpha1_TidbClusterAutoScalerList_To_v1beta1_TidbClusterAutoScalerList(in *v1alpha1.TidbClusterAutoScalerList, out *TidbClusterAutoScalerList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]TidbClusterAutoScaler)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_TidbClusterAutoScalerList_To_v1beta1_TidbClusterAutoScalerList is an autogenerated conversion function.
+func Convert_v1alpha1_TidbClusterAutoScalerList_To_v1beta1_TidbClusterAutoScalerList(in *v1alpha1.TidbClusterAutoScalerList, out *TidbClusterAutoScalerList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TidbClusterAutoScalerList_To_v1beta1_TidbClusterAutoScalerList(in, out, s)
+}
+
+func autoConvert_v1beta1_TidbClusterAutoScalerSpec_To_v1alpha1_TidbClusterAutoScalerSpec(in *TidbClusterAutoScalerSpec, out *v1alpha1.TidbClusterAutoScalerSpec, s conversion.Scope) error {
+	out.Cluster = in.Cluster
+	out.TiKV = (*v1alpha1.TikvAutoScalerSpec)(unsafe.Pointer(in.TiKV))
+	out.TiDB = (*v1alpha1.TidbAutoScalerSpec)(unsafe.Pointer(in.TiDB))
+	return nil
+}
+
+// Convert_v1beta1_TidbClusterAutoScalerSpec_To_v1alpha1_TidbClusterAutoScalerSpec is an autogenerated conversion function.
+func Convert_v1beta1_TidbClusterAutoScalerSpec_To_v1alpha1_TidbClusterAutoScalerSpec(in *TidbClusterAutoScalerSpec, out *v1alpha1.TidbClusterAutoScalerSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_TidbClusterAutoScalerSpec_To_v1alpha1_TidbClusterAutoScalerSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_TidbClusterAutoScalerSpec_To_v1beta1_TidbClusterAutoScalerSpec(in *v1alpha1.TidbClusterAutoScalerSpec, out *TidbClusterAutoScalerSpec, s conversion.Scope) error {
+	out.Cluster = in.Cluster
+	out.TiKV = (*TikvAutoScalerSpec)(unsafe.Pointer(in.TiKV))
+	out.TiDB = (*TidbAutoScalerSpec)(unsafe.Pointer(in.TiDB))
+	return nil
+}
+
+// Convert_v1alpha1_TidbClusterAutoScalerSpec_To_v1beta1_TidbClusterAutoScalerSpec is an autogenerated conversion function.
+func Convert_v1alpha1_TidbClusterAutoScalerSpec_To_v1beta1_TidbClusterAutoScalerSpec(in *v1alpha1.TidbClusterAutoScalerSpec, out *TidbClusterAutoScalerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TidbClusterAutoScalerSpec_To_v1beta1_TidbClusterAutoScalerSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_TidbClusterList_To_v1alpha1_TidbClusterList(in *TidbClusterList, out *v1alpha1.TidbClusterList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha1.TidbCluster, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_TidbCluster_To_v1alpha1_TidbCluster(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_TidbClusterList_To_v1alpha1_TidbClusterList is an autogenerated conversion function.
+func Convert_v1beta1_TidbClusterList_To_v1alpha1_TidbClusterList(in *TidbClusterList, out *v1alpha1.TidbClusterList, s conversion.Scope) error {
+	return autoConvert_v1beta1_TidbClusterList_To_v1alpha1_TidbClusterList(in, out, s)
+}
+
+func autoConvert_v1alpha1_TidbClusterList_To_v1beta1_TidbClusterList(in *v1alpha1.TidbClusterList, out *TidbClusterList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TidbCluster, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_TidbCluster_To_v1beta1_TidbCluster(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_TidbClusterList_To_v1beta1_TidbClusterList is an autogenerated conversion function.
+func Convert_v1alpha1_TidbClusterList_To_v1beta1_TidbClusterList(in *v1alpha1.TidbClusterList, out *TidbClusterList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TidbClusterList_To_v1beta1_TidbClusterList(in, out, s)
+}
+
+func autoConvert_v1beta1_TidbClusterSpec_To_v1alpha1_TidbClusterSpec(in *TidbClusterSpec, out *v1alpha1.TidbClusterSpec, s conversion.Scope) error {
+	out.Discovery = in.Discovery
+	out.ServiceAccount = in.ServiceAccount
+	out.PD = (*v1alpha1.PDSpec)(unsafe.Pointer(in.PD))
+	out.TiDB = (*v1alpha1.TiDBSpec)(unsafe.Pointer(in.TiDB))
+	out.TiKV = (*v1alpha1.TiKVSpec)(unsafe.Pointer(in.TiKV))
+	out.TiFlash = (*v1alpha1.TiFlashSpec)(unsafe.Pointer(in.TiFlash))
+	out.TiCDC = (*v1alpha1.TiCDCSpec)(unsafe.Pointer(in.TiCDC))
+	out.Pump = (*v1alpha1.PumpSpec)(unsafe.Pointer(in.Pump))
+	out.Helper = (*v1alpha1.HelperSpec)(unsafe.Pointer(in.Helper))
+	out.Paused = in.Paused
+	out.Version = in.Version
+	out.SchedulerName = in.SchedulerName
+	out.ImagePullPolicy = v1.PullPolicy(in.ImagePullPolicy)
+	out.ImagePullSecrets = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.ConfigUpdateStrategy = v1alpha1.ConfigUpdateStrategy(in.ConfigUpdateStrategy)
+	out.EnablePVReclaim = (*bool)(unsafe.Pointer(in.EnablePVReclaim))
+	out.TLSCluster = (*v1alpha1.TLSCluster)(unsafe.Pointer(in.TLSCluster))
+	out.HostNetwork = (*bool)(unsafe.Pointer(in.HostNetwork))
+	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.PriorityClassName = (*string)(unsafe.Pointer(in.PriorityClassName))
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Timezone = in.Timezone
+	out.EnableDynamicConfiguration = (*bool)(unsafe.Pointer(in.EnableDynamicConfiguration))
+	out.ClusterDomain = in.ClusterDomain
+	out.Cluster = (*v1alpha1.TidbClusterRef)(unsafe.Pointer(in.Cluster))
+	out.PDAddresses = *(*[]string)(unsafe.Pointer(&in.PDAddresses))
+	out.StatefulSetUpdateStrategy = appsv1.StatefulSetUpdateStrategyType(in.StatefulSetUpdateStrategy)
+	out.PodSecurityContext = (*v1.PodSecurityContext)(unsafe.Pointer(in.PodSecurityContext))
+	out.TopologySpreadConstraints = *(*[]v1alpha1.TopologySpreadConstraint)(unsafe.Pointer(&in.TopologySpreadConstraints))
+	out.MaintenanceWindows = *(*[]v1alpha1.MaintenanceWindow)(unsafe.Pointer(&in.MaintenanceWindows))
+	return nil
+}
+
+// Convert_v1beta1_TidbClusterSpec_To_v1alpha1_TidbClusterSpec is an autogenerated conversion function.
+func Convert_v1beta1_TidbClusterSpec_To_v1alpha1_TidbClusterSpec(in *TidbClusterSpec, out *v1alpha1.TidbClusterSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_TidbClusterSpec_To_v1alpha1_TidbClusterSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_TidbClusterSpec_To_v1beta1_TidbClusterSpec(in *v1alpha1.TidbClusterSpec, out *TidbClusterSpec, s conversion.Scope) error {
+	out.Discovery = in.Discovery
+	out.ServiceAccount = in.ServiceAccount
+	out.PD = (*v1alpha1.PDSpec)(unsafe.Pointer(in.PD))
+	out.TiDB = (*v1alpha1.TiDBSpec)(unsafe.Pointer(in.TiDB))
+	out.TiKV = (*v1alpha1.TiKVSpec)(unsafe.Pointer(in.TiKV))
+	out.TiFlash = (*v1alpha1.TiFlashSpec)(unsafe.Pointer(in.TiFlash))
+	out.TiCDC = (*v1alpha1.TiCDCSpec)(unsafe.Pointer(in.TiCDC))
+	out.Pump = (*v1alpha1.PumpSpec)(unsafe.Pointer(in.Pump))
+	out.Helper = (*v1alpha1.HelperSpec)(unsafe.Pointer(in.Helper))
+	out.Paused = in.Paused
+	out.Version = in.Version
+	out.SchedulerName = in.SchedulerName
+	// WARNING: in.PVReclaimPolicy requires manual conversion: does not exist in peer-type
+	out.ImagePullPolicy = v1.PullPolicy(in.ImagePullPolicy)
+	out.ImagePullSecrets = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.ConfigUpdateStrategy = v1alpha1.ConfigUpdateStrategy(in.ConfigUpdateStrategy)
+	out.EnablePVReclaim = (*bool)(unsafe.Pointer(in.EnablePVReclaim))
+	out.TLSCluster = (*v1alpha1.TLSCluster)(unsafe.Pointer(in.TLSCluster))
+	out.HostNetwork = (*bool)(unsafe.Pointer(in.HostNetwork))
+	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.PriorityClassName = (*string)(unsafe.Pointer(in.PriorityClassName))
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Timezone = in.Timezone
+	// WARNING: in.Services requires manual conversion: does not exist in peer-type
+	out.EnableDynamicConfiguration = (*bool)(unsafe.Pointer(in.EnableDynamicConfiguration))
+	out.ClusterDomain = in.ClusterDomain
+	out.Cluster = (*v1alpha1.TidbClusterRef)(unsafe.Pointer(in.Cluster))
+	out.PDAddresses = *(*[]string)(unsafe.Pointer(&in.PDAddresses))
+	out.StatefulSetUpdateStrategy = appsv1.StatefulSetUpdateStrategyType(in.StatefulSetUpdateStrategy)
+	out.PodSecurityContext = (*v1.PodSecurityContext)(unsafe.Pointer(in.PodSecurityContext))
+	out.TopologySpreadConstraints = *(*[]v1alpha1.TopologySpreadConstraint)(unsafe.Pointer(&in.TopologySpreadConstraints))
+	out.MaintenanceWindows = *(*[]v1alpha1.MaintenanceWindow)(unsafe.Pointer(&in.MaintenanceWindows))
+	return nil
+}
+
+func autoConvert_v1beta1_TidbClusterStatus_To_v1alpha1_TidbClusterStatus(in *TidbClusterStatus, out *v1alpha1.TidbClusterStatus, s conversion.Scope) error {
+	out.ClusterID = in.ClusterID
+	out.PD = in.PD
+	out.TiKV = in.TiKV
+	out.TiDB = in.TiDB
+	out.Pump = in.Pump
+	out.TiFlash = in.TiFlash
+	out.TiCDC = in.TiCDC
+	out.AutoScaler = (*v1alpha1.TidbClusterAutoScalerRef)(unsafe.Pointer(in.AutoScaler))
+	out.Maintenance = (*v1alpha1.MaintenanceStatus)(unsafe.Pointer(in.Maintenance))
+	out.Conditions = *(*[]v1alpha1.TidbClusterCondition)(unsafe.Pointer(&in.Conditions))
+	out.OperationHistory = *(*[]v1alpha1.TidbClusterOperation)(unsafe.Pointer(&in.OperationHistory))
+	return nil
+}
+
+// Convert_v1beta1_TidbClusterStatus_To_v1alpha1_TidbClusterStatus is an autogenerated conversion function.
+func Convert_v1beta1_TidbClusterStatus_To_v1alpha1_TidbClusterStatus(in *TidbClusterStatus, out *v1alpha1.TidbClusterStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_TidbClusterStatus_To_v1alpha1_TidbClusterStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_TidbClusterStatus_To_v1beta1_TidbClusterStatus(in *v1alpha1.TidbClusterStatus, out *TidbClusterStatus, s conversion.Scope) error {
+	out.ClusterID = in.ClusterID
+	out.PD = in.PD
+	out.TiKV = in.TiKV
+	out.TiDB = in.TiDB
+	out.Pump = in.Pump
+	out.TiFlash = in.TiFlash
+	out.TiCDC = in.TiCDC
+	out.AutoScaler = (*v1alpha1.TidbClusterAutoScalerRef)(unsafe.Pointer(in.AutoScaler))
+	out.Maintenance = (*v1alpha1.MaintenanceStatus)(unsafe.Pointer(in.Maintenance))
+	out.Conditions = *(*[]v1alpha1.TidbClusterCondition)(unsafe.Pointer(&in.Conditions))
+	out.OperationHistory = *(*[]v1alpha1.TidbClusterOperation)(unsafe.Pointer(&in.OperationHistory))
+	return nil
+}
+
+// Convert_v1alpha1_TidbClusterStatus_To_v1beta1_TidbClusterStatus is an autogenerated conversion function.
+func Convert_v1alpha1_TidbClusterStatus_To_v1beta1_TidbClusterStatus(in *v1alpha1.TidbClusterStatus, out *TidbClusterStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TidbClusterStatus_To_v1beta1_TidbClusterStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_TikvAutoScalerSpec_To_v1alpha1_TikvAutoScalerSpec(in *TikvAutoScalerSpec, out *v1alpha1.TikvAutoScalerSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_BasicAutoScalerSpec_To_v1alpha1_BasicAutoScalerSpec(&in.BasicAutoScalerSpec, &out.BasicAutoScalerSpec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_TikvAutoScalerSpec_To_v1alpha1_TikvAutoScalerSpec is an autogenerated conversion function.
+func Convert_v1beta1_TikvAutoScalerSpec_To_v1alpha1_TikvAutoScalerSpec(in *TikvAutoScalerSpec, out *v1alpha1.TikvAutoScalerSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_TikvAutoScalerSpec_To_v1alpha1_TikvAutoScalerSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_TikvAutoScalerSpec_To_v1beta1_TikvAutoScalerSpec(in *v1alpha1.TikvAutoScalerSpec, out *TikvAutoScalerSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha1_BasicAutoScalerSpec_To_v1beta1_BasicAutoScalerSpec(&in.BasicAutoScalerSpec, &out.BasicAutoScalerSpec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_TikvAutoScalerSpec_To_v1beta1_TikvAutoScalerSpec is an autogenerated conversion function.
+func Convert_v1alpha1_TikvAutoScalerSpec_To_v1beta1_TikvAutoScalerSpec(in *v1alpha1.TikvAutoScalerSpec, out *TikvAutoScalerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TikvAutoScalerSpec_To_v1beta1_TikvAutoScalerSpec(in, out, s)
+}
@@ -16,6 +16,7 @@ package backup
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -61,6 +62,10 @@ func (bm *backupManager) Sync(backup *v1alpha1.Backup) error {
 		return nil
 	}
 
+	if v1alpha1.IsBackupComplete(backup) {
+		return bm.syncVerifyJob(backup)
+	}
+
 	return bm.syncBackupJob(backup)
 }
 
@@ -171,10 +176,115 @@ func (bm *backupManager) syncBackupJob(backup *v1alpha1.Backup) error {
 		return errMsg
 	}
 
+	// record the chain of an incremental backup created by a backup schedule
+	var updateStatus *controller.BackupUpdateStatus
+	if parent, ok := backup.Annotations[label.AnnBackupParent]; ok {
+		updateStatus = &controller.BackupUpdateStatus{ParentBackup: &parent}
+	}
 	return bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
 		Type:   v1alpha1.BackupScheduled,
 		Status: corev1.ConditionTrue,
-	}, nil)
+	}, updateStatus)
+}
+
+// syncVerifyJob creates the verify job for the latest verification request of a completed backup
+// and marks the verification failed if its job has failed before reporting the result.
+func (bm *backupManager) syncVerifyJob(backup *v1alpha1.Backup) error {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	verifyJobName := backup.GetVerifyJobName()
+
+	if !v1alpha1.IsBackupVerifyRequested(backup) {
+		if v1alpha1.IsBackupVerifying(backup) {
+			return bm.checkVerifyJobFailed(backup)
+		}
+		return nil
+	}
+	requestTime := v1alpha1.GetBackupVerifyRequestTime(backup)
+
+	job, err := bm.deps.JobLister.Jobs(ns).Get(verifyJobName)
+	if err == nil {
+		// the running verification is not interrupted, its pod may still report the result after the job
+		// is deleted and overwrite the status of the new one
+		if job.DeletionTimestamp == nil && !isVerifyJobFinished(job) {
+			return controller.RequeueErrorf("backup %s/%s, waiting for the running verify job %s to finish", ns, name, verifyJobName)
+		}
+		// the job of the last verification is kept for its logs until the next verification is requested
+		if job.DeletionTimestamp != nil {
+			return controller.RequeueErrorf("backup %s/%s, waiting for the last verify job %s to be deleted", ns, name, verifyJobName)
+		}
+		if err := bm.deps.JobControl.DeleteJob(backup, job); err != nil {
+			return fmt.Errorf("backup %s/%s delete last verify job %s failed, err: %v", ns, name, verifyJobName, err)
+		}
+		return controller.RequeueErrorf("backup %s/%s, waiting for the last verify job %s to be deleted", ns, name, verifyJobName)
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("backup %s/%s get job %s failed, err: %v", ns, name, verifyJobName, err)
+	}
+
+	job, reason, err := bm.makeVerifyJob(backup, requestTime)
+	if err == nil {
+		err = bm.deps.JobControl.CreateJob(backup, job)
+		reason = "CreateVerifyJobFailed"
+	}
+	if err != nil {
+		errMsg := fmt.Errorf("create backup %s/%s verify job %s failed, err: %v", ns, name, verifyJobName, err)
+		bm.statusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{
+			Verify: &v1alpha1.BackupVerifyStatus{
+				Phase:       v1alpha1.BackupVerifyFailed,
+				RequestTime: *requestTime,
+				Message:     fmt.Sprintf("%s: %v", reason, err),
+			},
+		})
+		return errMsg
+	}
+
+	return bm.statusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{
+		Verify: &v1alpha1.BackupVerifyStatus{
+			Phase:       v1alpha1.BackupVerifyRunning,
+			RequestTime: *requestTime,
+		},
+	})
+}
+
+// isVerifyJobFinished returns whether the verify job has completed or failed, the verify job has
+// no retry, so it finishes once its pod does
+func isVerifyJobFinished(job *batchv1.Job) bool {
+	if job.Status.Succeeded > 0 || job.Status.Failed > 0 {
+		return true
+	}
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func (bm *backupManager) checkVerifyJobFailed(backup *v1alpha1.Backup) error {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	verifyJobName := backup.GetVerifyJobName()
+
+	job, err := bm.deps.JobLister.Jobs(ns).Get(verifyJobName)
+	if errors.IsNotFound(err) {
+		// the job may not be observed yet
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("backup %s/%s get job %s failed, err: %v", ns, name, verifyJobName, err)
+	}
+	if job.Status.Failed == 0 {
+		return nil
+	}
+
+	verify := backup.Status.Verify.DeepCopy()
+	verify.Phase = v1alpha1.BackupVerifyFailed
+	verify.Message = fmt.Sprintf("Job %s has failed", verifyJobName)
+	if verify.TimeCompleted == nil {
+		verify.TimeCompleted = &metav1.Time{Time: time.Now()}
+	}
+	return bm.statusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{Verify: verify})
 }
 
 func (bm *backupManager) makeExportJob(backup *v1alpha1.Backup) (*batchv1.Job, string, error) {
@@ -518,6 +628,138 @@ func (bm *backupManager) makeBackupJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 	return job, "", nil
 }
 
+// makeVerifyJob requires that backup.Spec.BR != nil
+func (bm *backupManager) makeVerifyJob(backup *v1alpha1.Backup, requestTime *metav1.Time) (*batchv1.Job, string, error) {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	backupNamespace := ns
+	if backup.Spec.BR.ClusterNamespace != "" {
+		backupNamespace = backup.Spec.BR.ClusterNamespace
+	}
+
+	envVars, reason, err := backuputil.GenerateStorageCertEnv(ns, backup.Spec.UseKMS, backup.Spec.StorageProvider, bm.deps.KubeClientset)
+	if err != nil {
+		return nil, reason, fmt.Errorf("backup %s/%s, %v", ns, name, err)
+	}
+	envVars = append(envVars, corev1.EnvVar{
+		Name:  "BR_LOG_TO_TERM",
+		Value: string(rune(1)),
+	})
+
+	// set env vars specified in backup.Spec.Env
+	envVars = util.AppendOverwriteEnv(envVars, backup.Spec.Env)
+
+	args := []string{
+		"verify",
+		fmt.Sprintf("--namespace=%s", ns),
+		fmt.Sprintf("--backupName=%s", name),
+		fmt.Sprintf("--requestTime=%s", requestTime.UTC().Format(time.RFC3339)),
+	}
+
+	// the BR of the cluster version is used unless the tool image is tagged
+	brImage := backup.Spec.ToolImage
+	if brImage == "" || !strings.ContainsRune(brImage, ':') {
+		tc, err := bm.deps.TiDBClusterLister.TidbClusters(backupNamespace).Get(backup.Spec.BR.Cluster)
+		if err != nil {
+			return nil, fmt.Sprintf("failed to fetch tidbcluster %s/%s", backupNamespace, backup.Spec.BR.Cluster), err
+		}
+		_, tikvVersion := backuputil.ParseImage(tc.TiKVImage())
+		if brImage == "" {
+			brImage = "pingcap/br:" + tikvVersion
+		} else {
+			brImage = fmt.Sprintf("%s:%s", brImage, tikvVersion)
+		}
+	}
+
+	jobLabels := util.CombineStringMap(label.NewBackup().Instance(backup.GetInstanceName()).VerifyJob().Backup(name), backup.Labels)
+	podLabels := jobLabels
+	jobAnnotations := backup.Annotations
+	podAnnotations := jobAnnotations
+
+	brVolumeMount := corev1.VolumeMount{
+		Name:      "br-bin",
+		ReadOnly:  false,
+		MountPath: util.BRBinPath,
+	}
+	volumeMounts := []corev1.VolumeMount{brVolumeMount}
+	volumes := []corev1.Volume{
+		{
+			Name: "br-bin",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	// mount volumes if specified
+	if backup.Spec.Local != nil {
+		volumes = append(volumes, backup.Spec.Local.Volume)
+		volumeMounts = append(volumeMounts, backup.Spec.Local.VolumeMount)
+	}
+
+	serviceAccount := constants.DefaultServiceAccountName
+	if backup.Spec.ServiceAccount != "" {
+		serviceAccount = backup.Spec.ServiceAccount
+	}
+
+	podSpec := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      podLabels,
+			Annotations: podAnnotations,
+		},
+		Spec: corev1.PodSpec{
+			SecurityContext:    backup.Spec.PodSecurityContext,
+			ServiceAccountName: serviceAccount,
+			InitContainers: []corev1.Container{
+				{
+					Name:            "br",
+					Image:           brImage,
+					Command:         []string{"/bin/sh", "-c"},
+					Args:            []string{fmt.Sprintf("cp /br %s/br; echo 'BR copy finished'", util.BRBinPath)},
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					VolumeMounts:    []corev1.VolumeMount{brVolumeMount},
+					Resources:       backup.Spec.ResourceRequirements,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            label.VerifyJobLabelVal,
+					Image:           bm.deps.CLIConfig.TiDBBackupManagerImage,
+					Args:            args,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					VolumeMounts:    volumeMounts,
+					Env:             util.AppendEnvIfPresent(envVars, "TZ"),
+					Resources:       backup.Spec.ResourceRequirements,
+				},
+			},
+			RestartPolicy:     corev1.RestartPolicyNever,
+			Tolerations:       backup.Spec.Tolerations,
+			ImagePullSecrets:  backup.Spec.ImagePullSecrets,
+			Affinity:          backup.Spec.Affinity,
+			Volumes:           volumes,
+			PriorityClassName: backup.Spec.PriorityClassName,
+		},
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        backup.GetVerifyJobName(),
+			Namespace:   ns,
+			Labels:      jobLabels,
+			Annotations: jobAnnotations,
+			OwnerReferences: []metav1.OwnerReference{
+				controller.GetBackupOwnerRef(backup),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32Ptr(0),
+			Template:     *podSpec,
+		},
+	}
+
+	return job, "", nil
+}
+
 func (bm *backupManager) ensureBackupPVCExist(backup *v1alpha1.Backup) (string, error) {
 	ns := backup.GetNamespace()
 	name := backup.GetName()
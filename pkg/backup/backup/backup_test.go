@@ -22,6 +22,7 @@ import (
 
 	"github.com/onsi/gomega"
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/testutils"
 	"github.com/pingcap/tidb-operator/pkg/controller"
@@ -366,3 +367,76 @@ func TestClean(t *testing.T) {
 	}
 
 }
+
+func TestBackupManagerVerify(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+	deps := helper.Deps
+
+	bm := NewBackupManager(deps).(*backupManager)
+
+	for _, backup := range genValidBRBackups() {
+		completed := metav1.NewTime(time.Date(2021, 3, 1, 8, 0, 0, 0, time.UTC))
+		backup.Spec.Verify = true
+		backup.Status.TimeCompleted = completed
+		v1alpha1.UpdateBackupCondition(&backup.Status, &v1alpha1.BackupCondition{
+			Type:   v1alpha1.BackupComplete,
+			Status: corev1.ConditionTrue,
+		})
+		_, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Create(context.TODO(), backup, metav1.CreateOptions{})
+		g.Expect(err).Should(BeNil())
+		helper.CreateSecret(backup)
+		helper.CreateTC(backup.Spec.BR.ClusterNamespace, backup.Spec.BR.Cluster)
+
+		// the completion of the backup is the first verify request
+		g.Expect(v1alpha1.IsBackupVerifyRequested(backup)).To(BeTrue())
+		err = bm.syncVerifyJob(backup)
+		g.Expect(err).Should(BeNil())
+		g.Expect(backup.Status.Verify.Phase).To(Equal(v1alpha1.BackupVerifyRunning))
+		g.Expect(backup.Status.Verify.RequestTime.Equal(&completed)).To(BeTrue())
+		g.Expect(v1alpha1.IsBackupVerifyRequested(backup)).To(BeFalse())
+		job, err := deps.KubeClientset.BatchV1().Jobs(backup.Namespace).Get(context.TODO(), backup.GetVerifyJobName(), metav1.GetOptions{})
+		g.Expect(err).Should(BeNil())
+		g.Expect(job.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--requestTime=2021-03-01T08:00:00Z"))
+
+		g.Eventually(func() error {
+			_, err := deps.JobLister.Jobs(backup.Namespace).Get(backup.GetVerifyJobName())
+			return err
+		}, time.Second*10).Should(BeNil())
+
+		// a newer request waits for the running verification to finish
+		backup.Annotations = map[string]string{label.AnnBackupVerifyRequestTime: "2021-03-04T08:00:00Z"}
+		err = bm.syncVerifyJob(backup)
+		g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+		_, err = deps.KubeClientset.BatchV1().Jobs(backup.Namespace).Get(context.TODO(), backup.GetVerifyJobName(), metav1.GetOptions{})
+		g.Expect(err).Should(BeNil())
+		backup.Annotations = nil
+
+		// a failed verify job fails the verification
+		job.Status.Failed = 1
+		_, err = deps.KubeClientset.BatchV1().Jobs(backup.Namespace).UpdateStatus(context.TODO(), job, metav1.UpdateOptions{})
+		g.Expect(err).Should(BeNil())
+		g.Eventually(func() int32 {
+			job, _ := deps.JobLister.Jobs(backup.Namespace).Get(backup.GetVerifyJobName())
+			return job.Status.Failed
+		}, time.Second*10).Should(Equal(int32(1)))
+		err = bm.syncVerifyJob(backup)
+		g.Expect(err).Should(BeNil())
+		g.Expect(backup.Status.Verify.Phase).To(Equal(v1alpha1.BackupVerifyFailed))
+
+		// a newer request replaces the job of the last verification
+		backup.Annotations = map[string]string{label.AnnBackupVerifyRequestTime: "2021-03-08T08:00:00Z"}
+		g.Expect(v1alpha1.IsBackupVerifyRequested(backup)).To(BeTrue())
+		err = bm.syncVerifyJob(backup)
+		g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+		g.Eventually(func() bool {
+			_, err := deps.JobLister.Jobs(backup.Namespace).Get(backup.GetVerifyJobName())
+			return errors.IsNotFound(err)
+		}, time.Second*10).Should(BeTrue())
+		err = bm.syncVerifyJob(backup)
+		g.Expect(err).Should(BeNil())
+		g.Expect(backup.Status.Verify.Phase).To(Equal(v1alpha1.BackupVerifyRunning))
+		g.Expect(backup.Status.Verify.RequestTime.UTC().Format(time.RFC3339)).To(Equal("2021-03-08T08:00:00Z"))
+	}
+}
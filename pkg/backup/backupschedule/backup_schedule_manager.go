@@ -26,12 +26,18 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/backup/constants"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/util"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 	"github.com/robfig/cron"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog"
 )
 
+// maxSkippedBackups is the number of the most recent skipped backups recorded in the status
+const maxSkippedBackups = 10
+
 type nowFn func() time.Time
 
 type backupScheduleManager struct {
@@ -54,7 +60,7 @@ func (bm *backupScheduleManager) Sync(bs *v1alpha1.BackupSchedule) error {
 		return controller.IgnoreErrorf("backupSchedule %s/%s has been paused", bs.GetNamespace(), bs.GetName())
 	}
 
-	if err := bm.canPerformNextBackup(bs); err != nil {
+	if err := bm.requestVerify(bs); err != nil {
 		return err
 	}
 
@@ -63,12 +69,26 @@ func (bm *backupScheduleManager) Sync(bs *v1alpha1.BackupSchedule) error {
 		return err
 	}
 
+	skipped, err := bm.checkClusterHealth(bs, *scheduledTime)
+	if err != nil || skipped {
+		return err
+	}
+
+	if err := bm.canPerformNextBackup(bs); err != nil {
+		return err
+	}
+
 	// delete the last backup job for release the backup PVC
 	if err := bm.deleteLastBackupJob(bs); err != nil {
 		return nil
 	}
 
-	backup, err := createBackup(bm.deps.BackupControl, bs, *scheduledTime)
+	parent, err := bm.getIncrementalParent(bs, *scheduledTime)
+	if err != nil {
+		return err
+	}
+
+	backup, err := createBackup(bm.deps.BackupControl, bs, *scheduledTime, parent)
 	if err != nil {
 		return err
 	}
@@ -91,6 +111,11 @@ func (bm *backupScheduleManager) deleteLastBackupJob(bs *v1alpha1.BackupSchedule
 		return fmt.Errorf("backup schedule %s/%s, get backup %s failed, err: %v", ns, bsName, bs.Status.LastBackup, err)
 	}
 
+	if !isBackupFinished(backup) {
+		// the last backup is allowed to keep running along with the next one
+		return nil
+	}
+
 	jobName := backup.GetBackupJobName()
 	job, err := bm.deps.JobLister.Jobs(ns).Get(jobName)
 	if err != nil {
@@ -116,7 +141,38 @@ func (bm *backupScheduleManager) canPerformNextBackup(bs *v1alpha1.BackupSchedul
 		return fmt.Errorf("backup schedule %s/%s, get backup %s failed, err: %v", ns, bsName, bs.Status.LastBackup, err)
 	}
 
-	if v1alpha1.IsBackupComplete(backup) || (v1alpha1.IsBackupScheduled(backup) && v1alpha1.IsBackupFailed(backup)) {
+	if isBackupFinished(backup) {
+		return nil
+	}
+
+	switch bs.Spec.ConcurrencyPolicy {
+	case v1alpha1.ConcurrencyPolicyTypeAllow:
+		klog.Infof("backup schedule %s/%s, the last backup %s is still running, start the next one along with it", ns, bsName, bs.Status.LastBackup)
+		return nil
+	case v1alpha1.ConcurrencyPolicyTypeReplace:
+		if backup.DeletionTimestamp == nil {
+			backupsList, err := bm.getBackupList(bs)
+			if err != nil {
+				return err
+			}
+			if isChainParent(backupsList, backup.GetName()) {
+				// the incremental backups chained from it could not be restored without it
+				return controller.RequeueErrorf("backup schedule %s/%s, the last backup %s is still running and backups are chained from it, can't replace it", ns, bsName, bs.Status.LastBackup)
+			}
+			// the backup controller stops the backup job and cleans the backup after the job terminates
+			if err := bm.deps.BackupControl.DeleteBackup(backup); err != nil {
+				return fmt.Errorf("backup schedule %s/%s, replace the last backup %s failed, err: %v", ns, bsName, bs.Status.LastBackup, err)
+			}
+			klog.Infof("backup schedule %s/%s, the last backup %s is still running, replace it with the next one", ns, bsName, bs.Status.LastBackup)
+		}
+		// the next backup is not started until the job of the replaced one terminates
+		terminated, err := bm.isBackupJobTerminated(backup)
+		if err != nil {
+			return err
+		}
+		if !terminated {
+			return controller.RequeueErrorf("backup schedule %s/%s, wait for the job of the replaced backup %s to terminate", ns, bsName, bs.Status.LastBackup)
+		}
 		return nil
 	}
 	// If the last backup is in a failed state, but it is not scheduled yet,
@@ -124,6 +180,238 @@ func (bm *backupScheduleManager) canPerformNextBackup(bs *v1alpha1.BackupSchedul
 	return controller.RequeueErrorf("backup schedule %s/%s, the last backup %s is still running", ns, bsName, bs.Status.LastBackup)
 }
 
+// isBackupJobTerminated returns whether the backup job is gone or has finished
+func (bm *backupScheduleManager) isBackupJobTerminated(backup *v1alpha1.Backup) (bool, error) {
+	ns := backup.GetNamespace()
+	jobName := backup.GetBackupJobName()
+	job, err := bm.deps.JobLister.Jobs(ns).Get(jobName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("get backup %s/%s job %s failed, err: %v", ns, backup.GetName(), jobName, err)
+	}
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isBackupFinished returns whether the backup will not run any more
+func isBackupFinished(backup *v1alpha1.Backup) bool {
+	return v1alpha1.IsBackupComplete(backup) || (v1alpha1.IsBackupScheduled(backup) && v1alpha1.IsBackupFailed(backup))
+}
+
+// checkClusterHealth checks the TidbCluster of the scheduled BR backup by the unhealthy cluster policy,
+// it returns true if the scheduled backup is skipped and an error if the scheduled backup is delayed.
+func (bm *backupScheduleManager) checkClusterHealth(bs *v1alpha1.BackupSchedule, scheduledTime time.Time) (bool, error) {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	policy := bs.Spec.UnhealthyClusterPolicy
+	br := bs.Spec.BackupTemplate.BR
+	if policy == "" || policy == v1alpha1.UnhealthyClusterPolicyTypeIgnore || br == nil {
+		return false, nil
+	}
+
+	clusterNamespace := br.ClusterNamespace
+	if clusterNamespace == "" {
+		clusterNamespace = ns
+	}
+	var reason, message string
+	tc, err := bm.deps.TiDBClusterLister.TidbClusters(clusterNamespace).Get(br.Cluster)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return false, fmt.Errorf("backup schedule %s/%s, get tidbcluster %s/%s failed, err: %v", ns, bsName, clusterNamespace, br.Cluster, err)
+		}
+		reason, message = "ClusterNotFound", fmt.Sprintf("tidbcluster %s/%s is not found", clusterNamespace, br.Cluster)
+	} else if tc.PDUpgrading() || tc.TiKVUpgrading() || tc.TiDBUpgrading() || tc.TiFlashUpgrading() {
+		reason, message = "ClusterUpgrading", fmt.Sprintf("tidbcluster %s/%s is upgrading", clusterNamespace, br.Cluster)
+	} else if cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterReady); cond == nil || cond.Status != corev1.ConditionTrue {
+		reason, message = "ClusterNotReady", fmt.Sprintf("tidbcluster %s/%s is not ready", clusterNamespace, br.Cluster)
+		if cond != nil && cond.Message != "" {
+			message = fmt.Sprintf("%s, %s", message, cond.Message)
+		}
+	} else {
+		return false, nil
+	}
+
+	if policy == v1alpha1.UnhealthyClusterPolicyTypeDelay {
+		return false, controller.RequeueErrorf("backup schedule %s/%s, the backup scheduled at %s is delayed, %s",
+			ns, bsName, scheduledTime.Format(time.RFC3339), message)
+	}
+
+	klog.Infof("backup schedule %s/%s, skip the backup scheduled at %s, %s", ns, bsName, scheduledTime.Format(time.RFC3339), message)
+	bs.Status.SkippedBackups = append(bs.Status.SkippedBackups, v1alpha1.SkippedBackup{
+		ScheduledTime: metav1.Time{Time: scheduledTime},
+		Reason:        reason,
+		Message:       message,
+	})
+	if len(bs.Status.SkippedBackups) > maxSkippedBackups {
+		bs.Status.SkippedBackups = bs.Status.SkippedBackups[len(bs.Status.SkippedBackups)-maxSkippedBackups:]
+	}
+	return true, nil
+}
+
+// requestVerify requests the verification of the newest completed BR backup of the schedule
+// when the verify schedule is due, the verifications missed meanwhile are not made up.
+func (bm *backupScheduleManager) requestVerify(bs *v1alpha1.BackupSchedule) error {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	if bs.Spec.VerifySchedule == "" {
+		return nil
+	}
+	sched, err := cron.ParseStandard(bs.Spec.VerifySchedule)
+	if err != nil {
+		return fmt.Errorf("parse backup schedule %s/%s verify cron format %s failed, err: %v", ns, bsName, bs.Spec.VerifySchedule, err)
+	}
+
+	earliestTime := bs.ObjectMeta.CreationTimestamp.Time
+	if bs.Status.LastVerifyTime != nil {
+		earliestTime = bs.Status.LastVerifyTime.Time
+	}
+	now := bm.now()
+	if sched.Next(earliestTime).After(now) {
+		return nil
+	}
+
+	backupsList, err := bm.getBackupList(bs)
+	if err != nil {
+		return err
+	}
+	var newest *v1alpha1.Backup
+	for _, backup := range backupsList {
+		if backup.Spec.BR == nil || backup.DeletionTimestamp != nil || !v1alpha1.IsBackupComplete(backup) {
+			continue
+		}
+		if newest == nil || newest.CreationTimestamp.Before(&backup.CreationTimestamp) {
+			newest = backup
+		}
+	}
+
+	if newest == nil {
+		klog.Infof("backup schedule %s/%s has no completed backup to verify", ns, bsName)
+	} else if err := bm.deps.BackupControl.RequestVerifyBackup(newest, now); err != nil {
+		return fmt.Errorf("backup schedule %s/%s, request verify of backup %s failed, err: %v", ns, bsName, newest.GetName(), err)
+	}
+	bs.Status.LastVerifyTime = &metav1.Time{Time: now}
+	return nil
+}
+
+// getIncrementalParent returns the newest completed backup which the next backup of the schedule is chained from,
+// it returns nil if a full backup should be taken because there is no such backup, the chain is broken,
+// or the full backup of the chain is older than the full backup interval.
+func (bm *backupScheduleManager) getIncrementalParent(bs *v1alpha1.BackupSchedule, timestamp time.Time) (*v1alpha1.Backup, error) {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	if bs.Spec.FullBackupInterval == nil || bs.Spec.BackupTemplate.BR == nil {
+		return nil, nil
+	}
+	interval, err := time.ParseDuration(*bs.Spec.FullBackupInterval)
+	if err != nil {
+		return nil, fmt.Errorf("backup schedule %s/%s, invalid FullBackupInterval %s", ns, bsName, *bs.Spec.FullBackupInterval)
+	}
+
+	backupsList, err := bm.getBackupList(bs)
+	if err != nil {
+		return nil, err
+	}
+	backups := make(map[string]*v1alpha1.Backup, len(backupsList))
+	var parent *v1alpha1.Backup
+	for _, backup := range backupsList {
+		backups[backup.GetName()] = backup
+		if backup.Spec.BR == nil || backup.DeletionTimestamp != nil || !v1alpha1.IsBackupComplete(backup) || backup.Status.CommitTs == "" {
+			continue
+		}
+		if parent == nil || parent.CreationTimestamp.Before(&backup.CreationTimestamp) {
+			parent = backup
+		}
+	}
+	if parent == nil {
+		return nil, nil
+	}
+
+	// find the full backup of the chain, all the backups in the chain are required to restore the parent
+	full := parent
+	for i := 0; i < len(backupsList); i++ {
+		name := getParentBackupName(full)
+		if name == "" {
+			break
+		}
+		backup, ok := backups[name]
+		if !ok || backup.DeletionTimestamp != nil {
+			klog.Warningf("backup schedule %s/%s, backup %s of the chain of backup %s is gone, take a full backup", ns, bsName, name, parent.GetName())
+			return nil, nil
+		}
+		full = backup
+	}
+	if !full.CreationTimestamp.Add(interval).After(timestamp) {
+		return nil, nil
+	}
+	return parent, nil
+}
+
+// getParentBackupName returns the name of the backup which an incremental backup is chained from,
+// the annotation is used before the backup is scheduled.
+func getParentBackupName(backup *v1alpha1.Backup) string {
+	if backup.Status.ParentBackup != "" {
+		return backup.Status.ParentBackup
+	}
+	return backup.Annotations[label.AnnBackupParent]
+}
+
+// isChainParent returns whether any backup is chained from the named backup
+func isChainParent(backupsList []*v1alpha1.Backup, name string) bool {
+	for _, backup := range backupsList {
+		if getParentBackupName(backup) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// filterDependedBackups returns the expired backups which no retained backup is chained from,
+// so a full backup is never deleted while its incremental backups are retained.
+func filterDependedBackups(backupsList []*v1alpha1.Backup, expiredBackups []*v1alpha1.Backup) []*v1alpha1.Backup {
+	backups := make(map[string]*v1alpha1.Backup, len(backupsList))
+	for _, backup := range backupsList {
+		backups[backup.GetName()] = backup
+	}
+	expired := make(map[string]bool, len(expiredBackups))
+	for _, backup := range expiredBackups {
+		expired[backup.GetName()] = true
+	}
+
+	depended := map[string]bool{}
+	for _, backup := range backupsList {
+		if expired[backup.GetName()] {
+			continue
+		}
+		for name := getParentBackupName(backup); name != "" && !depended[name]; {
+			depended[name] = true
+			parent, ok := backups[name]
+			if !ok {
+				break
+			}
+			name = getParentBackupName(parent)
+		}
+	}
+
+	var result []*v1alpha1.Backup
+	for _, backup := range expiredBackups {
+		if depended[backup.GetName()] {
+			klog.V(4).Infof("backup %s/%s is retained for the incremental backups chained from it", backup.GetNamespace(), backup.GetName())
+			continue
+		}
+		result = append(result, backup)
+	}
+	return result
+}
+
 // getLastScheduledTime return the newest time need to be scheduled according last backup time.
 // the return time is not before now and return nil if there's no such time.
 func getLastScheduledTime(bs *v1alpha1.BackupSchedule, nowFn nowFn) (*time.Time, error) {
@@ -151,6 +439,11 @@ func getLastScheduledTime(bs *v1alpha1.BackupSchedule, nowFn nowFn) (*time.Time,
 		earliestTime = bs.ObjectMeta.CreationTimestamp.Time
 	}
 
+	// the schedules up to the last skipped backup are done
+	if n := len(bs.Status.SkippedBackups); n > 0 && bs.Status.SkippedBackups[n-1].ScheduledTime.After(earliestTime) {
+		earliestTime = bs.Status.SkippedBackups[n-1].ScheduledTime.Time
+	}
+
 	now := nowFn()
 	if earliestTime.After(now) {
 		// timestamp fallback, waiting for the next backup schedule period
@@ -191,7 +484,7 @@ func getLastScheduledTime(bs *v1alpha1.BackupSchedule, nowFn nowFn) (*time.Time,
 	return &scheduledTime, nil
 }
 
-func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time) *v1alpha1.Backup {
+func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time, parent *v1alpha1.Backup) *v1alpha1.Backup {
 	ns := bs.GetNamespace()
 	bsName := bs.GetName()
 
@@ -221,6 +514,8 @@ func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time) *v1alpha1.Bac
 			backupSpec.S3.Prefix = path.Join(backupSpec.S3.Prefix, backupPrefix)
 		} else if backupSpec.Gcs != nil {
 			backupSpec.Gcs.Prefix = path.Join(backupSpec.Gcs.Prefix, backupPrefix)
+		} else if backupSpec.Azblob != nil {
+			backupSpec.Azblob.Prefix = path.Join(backupSpec.Azblob.Prefix, backupPrefix)
 		} else if backupSpec.Local != nil {
 			backupSpec.Local.Prefix = path.Join(backupSpec.Local.Prefix, backupPrefix)
 		}
@@ -230,6 +525,13 @@ func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time) *v1alpha1.Bac
 		backupSpec.ImagePullSecrets = bs.Spec.ImagePullSecrets
 	}
 
+	annotations := bs.Annotations
+	if parent != nil {
+		// take an incremental backup of the changes after the parent backup
+		backupSpec.BR.LastBackupTS = parent.Status.CommitTs
+		annotations = util.CombineStringMap(map[string]string{label.AnnBackupParent: parent.GetName()}, bs.Annotations)
+	}
+
 	bsLabel := util.CombineStringMap(label.NewBackupSchedule().Instance(bsName).BackupSchedule(bsName), bs.Labels)
 	backup := &v1alpha1.Backup{
 		Spec: backupSpec,
@@ -237,7 +539,7 @@ func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time) *v1alpha1.Bac
 			Namespace:   ns,
 			Name:        bs.GetBackupCRDName(timestamp),
 			Labels:      bsLabel,
-			Annotations: bs.Annotations,
+			Annotations: annotations,
 			OwnerReferences: []metav1.OwnerReference{
 				controller.GetBackupScheduleOwnerRef(bs),
 			},
@@ -247,8 +549,8 @@ func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time) *v1alpha1.Bac
 	return backup
 }
 
-func createBackup(bkController controller.BackupControlInterface, bs *v1alpha1.BackupSchedule, timestamp time.Time) (*v1alpha1.Backup, error) {
-	bk := buildBackup(bs, timestamp)
+func createBackup(bkController controller.BackupControlInterface, bs *v1alpha1.BackupSchedule, timestamp time.Time, parent *v1alpha1.Backup) (*v1alpha1.Backup, error) {
+	bk := buildBackup(bs, timestamp, parent)
 	return bkController.CreateBackup(bk)
 }
 
@@ -256,6 +558,13 @@ func (bm *backupScheduleManager) backupGC(bs *v1alpha1.BackupSchedule) {
 	ns := bs.GetNamespace()
 	bsName := bs.GetName()
 
+	// the tiered retention policy is preferred over MaxBackups and MaxReservedTime.
+	if bs.Spec.TieredRetention != nil {
+		bm.backupGCByTieredRetention(bs)
+		return
+	}
+	bs.Status.RetainedBackups = nil
+
 	// if MaxBackups and MaxReservedTime are set at the same time, MaxReservedTime is preferred.
 	if bs.Spec.MaxReservedTime != nil {
 		bm.backupGCByMaxReservedTime(bs)
@@ -286,11 +595,16 @@ func (bm *backupScheduleManager) backupGCByMaxReservedTime(bs *v1alpha1.BackupSc
 		return
 	}
 
-	var deleteCount int
+	var expiredBackups []*v1alpha1.Backup
 	for _, backup := range backupsList {
 		if backup.CreationTimestamp.Add(reservedTime).After(bm.now()) {
 			continue
 		}
+		expiredBackups = append(expiredBackups, backup)
+	}
+
+	var deleteCount int
+	for _, backup := range filterDependedBackups(backupsList, expiredBackups) {
 		// delete the expired backup
 		if err := bm.deps.BackupControl.DeleteBackup(backup); err != nil {
 			klog.Errorf("backup schedule %s/%s gc backup %s failed, err %v", ns, bsName, backup.GetName(), err)
@@ -318,11 +632,13 @@ func (bm *backupScheduleManager) backupGCByMaxBackups(bs *v1alpha1.BackupSchedul
 
 	sort.Sort(byCreateTimeDesc(backupsList))
 
+	var expiredBackups []*v1alpha1.Backup
+	if len(backupsList) > int(*bs.Spec.MaxBackups) {
+		expiredBackups = backupsList[*bs.Spec.MaxBackups:]
+	}
+
 	var deleteCount int
-	for i, backup := range backupsList {
-		if i < int(*bs.Spec.MaxBackups) {
-			continue
-		}
+	for _, backup := range filterDependedBackups(backupsList, expiredBackups) {
 		// delete the backup
 		if err := bm.deps.BackupControl.DeleteBackup(backup); err != nil {
 			klog.Errorf("backup schedule %s/%s gc backup %s failed, err %v", ns, bsName, backup.GetName(), err)
@@ -338,6 +654,113 @@ func (bm *backupScheduleManager) backupGCByMaxBackups(bs *v1alpha1.BackupSchedul
 	}
 }
 
+// backupGCByTieredRetention deletes the backups which belong to no tier of the tiered retention policy,
+// the backups still running and the failed ones newer than the newest completed backup are left alone.
+func (bm *backupScheduleManager) backupGCByTieredRetention(bs *v1alpha1.BackupSchedule) {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	backupsList, err := bm.getBackupList(bs)
+	if err != nil {
+		klog.Errorf("backupGCByTieredRetention failed, err: %s", err)
+		return
+	}
+
+	sort.Sort(byCreateTimeDesc(backupsList))
+	tiers := getRetentionTiers(backupsList, bs.Spec.TieredRetention)
+
+	var expiredBackups []*v1alpha1.Backup
+	newerComplete := false
+	for _, backup := range backupsList {
+		switch {
+		case len(tiers[backup.GetName()]) > 0:
+		case v1alpha1.IsBackupComplete(backup), v1alpha1.IsBackupFailed(backup) && newerComplete:
+			expiredBackups = append(expiredBackups, backup)
+		}
+		if v1alpha1.IsBackupComplete(backup) {
+			newerComplete = true
+		}
+	}
+
+	deletedBackups := filterDependedBackups(backupsList, expiredBackups)
+	deleted := make(map[string]bool, len(deletedBackups))
+	for _, backup := range deletedBackups {
+		deleted[backup.GetName()] = true
+	}
+	for _, backup := range expiredBackups {
+		if !deleted[backup.GetName()] {
+			tiers[backup.GetName()] = append(tiers[backup.GetName()], v1alpha1.RetentionTierChain)
+		}
+	}
+
+	var retainedBackups []v1alpha1.RetainedBackup
+	for _, backup := range backupsList {
+		if len(tiers[backup.GetName()]) > 0 {
+			retainedBackups = append(retainedBackups, v1alpha1.RetainedBackup{
+				Name:  backup.GetName(),
+				Tiers: tiers[backup.GetName()],
+			})
+		}
+	}
+	bs.Status.RetainedBackups = retainedBackups
+
+	var deleteCount int
+	for _, backup := range deletedBackups {
+		// delete the backup out of all the tiers
+		if err := bm.deps.BackupControl.DeleteBackup(backup); err != nil {
+			klog.Errorf("backup schedule %s/%s gc backup %s failed, err %v", ns, bsName, backup.GetName(), err)
+			return
+		}
+		deleteCount += 1
+		klog.Infof("backup schedule %s/%s gc backup %s success", ns, bsName, backup.GetName())
+	}
+
+	if deleteCount == len(backupsList) {
+		// All backups have been deleted, so the last backup information in the backupSchedule should be reset
+		bm.resetLastBackup(bs)
+	}
+}
+
+// getRetentionTiers returns the tiers each completed backup belongs to, the newest completed backup of each of
+// the most recent days, weeks and months which have a completed backup is kept. backupsList is sorted newest first.
+func getRetentionTiers(backupsList []*v1alpha1.Backup, policy *v1alpha1.TieredRetentionPolicy) map[string][]v1alpha1.RetentionTier {
+	periods := []struct {
+		tier  v1alpha1.RetentionTier
+		count *int32
+		key   func(t time.Time) string
+	}{
+		{v1alpha1.RetentionTierDaily, policy.Daily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{v1alpha1.RetentionTierWeekly, policy.Weekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{v1alpha1.RetentionTierMonthly, policy.Monthly, func(t time.Time) string { return t.Format("2006-01") }},
+	}
+
+	tiers := map[string][]v1alpha1.RetentionTier{}
+	for _, period := range periods {
+		if period.count == nil {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, backup := range backupsList {
+			if !v1alpha1.IsBackupComplete(backup) {
+				continue
+			}
+			key := period.key(backup.CreationTimestamp.Time.Local())
+			if seen[key] {
+				continue
+			}
+			if len(seen) >= int(*period.count) {
+				break
+			}
+			seen[key] = true
+			tiers[backup.GetName()] = append(tiers[backup.GetName()], period.tier)
+		}
+	}
+	return tiers
+}
+
 func (bm *backupScheduleManager) resetLastBackup(bs *v1alpha1.BackupSchedule) {
 	bs.Status.LastBackupTime = nil
 	bs.Status.LastBackup = ""
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/constants"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -125,6 +126,57 @@ func TestManager(t *testing.T) {
 	helper.checkBacklist(bs.Namespace, 1)
 }
 
+func TestRequestVerify(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.close()
+	deps := helper.deps
+	m := NewBackupScheduleManager(deps).(*backupScheduleManager)
+
+	created := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Namespace = "ns"
+	bs.Name = "bsname"
+	bs.CreationTimestamp = metav1.Time{Time: created}
+	bs.Spec.VerifySchedule = "0 3 * * *" // Verify at 3am every day
+
+	// the newest backup is still running, the one before it is verified
+	for i, complete := range []bool{true, true, false} {
+		bk := &v1alpha1.Backup{}
+		bk.Namespace = bs.Namespace
+		bk.Name = fmt.Sprintf("backup-%d", i)
+		bk.Labels = label.NewBackupSchedule().Instance(bs.Name).BackupSchedule(bs.Name)
+		bk.CreationTimestamp = metav1.Time{Time: created.Add(time.Duration(i) * time.Hour)}
+		bk.Spec.BR = &v1alpha1.BRConfig{Cluster: "demo"}
+		if complete {
+			v1alpha1.UpdateBackupCondition(&bk.Status, &v1alpha1.BackupCondition{
+				Type:   v1alpha1.BackupComplete,
+				Status: v1.ConditionTrue,
+			})
+		}
+		helper.createBackup(bk)
+	}
+
+	// not due yet
+	m.now = func() time.Time { return created.Add(2 * time.Hour) }
+	g.Expect(m.requestVerify(bs)).Should(Succeed())
+	g.Expect(bs.Status.LastVerifyTime).Should(BeNil())
+
+	now := created.Add(27 * time.Hour)
+	m.now = func() time.Time { return now }
+	g.Expect(m.requestVerify(bs)).Should(Succeed())
+	g.Expect(bs.Status.LastVerifyTime.Time).Should(Equal(now))
+	for i, requested := range []bool{false, true, false} {
+		bk, err := deps.Clientset.PingcapV1alpha1().Backups(bs.Namespace).Get(context.TODO(), fmt.Sprintf("backup-%d", i), metav1.GetOptions{})
+		g.Expect(err).Should(BeNil())
+		if requested {
+			g.Expect(bk.Annotations).Should(HaveKeyWithValue(label.AnnBackupVerifyRequestTime, "2021-03-02T03:00:00Z"))
+		} else {
+			g.Expect(bk.Annotations).ShouldNot(HaveKey(label.AnnBackupVerifyRequestTime))
+		}
+	}
+}
+
 func TestGetLastScheduledTime(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -198,14 +250,14 @@ func TestBuildBackup(t *testing.T) {
 	}
 
 	// test BR == nil
-	get = buildBackup(bs, now)
+	get = buildBackup(bs, now, nil)
 	if diff := cmp.Diff(bk, get); diff != "" {
 		t.Errorf("unexpected (-want, +got): %s", diff)
 	}
 	// should keep StorageSize from BackupSchedule
 	bs.Spec.StorageSize = "9527G"
 	bk.Spec.StorageSize = bs.Spec.StorageSize
-	get = buildBackup(bs, now)
+	get = buildBackup(bs, now, nil)
 	if diff := cmp.Diff(bk, get); diff != "" {
 		t.Errorf("unexpected (-want, +got): %s", diff)
 	}
@@ -214,10 +266,342 @@ func TestBuildBackup(t *testing.T) {
 	bs.Spec.BackupTemplate.BR = &v1alpha1.BRConfig{}
 	bk.Spec.BR = bs.Spec.BackupTemplate.BR.DeepCopy()
 	bk.Spec.StorageSize = "" // no use for BR
-	get = buildBackup(bs, now)
+	get = buildBackup(bs, now, nil)
+	if diff := cmp.Diff(bk, get); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+
+	// test incremental backup
+	parent := &v1alpha1.Backup{}
+	parent.Name = "parent"
+	parent.Status.CommitTs = "421762809912885249"
+	bk.Spec.BR.LastBackupTS = parent.Status.CommitTs
+	bk.Annotations = map[string]string{label.AnnBackupParent: parent.Name}
+	get = buildBackup(bs, now, parent)
 	if diff := cmp.Diff(bk, get); diff != "" {
 		t.Errorf("unexpected (-want, +got): %s", diff)
 	}
+	if bs.Spec.BackupTemplate.BR.LastBackupTS != "" || bs.Annotations != nil {
+		t.Errorf("backup schedule is modified: %v", bs)
+	}
+}
+
+func TestGetIncrementalParent(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.close()
+	m := NewBackupScheduleManager(helper.deps).(*backupScheduleManager)
+
+	created := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Namespace = "ns"
+	bs.Name = "bsname"
+	bs.Spec.BackupTemplate.BR = &v1alpha1.BRConfig{Cluster: "demo"}
+
+	// a full backup with two incremental backups, the newest one is still running
+	for i, parent := range []string{"", "backup-0", "backup-1"} {
+		bk := &v1alpha1.Backup{}
+		bk.Namespace = bs.Namespace
+		bk.Name = fmt.Sprintf("backup-%d", i)
+		bk.Labels = label.NewBackupSchedule().Instance(bs.Name).BackupSchedule(bs.Name)
+		bk.CreationTimestamp = metav1.Time{Time: created.Add(time.Duration(i) * 24 * time.Hour)}
+		bk.Spec.BR = &v1alpha1.BRConfig{Cluster: "demo"}
+		bk.Status.ParentBackup = parent
+		if i < 2 {
+			bk.Status.CommitTs = fmt.Sprintf("42176280991288524%d", i)
+			v1alpha1.UpdateBackupCondition(&bk.Status, &v1alpha1.BackupCondition{
+				Type:   v1alpha1.BackupComplete,
+				Status: v1.ConditionTrue,
+			})
+		}
+		helper.createBackup(bk)
+	}
+
+	// full backups only
+	parent, err := m.getIncrementalParent(bs, created.Add(72*time.Hour))
+	g.Expect(err).Should(BeNil())
+	g.Expect(parent).Should(BeNil())
+
+	bs.Spec.FullBackupInterval = pointer.StringPtr("168h")
+	parent, err = m.getIncrementalParent(bs, created.Add(72*time.Hour))
+	g.Expect(err).Should(BeNil())
+	g.Expect(parent.Name).Should(Equal("backup-1"))
+
+	// the full backup is too old
+	parent, err = m.getIncrementalParent(bs, created.Add(168*time.Hour))
+	g.Expect(err).Should(BeNil())
+	g.Expect(parent).Should(BeNil())
+
+	// the chain is broken
+	helper.deleteBackup(&v1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Namespace: bs.Namespace, Name: "backup-0"}})
+	parent, err = m.getIncrementalParent(bs, created.Add(72*time.Hour))
+	g.Expect(err).Should(BeNil())
+	g.Expect(parent).Should(BeNil())
+}
+
+func TestFilterDependedBackups(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newBackup := func(name, parent string) *v1alpha1.Backup {
+		bk := &v1alpha1.Backup{}
+		bk.Name = name
+		bk.Status.ParentBackup = parent
+		return bk
+	}
+	// two chains, full-0 <- incr-1 <- incr-2 and full-3 <- incr-4
+	backups := []*v1alpha1.Backup{
+		newBackup("full-0", ""),
+		newBackup("incr-1", "full-0"),
+		newBackup("incr-2", "incr-1"),
+		newBackup("full-3", ""),
+		newBackup("incr-4", "full-3"),
+	}
+	names := func(backups []*v1alpha1.Backup) []string {
+		var names []string
+		for _, bk := range backups {
+			names = append(names, bk.Name)
+		}
+		return names
+	}
+
+	g.Expect(names(filterDependedBackups(backups, backups[:2]))).Should(BeEmpty())
+	g.Expect(names(filterDependedBackups(backups, backups[:3]))).Should(Equal([]string{"full-0", "incr-1", "incr-2"}))
+	g.Expect(names(filterDependedBackups(backups, backups[:4]))).Should(Equal([]string{"full-0", "incr-1", "incr-2"}))
+	g.Expect(names(filterDependedBackups(backups, backups[3:4]))).Should(BeEmpty())
+}
+
+func TestGetRetentionTiers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// a completed backup at noon every day from 2021-01-01 to 2021-03-31, newest first
+	var backups []*v1alpha1.Backup
+	for day := time.Date(2021, 3, 31, 12, 0, 0, 0, time.Local); day.Year() == 2021; day = day.AddDate(0, 0, -1) {
+		bk := &v1alpha1.Backup{}
+		bk.Name = day.Format("2006-01-02")
+		bk.CreationTimestamp = metav1.Time{Time: day}
+		v1alpha1.UpdateBackupCondition(&bk.Status, &v1alpha1.BackupCondition{
+			Type:   v1alpha1.BackupComplete,
+			Status: v1.ConditionTrue,
+		})
+		backups = append(backups, bk)
+	}
+	// the newest backup is still running
+	running := &v1alpha1.Backup{}
+	running.Name = "running"
+	running.CreationTimestamp = metav1.Time{Time: time.Date(2021, 4, 1, 12, 0, 0, 0, time.Local)}
+	backups = append([]*v1alpha1.Backup{running}, backups...)
+
+	tiers := getRetentionTiers(backups, &v1alpha1.TieredRetentionPolicy{
+		Daily:   pointer.Int32Ptr(7),
+		Weekly:  pointer.Int32Ptr(4),
+		Monthly: pointer.Int32Ptr(3),
+	})
+	daily := []v1alpha1.RetentionTier{v1alpha1.RetentionTierDaily}
+	g.Expect(tiers).Should(Equal(map[string][]v1alpha1.RetentionTier{
+		"2021-03-31": {v1alpha1.RetentionTierDaily, v1alpha1.RetentionTierWeekly, v1alpha1.RetentionTierMonthly},
+		"2021-03-30": daily,
+		"2021-03-29": daily,
+		"2021-03-28": {v1alpha1.RetentionTierDaily, v1alpha1.RetentionTierWeekly},
+		"2021-03-27": daily,
+		"2021-03-26": daily,
+		"2021-03-25": daily,
+		"2021-03-21": {v1alpha1.RetentionTierWeekly},
+		"2021-03-14": {v1alpha1.RetentionTierWeekly},
+		"2021-02-28": {v1alpha1.RetentionTierMonthly},
+		"2021-01-31": {v1alpha1.RetentionTierMonthly},
+	}))
+}
+
+func TestBackupGCByTieredRetention(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.close()
+	m := NewBackupScheduleManager(helper.deps).(*backupScheduleManager)
+
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Namespace = "ns"
+	bs.Name = "bsname"
+	bs.Spec.TieredRetention = &v1alpha1.TieredRetentionPolicy{Daily: pointer.Int32Ptr(2)}
+
+	for i, tc := range []struct {
+		name      string
+		parent    string
+		condition v1alpha1.BackupConditionType
+	}{
+		{name: "complete-old", condition: v1alpha1.BackupComplete},
+		{name: "failed-old", condition: v1alpha1.BackupFailed},
+		{name: "full", condition: v1alpha1.BackupComplete},
+		{name: "incr", parent: "full", condition: v1alpha1.BackupComplete},
+		{name: "complete", condition: v1alpha1.BackupComplete},
+		{name: "failed", condition: v1alpha1.BackupFailed},
+		{name: "running"},
+	} {
+		bk := &v1alpha1.Backup{}
+		bk.Namespace = bs.Namespace
+		bk.Name = tc.name
+		bk.Labels = label.NewBackupSchedule().Instance(bs.Name).BackupSchedule(bs.Name)
+		bk.CreationTimestamp = metav1.Time{Time: time.Date(2021, 3, 1+i, 12, 0, 0, 0, time.Local)}
+		bk.Status.ParentBackup = tc.parent
+		if tc.condition != "" {
+			v1alpha1.UpdateBackupCondition(&bk.Status, &v1alpha1.BackupCondition{
+				Type:   tc.condition,
+				Status: v1.ConditionTrue,
+			})
+		}
+		helper.createBackup(bk)
+	}
+
+	m.backupGC(bs)
+	bks := helper.checkBacklist(bs.Namespace, 5)
+	var names []string
+	for _, bk := range bks.Items {
+		names = append(names, bk.Name)
+	}
+	g.Expect(names).Should(ConsistOf("full", "incr", "complete", "failed", "running"))
+	g.Expect(bs.Status.RetainedBackups).Should(Equal([]v1alpha1.RetainedBackup{
+		{Name: "complete", Tiers: []v1alpha1.RetentionTier{v1alpha1.RetentionTierDaily}},
+		{Name: "incr", Tiers: []v1alpha1.RetentionTier{v1alpha1.RetentionTierDaily}},
+		{Name: "full", Tiers: []v1alpha1.RetentionTier{v1alpha1.RetentionTierChain}},
+	}))
+}
+
+func TestConcurrencyPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.close()
+	m := NewBackupScheduleManager(helper.deps).(*backupScheduleManager)
+
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Namespace = "ns"
+	bs.Name = "bsname"
+	bs.Status.LastBackup = "running"
+
+	bk := &v1alpha1.Backup{}
+	bk.Namespace = bs.Namespace
+	bk.Name = bs.Status.LastBackup
+	bk.Status.Conditions = append(bk.Status.Conditions, v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupRunning,
+		Status: v1.ConditionTrue,
+	})
+	helper.createBackup(bk)
+
+	// Forbid by default
+	err := m.canPerformNextBackup(bs)
+	g.Expect(err).Should(BeAssignableToTypeOf(&controller.RequeueError{}))
+
+	// the running backup is left alone
+	bs.Spec.ConcurrencyPolicy = v1alpha1.ConcurrencyPolicyTypeAllow
+	g.Expect(m.canPerformNextBackup(bs)).Should(BeNil())
+	g.Expect(m.deleteLastBackupJob(bs)).Should(BeNil())
+	helper.checkBacklist(bs.Namespace, 1)
+
+	// the running backup is kept while a backup is chained from it
+	bs.Spec.ConcurrencyPolicy = v1alpha1.ConcurrencyPolicyTypeReplace
+	incr := &v1alpha1.Backup{}
+	incr.Namespace = bs.Namespace
+	incr.Name = "incr"
+	incr.Labels = label.NewBackupSchedule().Instance(bs.Name).BackupSchedule(bs.Name)
+	incr.Annotations = map[string]string{label.AnnBackupParent: bk.Name}
+	helper.createBackup(incr)
+	err = m.canPerformNextBackup(bs)
+	g.Expect(err).Should(BeAssignableToTypeOf(&controller.RequeueError{}))
+	helper.checkBacklist(bs.Namespace, 2)
+
+	// the running backup is deleted, the next one waits for its job to terminate
+	helper.deleteBackup(incr)
+	job := &batchv1.Job{}
+	job.Namespace = bs.Namespace
+	job.Name = bk.GetBackupJobName()
+	_, err = helper.deps.KubeClientset.BatchV1().Jobs(job.Namespace).Create(context.TODO(), job, metav1.CreateOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Eventually(func() error {
+		_, err := helper.deps.JobLister.Jobs(job.Namespace).Get(job.Name)
+		return err
+	}, time.Second*10).Should(BeNil())
+	err = m.canPerformNextBackup(bs)
+	g.Expect(err).Should(BeAssignableToTypeOf(&controller.RequeueError{}))
+	helper.checkBacklist(bs.Namespace, 0)
+
+	// the job of the replaced backup terminates
+	g.Expect(m.isBackupJobTerminated(bk)).Should(BeFalse())
+	g.Expect(helper.deps.KubeClientset.BatchV1().Jobs(job.Namespace).Delete(context.TODO(), job.Name, metav1.DeleteOptions{})).Should(Succeed())
+	g.Eventually(func() bool {
+		terminated, err := m.isBackupJobTerminated(bk)
+		return err == nil && terminated
+	}, time.Second*10).Should(BeTrue())
+	g.Expect(m.canPerformNextBackup(bs)).Should(BeNil())
+}
+
+func TestCheckClusterHealth(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.close()
+	m := NewBackupScheduleManager(helper.deps).(*backupScheduleManager)
+	tcIndexer := helper.deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+
+	now := time.Date(2021, 3, 1, 0, 30, 0, 0, time.UTC)
+	scheduled := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Namespace = "ns"
+	bs.Name = "bsname"
+	bs.CreationTimestamp = metav1.Time{Time: scheduled.Add(-time.Hour)}
+	bs.Spec.Schedule = "0 * * * *"
+	bs.Spec.BackupTemplate.BR = &v1alpha1.BRConfig{Cluster: "demo"}
+
+	// the cluster health is ignored by default
+	skipped, err := m.checkClusterHealth(bs, scheduled)
+	g.Expect(skipped).Should(BeFalse())
+	g.Expect(err).Should(BeNil())
+
+	bs.Spec.UnhealthyClusterPolicy = v1alpha1.UnhealthyClusterPolicyTypeSkip
+	skipped, err = m.checkClusterHealth(bs, scheduled)
+	g.Expect(skipped).Should(BeTrue())
+	g.Expect(err).Should(BeNil())
+	g.Expect(bs.Status.SkippedBackups).Should(HaveLen(1))
+	g.Expect(bs.Status.SkippedBackups[0].Reason).Should(Equal("ClusterNotFound"))
+	g.Expect(bs.Status.SkippedBackups[0].ScheduledTime.Time).Should(Equal(scheduled))
+
+	// the skipped schedule is not taken again
+	scheduledTime, err := getLastScheduledTime(bs, func() time.Time { return now })
+	g.Expect(scheduledTime).Should(BeNil())
+	g.Expect(err).Should(BeNil())
+
+	tc := &v1alpha1.TidbCluster{}
+	tc.Namespace = bs.Namespace
+	tc.Name = "demo"
+	tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
+	g.Expect(tcIndexer.Add(tc)).Should(Succeed())
+	_, err = m.checkClusterHealth(bs, scheduled.Add(time.Hour))
+	g.Expect(err).Should(BeNil())
+	g.Expect(bs.Status.SkippedBackups).Should(HaveLen(2))
+	g.Expect(bs.Status.SkippedBackups[1].Reason).Should(Equal("ClusterUpgrading"))
+
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	g.Expect(tcIndexer.Update(tc)).Should(Succeed())
+	bs.Spec.UnhealthyClusterPolicy = v1alpha1.UnhealthyClusterPolicyTypeDelay
+	skipped, err = m.checkClusterHealth(bs, scheduled.Add(2*time.Hour))
+	g.Expect(skipped).Should(BeFalse())
+	g.Expect(err).Should(BeAssignableToTypeOf(&controller.RequeueError{}))
+	g.Expect(bs.Status.SkippedBackups).Should(HaveLen(2))
+
+	tc.Status.Conditions = []v1alpha1.TidbClusterCondition{{
+		Type:   v1alpha1.TidbClusterReady,
+		Status: v1.ConditionTrue,
+	}}
+	g.Expect(tcIndexer.Update(tc)).Should(Succeed())
+	skipped, err = m.checkClusterHealth(bs, scheduled.Add(2*time.Hour))
+	g.Expect(skipped).Should(BeFalse())
+	g.Expect(err).Should(BeNil())
+
+	// only the most recent skipped backups are recorded
+	bs.Spec.UnhealthyClusterPolicy = v1alpha1.UnhealthyClusterPolicyTypeSkip
+	g.Expect(tcIndexer.Delete(tc)).Should(Succeed())
+	for i := 0; i < maxSkippedBackups; i++ {
+		_, err = m.checkClusterHealth(bs, scheduled.Add(time.Duration(3+i)*time.Hour))
+		g.Expect(err).Should(BeNil())
+	}
+	g.Expect(bs.Status.SkippedBackups).Should(HaveLen(maxSkippedBackups))
+	g.Expect(bs.Status.SkippedBackups[0].ScheduledTime.Time).Should(Equal(scheduled.Add(3 * time.Hour)))
 }
 
 type helper struct {
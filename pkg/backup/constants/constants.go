@@ -38,6 +38,21 @@ const (
 	// GcsCredentialsKey represents the gcs service account credentials json key in related secret
 	GcsCredentialsKey = "credentials"
 
+	// AzblobAccountName represents the azure storage account name key in related secret
+	AzblobAccountName = "AZURE_STORAGE_ACCOUNT"
+
+	// AzblobAccountKey represents the azure storage account shared key in related secret
+	AzblobAccountKey = "AZURE_STORAGE_KEY"
+
+	// AzblobClientID represents the azure service principal client id key in related secret
+	AzblobClientID = "AZURE_CLIENT_ID"
+
+	// AzblobClientSecret represents the azure service principal client secret key in related secret
+	AzblobClientSecret = "AZURE_CLIENT_SECRET"
+
+	// AzblobTenantID represents the azure service principal tenant id key in related secret
+	AzblobTenantID = "AZURE_TENANT_ID"
+
 	// BackupManagerEnvVarPrefix represents the environment variable used for tidb-backup-manager must include this prefix
 	BackupManagerEnvVarPrefix = "BACKUP_MANAGER"
 
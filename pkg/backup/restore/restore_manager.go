@@ -433,6 +433,60 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 		brImage = toolImage
 	}
 
+	initContainers := []corev1.Container{
+		{
+			Name:            "br",
+			Image:           brImage,
+			Command:         []string{"/bin/sh", "-c"},
+			Args:            []string{fmt.Sprintf("cp /br %s/br; echo 'BR copy finished'", util.BRBinPath)},
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			VolumeMounts:    []corev1.VolumeMount{brVolumeMount},
+			Resources:       restore.Spec.ResourceRequirements,
+		},
+	}
+
+	if restore.Spec.Pitr != nil {
+		// the change log is downloaded into an emptyDir and replayed by reparo from the tidb-binlog image
+		reparoVolumeMount := corev1.VolumeMount{
+			Name:      "reparo-bin",
+			ReadOnly:  false,
+			MountPath: util.ReparoBinPath,
+		}
+		volumeMounts = append(volumeMounts, reparoVolumeMount, corev1.VolumeMount{
+			Name:      "pitr-log",
+			ReadOnly:  false,
+			MountPath: util.PitrLogPath,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: "reparo-bin",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}, corev1.Volume{
+			Name: "pitr-log",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+
+		reparoImage := "pingcap/tidb-binlog:" + tikvVersion
+		if restore.Spec.Pitr.LogToolImage != "" {
+			reparoImage = restore.Spec.Pitr.LogToolImage
+			if !strings.ContainsRune(reparoImage, ':') {
+				reparoImage = fmt.Sprintf("%s:%s", reparoImage, tikvVersion)
+			}
+		}
+		initContainers = append(initContainers, corev1.Container{
+			Name:            "reparo",
+			Image:           reparoImage,
+			Command:         []string{"/bin/sh", "-c"},
+			Args:            []string{fmt.Sprintf("cp /reparo %s/reparo; echo 'reparo copy finished'", util.ReparoBinPath)},
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			VolumeMounts:    []corev1.VolumeMount{reparoVolumeMount},
+			Resources:       restore.Spec.ResourceRequirements,
+		})
+	}
+
 	podSpec := &corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Labels:      podLabels,
@@ -441,17 +495,7 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 		Spec: corev1.PodSpec{
 			SecurityContext:    restore.Spec.PodSecurityContext,
 			ServiceAccountName: serviceAccount,
-			InitContainers: []corev1.Container{
-				{
-					Name:            "br",
-					Image:           brImage,
-					Command:         []string{"/bin/sh", "-c"},
-					Args:            []string{fmt.Sprintf("cp /br %s/br; echo 'BR copy finished'", util.BRBinPath)},
-					ImagePullPolicy: corev1.PullIfNotPresent,
-					VolumeMounts:    []corev1.VolumeMount{brVolumeMount},
-					Resources:       restore.Spec.ResourceRequirements,
-				},
-			},
+			InitContainers:     initContainers,
 			Containers: []corev1.Container{
 				{
 					Name:            label.RestoreJobLabelVal,
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/testutils"
+	"github.com/pingcap/tidb-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
@@ -232,3 +233,37 @@ func TestBRRestore(t *testing.T) {
 		g.Expect(job.Spec.Template.Spec.Containers[0].Env).NotTo(gomega.ContainElement(env2No))
 	}
 }
+
+func TestBRRestoreWithPitr(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+	deps := helper.Deps
+
+	restore := genValidBRRestores()[0]
+	restore.Spec.Pitr = &v1alpha1.PitrSpec{
+		RestoredTs:   "2021-01-02 15:04:05",
+		LogPrefix:    "binlog",
+		LogToolImage: "pingcap/tidb-binlog:v4.0.9",
+	}
+	helper.createRestore(restore)
+	helper.CreateSecret(restore)
+	helper.CreateTC(restore.Spec.BR.ClusterNamespace, restore.Spec.BR.Cluster)
+
+	m := NewRestoreManager(deps)
+	g.Expect(m.Sync(restore)).Should(BeNil())
+	helper.hasCondition(restore.Namespace, restore.Name, v1alpha1.RestoreScheduled, "")
+	job, err := deps.KubeClientset.BatchV1().Jobs(restore.Namespace).Get(context.TODO(), restore.GetRestoreJobName(), metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+
+	initContainers := job.Spec.Template.Spec.InitContainers
+	g.Expect(initContainers).To(HaveLen(2))
+	g.Expect(initContainers[1].Name).To(Equal("reparo"))
+	g.Expect(initContainers[1].Image).To(Equal("pingcap/tidb-binlog:v4.0.9"))
+	var mountPaths []string
+	for _, vm := range job.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mountPaths = append(mountPaths, vm.MountPath)
+	}
+	g.Expect(mountPaths).To(ContainElement(util.ReparoBinPath))
+	g.Expect(mountPaths).To(ContainElement(util.PitrLogPath))
+}
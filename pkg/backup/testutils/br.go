@@ -51,6 +51,21 @@ func GenValidStorageProviders() []v1alpha1.StorageProvider {
 				SecretName: "gcs",
 			},
 		},
+		{
+			Azblob: &v1alpha1.AzblobStorageProvider{
+				StorageAccount: "azblob",
+				Container:      "azblob",
+				Prefix:         "prefix-",
+			},
+		},
+		{
+			Azblob: &v1alpha1.AzblobStorageProvider{
+				StorageAccount: "azblob",
+				Container:      "azblob",
+				Prefix:         "prefix-",
+				SecretName:     "azblob",
+			},
+		},
 		{
 			Local: &v1alpha1.LocalStorageProvider{
 				Prefix: "prefix-",
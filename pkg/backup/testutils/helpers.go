@@ -71,6 +71,7 @@ func (h *Helper) createSecret(namespace, secretName string) {
 		constants.GcsCredentialsKey: []byte("dummy"),
 		constants.S3AccessKey:       []byte("dummy"),
 		constants.S3SecretKey:       []byte("dummy"),
+		constants.AzblobAccountKey:  []byte("dummy"),
 	}
 	s.Namespace = namespace
 	s.Name = secretName
@@ -87,6 +88,8 @@ func (h *Helper) CreateSecret(obj interface{}) {
 			h.createSecret(obj1.Namespace, obj1.Spec.StorageProvider.S3.SecretName)
 		} else if obj1.Spec.StorageProvider.Gcs != nil && obj1.Spec.StorageProvider.Gcs.SecretName != "" {
 			h.createSecret(obj1.Namespace, obj1.Spec.StorageProvider.Gcs.SecretName)
+		} else if obj1.Spec.StorageProvider.Azblob != nil && obj1.Spec.StorageProvider.Azblob.SecretName != "" {
+			h.createSecret(obj1.Namespace, obj1.Spec.StorageProvider.Azblob.SecretName)
 		}
 	} else if obj2, ok := obj.(*v1alpha1.Restore); ok {
 		h.createSecret(obj2.Namespace, obj2.Spec.To.SecretName)
@@ -94,6 +97,8 @@ func (h *Helper) CreateSecret(obj interface{}) {
 			h.createSecret(obj2.Namespace, obj2.Spec.StorageProvider.S3.SecretName)
 		} else if obj2.Spec.StorageProvider.Gcs != nil && obj2.Spec.StorageProvider.Gcs.SecretName != "" {
 			h.createSecret(obj2.Namespace, obj2.Spec.StorageProvider.Gcs.SecretName)
+		} else if obj2.Spec.StorageProvider.Azblob != nil && obj2.Spec.StorageProvider.Azblob.SecretName != "" {
+			h.createSecret(obj2.Namespace, obj2.Spec.StorageProvider.Azblob.SecretName)
 		}
 	}
 }
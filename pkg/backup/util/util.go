@@ -174,6 +174,58 @@ func generateGcsCertEnvVar(gcs *v1alpha1.GcsStorageProvider) ([]corev1.EnvVar, s
 	return envVars, "", nil
 }
 
+// generateAzblobCertEnvVar generate the env info in order to access azure blob storage,
+// the credentials are taken from the keys present in the secret or, without a secret,
+// from the managed identity of the pod
+func generateAzblobCertEnvVar(azblob *v1alpha1.AzblobStorageProvider, secret *corev1.Secret) ([]corev1.EnvVar, string, error) {
+	envVars := []corev1.EnvVar{
+		{
+			Name:  "AZURE_STORAGE_ENDPOINT",
+			Value: azblob.Endpoint,
+		},
+		{
+			Name:  "AZURE_ACCESS_TIER",
+			Value: azblob.AccessTier,
+		},
+	}
+	if azblob.StorageAccount != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  constants.AzblobAccountName,
+			Value: azblob.StorageAccount,
+		})
+	}
+	if secret == nil {
+		if azblob.StorageAccount == "" {
+			return nil, "StorageAccountIsEmpty", fmt.Errorf("the storage account is not set")
+		}
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "AZURE_USE_MSI",
+			Value: "true",
+		})
+		return envVars, "", nil
+	}
+
+	keys := []string{constants.AzblobAccountKey, constants.AzblobClientID, constants.AzblobClientSecret, constants.AzblobTenantID}
+	if azblob.StorageAccount == "" {
+		keys = append([]string{constants.AzblobAccountName}, keys...)
+	}
+	for _, key := range keys {
+		if _, ok := secret.Data[key]; !ok {
+			continue
+		}
+		envVars = append(envVars, corev1.EnvVar{
+			Name: key,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
+					Key:                  key,
+				},
+			},
+		})
+	}
+	return envVars, "", nil
+}
+
 // GenerateStorageCertEnv generate the env info in order to access backend backup storage
 func GenerateStorageCertEnv(ns string, useKMS bool, provider v1alpha1.StorageProvider, kubeCli kubernetes.Interface) ([]corev1.EnvVar, string, error) {
 	var certEnv []corev1.EnvVar
@@ -220,6 +272,34 @@ func GenerateStorageCertEnv(ns string, useKMS bool, provider v1alpha1.StoragePro
 
 		certEnv, reason, err = generateGcsCertEnvVar(provider.Gcs)
 
+		if err != nil {
+			return certEnv, reason, err
+		}
+	case v1alpha1.BackupStorageTypeAzblob:
+		var secret *corev1.Secret
+		azblobSecretName := provider.Azblob.SecretName
+		if azblobSecretName != "" {
+			secret, err = kubeCli.CoreV1().Secrets(ns).Get(context.TODO(), azblobSecretName, metav1.GetOptions{})
+			if err != nil {
+				err := fmt.Errorf("get azblob secret %s/%s failed, err: %v", ns, azblobSecretName, err)
+				return certEnv, "GetAzblobSecretFailed", err
+			}
+
+			if provider.Azblob.StorageAccount == "" {
+				if keyStr, exist := CheckAllKeysExistInSecret(secret, constants.AzblobAccountName); !exist {
+					err := fmt.Errorf("the azblob secret %s/%s missing some keys %s", ns, azblobSecretName, keyStr)
+					return certEnv, "azblobKeyNotExist", err
+				}
+			}
+			_, hasSharedKey := CheckAllKeysExistInSecret(secret, constants.AzblobAccountKey)
+			keyStr, hasServicePrincipal := CheckAllKeysExistInSecret(secret, constants.AzblobClientID, constants.AzblobClientSecret, constants.AzblobTenantID)
+			if !hasSharedKey && !hasServicePrincipal {
+				err := fmt.Errorf("the azblob secret %s/%s missing some keys %s or %s", ns, azblobSecretName, constants.AzblobAccountKey, keyStr)
+				return certEnv, "azblobKeyNotExist", err
+			}
+		}
+
+		certEnv, reason, err = generateAzblobCertEnvVar(provider.Azblob, secret)
 		if err != nil {
 			return certEnv, reason, err
 		}
@@ -284,6 +364,8 @@ func GetBackupBucketName(backup *v1alpha1.Backup) (string, string, error) {
 		bucketName = backup.Spec.S3.Bucket
 	case v1alpha1.BackupStorageTypeGcs:
 		bucketName = backup.Spec.Gcs.Bucket
+	case v1alpha1.BackupStorageTypeAzblob:
+		bucketName = backup.Spec.Azblob.Container
 	default:
 		return bucketName, "UnsupportedStorageType", fmt.Errorf("backup %s/%s unsupported storage type %s", ns, name, storageType)
 	}
@@ -302,6 +384,8 @@ func GetBackupPrefixName(backup *v1alpha1.Backup) (string, string, error) {
 		prefix = backup.Spec.S3.Prefix
 	case v1alpha1.BackupStorageTypeGcs:
 		prefix = backup.Spec.Gcs.Prefix
+	case v1alpha1.BackupStorageTypeAzblob:
+		prefix = backup.Spec.Azblob.Prefix
 	default:
 		return prefix, "UnsupportedStorageType", fmt.Errorf("backup %s/%s unsupported storage type %s", ns, name, storageType)
 	}
@@ -317,6 +401,9 @@ func GetStorageType(provider v1alpha1.StorageProvider) v1alpha1.BackupStorageTyp
 	if provider.Gcs != nil {
 		return v1alpha1.BackupStorageTypeGcs
 	}
+	if provider.Azblob != nil {
+		return v1alpha1.BackupStorageTypeAzblob
+	}
 	if provider.Local != nil {
 		return v1alpha1.BackupStorageTypeLocal
 	}
@@ -333,6 +420,8 @@ func GetBackupDataPath(provider v1alpha1.StorageProvider) (string, string, error
 		backupPath = provider.S3.Path
 	case v1alpha1.BackupStorageTypeGcs:
 		backupPath = provider.Gcs.Path
+	case v1alpha1.BackupStorageTypeAzblob:
+		backupPath = provider.Azblob.Path
 	default:
 		return backupPath, "UnsupportedStorageType", fmt.Errorf("unsupported storage type %s", storageType)
 	}
@@ -405,6 +494,10 @@ func ValidateBackup(backup *v1alpha1.Backup, tikvImage string) error {
 			if err := validateGcs(ns, name, backup.Spec.Gcs); err != nil {
 				return err
 			}
+		} else if backup.Spec.Azblob != nil {
+			if err := validateAzblob(ns, name, backup.Spec.Azblob); err != nil {
+				return err
+			}
 		} else if backup.Spec.Local != nil {
 			if err := validateLocal(ns, name, backup.Spec.Local); err != nil {
 				return err
@@ -460,6 +553,10 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 			if err := validateGcs(ns, name, restore.Spec.Gcs); err != nil {
 				return err
 			}
+		} else if restore.Spec.Azblob != nil {
+			if err := validateAzblob(ns, name, restore.Spec.Azblob); err != nil {
+				return err
+			}
 		} else if restore.Spec.Local != nil {
 			if err := validateLocal(ns, name, restore.Spec.Local); err != nil {
 				return err
@@ -501,6 +598,23 @@ func validateGcs(ns, name string, gcs *v1alpha1.GcsStorageProvider) error {
 	return nil
 }
 
+func validateAzblob(ns, name string, azblob *v1alpha1.AzblobStorageProvider) error {
+	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
+	if azblob.Container == "" {
+		return fmt.Errorf("container should be %s", configuredForBR)
+	}
+	if azblob.StorageAccount == "" && azblob.SecretName == "" {
+		return fmt.Errorf("storageAccount or secretName should be %s", configuredForBR)
+	}
+	if azblob.Endpoint != "" {
+		u, err := url.Parse(azblob.Endpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid endpoint %s is %s", azblob.Endpoint, configuredForBR)
+		}
+	}
+	return nil
+}
+
 func validateLocal(ns, name string, local *v1alpha1.LocalStorageProvider) error {
 	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
 	if local.VolumeMount.Name != local.Volume.Name {
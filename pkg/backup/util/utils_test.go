@@ -148,6 +148,35 @@ func TestGenerateGcsCertEnvVar(t *testing.T) {
 	g.Expect(len(envs)).ShouldNot(Equal(0))
 }
 
+func TestGenerateAzblobCertEnvVar(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// test managed identity without the storage account
+	_, _, err := generateAzblobCertEnvVar(&v1alpha1.AzblobStorageProvider{}, nil)
+	g.Expect(err).ShouldNot(BeNil())
+
+	// test managed identity
+	envs, _, err := generateAzblobCertEnvVar(&v1alpha1.AzblobStorageProvider{StorageAccount: "account"}, nil)
+	g.Expect(err).Should(BeNil())
+	g.Expect(envs).Should(ContainElement(corev1.EnvVar{Name: "AZURE_USE_MSI", Value: "true"}))
+
+	// test service principal, only the keys present in the secret are referenced
+	secret := &corev1.Secret{Data: map[string][]byte{
+		constants.AzblobAccountName:  []byte("account"),
+		constants.AzblobClientID:     []byte("id"),
+		constants.AzblobClientSecret: []byte("secret"),
+		constants.AzblobTenantID:     []byte("tenant"),
+	}}
+	envs, _, err = generateAzblobCertEnvVar(&v1alpha1.AzblobStorageProvider{SecretName: "azblob"}, secret)
+	g.Expect(err).Should(BeNil())
+	var names []string
+	for _, env := range envs {
+		names = append(names, env.Name)
+	}
+	g.Expect(names).Should(Equal([]string{"AZURE_STORAGE_ENDPOINT", "AZURE_ACCESS_TIER", constants.AzblobAccountName,
+		constants.AzblobClientID, constants.AzblobClientSecret, constants.AzblobTenantID}))
+}
+
 func TestGenerateStorageCertEnv(t *testing.T) {
 	g := NewGomegaWithT(t)
 	ns := "ns"
@@ -179,6 +208,20 @@ func TestGenerateStorageCertEnv(t *testing.T) {
 				},
 			},
 		},
+		{
+			provider: v1alpha1.StorageProvider{
+				Azblob: &v1alpha1.AzblobStorageProvider{
+					SecretName: secretName,
+				},
+			},
+		},
+		{
+			provider: v1alpha1.StorageProvider{
+				Azblob: &v1alpha1.AzblobStorageProvider{
+					StorageAccount: "account",
+				},
+			},
+		},
 		{
 			provider: v1alpha1.StorageProvider{},
 		},
@@ -197,7 +240,7 @@ func TestGenerateStorageCertEnv(t *testing.T) {
 
 		// start normal storage type
 		_, _, err := GenerateStorageCertEnv(ns, false, test.provider, client)
-		if test.provider.Gcs != nil && test.provider.Gcs.SecretName == "" {
+		if noSecret(test.provider) {
 			g.Expect(err).Should(BeNil())
 		} else {
 			g.Expect(err.Error()).Should(MatchRegexp(".*get.*secret.*"))
@@ -209,7 +252,7 @@ func TestGenerateStorageCertEnv(t *testing.T) {
 		_, err = client.CoreV1().Secrets(ns).Create(context.TODO(), s, metav1.CreateOptions{})
 		g.Expect(err).Should(BeNil())
 		_, _, err = GenerateStorageCertEnv(ns, false, test.provider, client)
-		if test.provider.Gcs != nil && test.provider.Gcs.SecretName == "" {
+		if noSecret(test.provider) {
 			g.Expect(err).Should(BeNil())
 		} else {
 			g.Expect(err.Error()).Should(MatchRegexp(".*missing some keys.*"))
@@ -220,6 +263,8 @@ func TestGenerateStorageCertEnv(t *testing.T) {
 			constants.GcsCredentialsKey: []byte("dummy"),
 			constants.S3AccessKey:       []byte("dummy"),
 			constants.S3SecretKey:       []byte("dummy"),
+			constants.AzblobAccountName: []byte("dummy"),
+			constants.AzblobAccountKey:  []byte("dummy"),
 		}
 		_, err = client.CoreV1().Secrets(ns).Update(context.TODO(), s, metav1.UpdateOptions{})
 		g.Expect(err).Should(BeNil())
@@ -228,6 +273,11 @@ func TestGenerateStorageCertEnv(t *testing.T) {
 	}
 }
 
+func noSecret(provider v1alpha1.StorageProvider) bool {
+	return (provider.Gcs != nil && provider.Gcs.SecretName == "") ||
+		(provider.Azblob != nil && provider.Azblob.SecretName == "")
+}
+
 func TestGenerateTidbPasswordEnv(t *testing.T) {
 	g := NewGomegaWithT(t)
 	ns := "ns"
@@ -45,6 +45,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 	}
 	tidbAutoScalerInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterAutoScalers()
 	controller.WatchForObject(tidbAutoScalerInformer.Informer(), t.queue)
+	controller.RequeueOnRebalance(deps.Sharder, tidbAutoScalerInformer.Informer(), t.queue)
 	return t
 }
 
@@ -72,7 +73,14 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	if !c.deps.Sharder.Owns(key.(string)) {
+		c.queue.Forget(key)
+		return true
+	}
+	startTime := time.Now()
+	err := c.sync(key.(string))
+	controller.ObserveReconcile("tidbclusterautoscaler", key.(string), startTime, err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("TidbClusterAutoScaler: %v, still need sync: %v, requeuing", key.(string), err)
 		} else {
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/backup"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -59,6 +60,11 @@ func NewController(deps *controller.Dependencies) *Controller {
 	backupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: c.updateBackup,
 		UpdateFunc: func(old, cur interface{}) {
+			newBackup := cur.(*v1alpha1.Backup)
+			// all replicas watch the backup, only the one owning it records the metrics
+			if c.deps.Sharder.Owns(newBackup.Namespace + "/" + newBackup.Name) {
+				observeBackupFinished(old.(*v1alpha1.Backup), newBackup)
+			}
 			c.updateBackup(cur)
 		},
 		DeleteFunc: c.updateBackup,
@@ -67,6 +73,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 		DeleteFunc: c.deleteJob,
 	})
 
+	controller.RequeueOnRebalance(deps.Sharder, backupInformer.Informer(), c.queue)
 	return c
 }
 
@@ -99,7 +106,14 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	if !c.deps.Sharder.Owns(key.(string)) {
+		c.queue.Forget(key)
+		return true
+	}
+	startTime := time.Now()
+	err := c.sync(key.(string))
+	controller.ObserveReconcile("backup", key.(string), startTime, err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("Backup: %v, still need sync: %v, requeuing", key.(string), err)
 			c.queue.AddRateLimited(key)
@@ -160,6 +174,11 @@ func (c *Controller) updateBackup(cur interface{}) {
 	}
 
 	if v1alpha1.IsBackupComplete(newBackup) {
+		if v1alpha1.IsBackupVerifyRequested(newBackup) || v1alpha1.IsBackupVerifying(newBackup) {
+			klog.V(4).Infof("backup %s/%s is Complete and being verified, enqueue.", ns, name)
+			c.enqueueBackup(newBackup)
+			return
+		}
 		klog.V(4).Infof("backup %s/%s is Complete, skipping.", ns, name)
 		return
 	}
@@ -248,3 +267,34 @@ func (c *Controller) enqueueBackup(obj interface{}) {
 	}
 	c.queue.Add(key)
 }
+
+// observeBackupFinished records the duration and the result of the backup when it turns complete or failed
+func observeBackupFinished(old, cur *v1alpha1.Backup) {
+	var result string
+	switch {
+	case v1alpha1.IsBackupComplete(cur) && !v1alpha1.IsBackupComplete(old):
+		result = metrics.BackupComplete
+	case v1alpha1.IsBackupFailed(cur) && !v1alpha1.IsBackupFailed(old):
+		result = metrics.BackupFailed
+	default:
+		return
+	}
+
+	var cluster string
+	if cur.Spec.BR != nil {
+		cluster = cur.Spec.BR.Cluster
+	}
+	backupType := cur.Spec.Type
+	if backupType == "" {
+		backupType = v1alpha1.BackupTypeFull
+	}
+	startTime := cur.Status.TimeStarted.Time
+	if startTime.IsZero() {
+		startTime = cur.CreationTimestamp.Time
+	}
+	endTime := cur.Status.TimeCompleted.Time
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+	metrics.BackupDuration.WithLabelValues(cur.Namespace, cluster, string(backupType), result).Observe(endTime.Sub(startTime).Seconds())
+}
@@ -141,6 +141,18 @@ func TestBackupControllerUpdateBackup(t *testing.T) {
 				g.Expect(bkc.queue.Len()).To(Equal(0))
 			},
 		},
+		{
+			name:                 "backup has been completed with verification requested",
+			backupHasBeenDeleted: false,
+			conditionType:        v1alpha1.BackupComplete,
+			beforeUpdateFn: func(g *GomegaWithT, bkc *Controller, backup *v1alpha1.Backup) {
+				backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "demo1"}
+				backup.Spec.Verify = true
+			},
+			expectFn: func(g *GomegaWithT, bkc *Controller) {
+				g.Expect(bkc.queue.Len()).To(Equal(1))
+			},
+		},
 		{
 			name:                 "backup has been scheduled",
 			backupHasBeenDeleted: false,
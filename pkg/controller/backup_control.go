@@ -30,6 +30,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -47,6 +48,8 @@ import (
 type BackupControlInterface interface {
 	CreateBackup(backup *v1alpha1.Backup) (*v1alpha1.Backup, error)
 	DeleteBackup(backup *v1alpha1.Backup) error
+	// RequestVerifyBackup requests the verification of a completed Backup at the given time
+	RequestVerifyBackup(backup *v1alpha1.Backup, requestTime time.Time) error
 }
 
 type realBackupControl struct {
@@ -95,6 +98,27 @@ func (c *realBackupControl) DeleteBackup(backup *v1alpha1.Backup) error {
 	return err
 }
 
+func (c *realBackupControl) RequestVerifyBackup(backup *v1alpha1.Backup, requestTime time.Time) error {
+	ns := backup.GetNamespace()
+	backupName := backup.GetName()
+
+	bsName := backup.GetLabels()[label.BackupScheduleLabelKey]
+	// make a copy so we don't mutate the shared cache
+	backup = backup.DeepCopy()
+	if backup.Annotations == nil {
+		backup.Annotations = map[string]string{}
+	}
+	backup.Annotations[label.AnnBackupVerifyRequestTime] = requestTime.UTC().Format(time.RFC3339)
+	_, err := c.cli.PingcapV1alpha1().Backups(ns).Update(context.TODO(), backup, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Errorf("failed to request verify of Backup: [%s/%s] for backupSchedule/%s, err: %v", ns, backupName, bsName, err)
+	} else {
+		klog.V(4).Infof("request verify of Backup: [%s/%s] successfully, backupSchedule/%s", ns, backupName, bsName)
+	}
+	c.recordBackupEvent("verify", backup, err)
+	return err
+}
+
 func (c *realBackupControl) recordBackupEvent(verb string, backup *v1alpha1.Backup, err error) {
 	backupName := backup.GetName()
 	ns := backup.GetNamespace()
@@ -121,6 +145,7 @@ type FakeBackupControl struct {
 	backupIndexer       cache.Indexer
 	createBackupTracker RequestTracker
 	deleteBackupTracker RequestTracker
+	verifyBackupTracker RequestTracker
 }
 
 // NewFakeBackupControl returns a FakeBackupControl
@@ -130,6 +155,7 @@ func NewFakeBackupControl(backupInformer informers.BackupInformer) *FakeBackupCo
 		backupInformer.Informer().GetIndexer(),
 		RequestTracker{},
 		RequestTracker{},
+		RequestTracker{},
 	}
 }
 
@@ -165,4 +191,25 @@ func (fbc *FakeBackupControl) DeleteBackup(backup *v1alpha1.Backup) error {
 	return fbc.backupIndexer.Delete(backup)
 }
 
+// SetRequestVerifyBackupError sets the error attributes of verifyBackupTracker
+func (fbc *FakeBackupControl) SetRequestVerifyBackupError(err error, after int) {
+	fbc.verifyBackupTracker.SetError(err).SetAfter(after)
+}
+
+// RequestVerifyBackup sets the verify request annotation of the backup in BackupIndexer
+func (fbc *FakeBackupControl) RequestVerifyBackup(backup *v1alpha1.Backup, requestTime time.Time) error {
+	defer fbc.verifyBackupTracker.Inc()
+	if fbc.verifyBackupTracker.ErrorReady() {
+		defer fbc.verifyBackupTracker.Reset()
+		return fbc.verifyBackupTracker.GetError()
+	}
+
+	backup = backup.DeepCopy()
+	if backup.Annotations == nil {
+		backup.Annotations = map[string]string{}
+	}
+	backup.Annotations[label.AnnBackupVerifyRequestTime] = requestTime.UTC().Format(time.RFC3339)
+	return fbc.backupIndexer.Update(backup)
+}
+
 var _ BackupControlInterface = &FakeBackupControl{}
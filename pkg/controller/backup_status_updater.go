@@ -47,9 +47,18 @@ type BackupUpdateStatus struct {
 	BackupSize *int64
 	// CommitTs is the snapshot time point of tidb cluster.
 	CommitTs *string
+	// ParentBackup is the name of the backup which an incremental backup is chained from.
+	ParentBackup *string
+	// Progress is the latest progress reported by BR.
+	Progress *v1alpha1.BRProgress
+	// LastError is the last error line printed by BR.
+	LastError *string
+	// Verify is the result of the latest verification of the backup.
+	Verify *v1alpha1.BackupVerifyStatus
 }
 
-// BackupConditionUpdaterInterface enables updating Backup conditions.
+// BackupConditionUpdaterInterface enables updating Backup conditions,
+// a nil condition updates only the status fields in BackupUpdateStatus.
 type BackupConditionUpdaterInterface interface {
 	Update(backup *v1alpha1.Backup, condition *v1alpha1.BackupCondition, newStatus *BackupUpdateStatus) error
 }
@@ -78,7 +87,8 @@ func (u *realBackupConditionUpdater) Update(backup *v1alpha1.Backup, condition *
 	var isUpdate bool
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		updateBackupStatus(&backup.Status, newStatus)
-		isUpdate = v1alpha1.UpdateBackupCondition(&backup.Status, condition)
+		// a nil condition only updates the status fields, e.g. the progress
+		isUpdate = condition == nil || v1alpha1.UpdateBackupCondition(&backup.Status, condition)
 		if isUpdate {
 			_, updateErr := u.cli.PingcapV1alpha1().Backups(ns).Update(context.TODO(), backup, metav1.UpdateOptions{})
 			if updateErr == nil {
@@ -123,6 +133,18 @@ func updateBackupStatus(status *v1alpha1.BackupStatus, newStatus *BackupUpdateSt
 	if newStatus.CommitTs != nil {
 		status.CommitTs = *newStatus.CommitTs
 	}
+	if newStatus.ParentBackup != nil {
+		status.ParentBackup = *newStatus.ParentBackup
+	}
+	if newStatus.Progress != nil {
+		status.Progress = newStatus.Progress
+	}
+	if newStatus.LastError != nil {
+		status.LastError = *newStatus.LastError
+	}
+	if newStatus.Verify != nil {
+		status.Verify = newStatus.Verify
+	}
 }
 
 var _ BackupConditionUpdaterInterface = &realBackupConditionUpdater{}
@@ -66,11 +66,15 @@ func newUpdateBackupStatus() *BackupUpdateStatus {
 	size := int64(5024)
 	return &BackupUpdateStatus{
 		CommitTs:           &ts,
+		ParentBackup:       &path,
 		TimeCompleted:      &metav1.Time{Time: end},
 		TimeStarted:        &metav1.Time{Time: start},
 		BackupPath:         &path,
 		BackupSizeReadable: &sizeReadable,
 		BackupSize:         &size,
+		Progress:           &v1alpha1.BRProgress{Step: "Full backup", Percentage: "50.00%"},
+		LastError:          &path,
+		Verify:             &v1alpha1.BackupVerifyStatus{Phase: v1alpha1.BackupVerifyPassed, RequestTime: metav1.Time{Time: end}},
 	}
 }
 
@@ -114,10 +118,14 @@ func newExpectBackupStatus() *v1alpha1.BackupStatus {
 	size := int64(5024)
 	s := newBackupStatus()
 	s.CommitTs = ts
+	s.ParentBackup = path
 	s.TimeStarted = metav1.Time{Time: start}
 	s.TimeCompleted = metav1.Time{Time: end}
 	s.BackupPath = path
 	s.BackupSizeReadable = sizeReadable
 	s.BackupSize = size
+	s.Progress = &v1alpha1.BRProgress{Step: "Full backup", Percentage: "50.00%"}
+	s.LastError = path
+	s.Verify = &v1alpha1.BackupVerifyStatus{Phase: v1alpha1.BackupVerifyPassed, RequestTime: metav1.Time{Time: end}}
 	return s
 }
@@ -59,6 +59,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 		DeleteFunc: c.enqueueBackupSchedule,
 	})
 
+	controller.RequeueOnRebalance(deps.Sharder, backupScheduleInformer.Informer(), c.queue)
 	return c
 }
 
@@ -91,7 +92,14 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	if !c.deps.Sharder.Owns(key.(string)) {
+		c.queue.Forget(key)
+		return true
+	}
+	startTime := time.Now()
+	err := c.sync(key.(string))
+	controller.ObserveReconcile("backupschedule", key.(string), startTime, err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("BackupSchedule: %v, still need sync: %v, requeuing", key.(string), err)
 			c.queue.AddRateLimited(key)
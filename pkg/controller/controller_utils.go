@@ -17,9 +17,12 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/dustin/go-humanize"
+	perrors "github.com/pingcap/errors"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/scheme"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
@@ -99,6 +102,24 @@ func IsIgnoreError(err error) bool {
 	return ok
 }
 
+// ObserveReconcile records the duration and the result of a reconciliation of the object of the key
+func ObserveReconcile(controllerName, key string, startTime time.Time, err error) {
+	result := metrics.ReconcileSuccess
+	if err != nil {
+		switch {
+		case perrors.Find(err, IsRequeueError) != nil:
+			result = metrics.ReconcileRequeue
+		case perrors.Find(err, IsIgnoreError) != nil:
+			result = metrics.ReconcileIgnore
+		default:
+			result = metrics.ReconcileError
+			ns, name, _ := cache.SplitMetaNamespaceKey(key)
+			metrics.ReconcileErrors.WithLabelValues(controllerName, ns, name).Inc()
+		}
+	}
+	metrics.ReconcileDuration.WithLabelValues(controllerName, result).Observe(time.Since(startTime).Seconds())
+}
+
 // GetOwnerRef returns TidbCluster's OwnerReference
 func GetOwnerRef(tc *v1alpha1.TidbCluster) metav1.OwnerReference {
 	controller := true
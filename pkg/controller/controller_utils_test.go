@@ -16,8 +16,11 @@ package controller
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -48,6 +51,21 @@ func TestIgnoreError(t *testing.T) {
 	g.Expect(IsIgnoreError(fmt.Errorf("i am not an ignore error"))).To(BeFalse())
 }
 
+func TestObserveReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	startTime := time.Now()
+	ObserveReconcile("test", "ns/ok", startTime, nil)
+	ObserveReconcile("test", "ns/requeue", startTime, RequeueErrorf("requeue"))
+	ObserveReconcile("test", "ns/ignore", startTime, IgnoreErrorf("ignore"))
+	ObserveReconcile("test", "ns/failed", startTime, fmt.Errorf("failed"))
+	ObserveReconcile("test", "ns/failed", startTime, fmt.Errorf("failed"))
+
+	g.Expect(testutil.CollectAndCount(metrics.ReconcileDuration)).To(Equal(4))
+	g.Expect(testutil.ToFloat64(metrics.ReconcileErrors.WithLabelValues("test", "ns", "failed"))).To(Equal(float64(2)))
+	g.Expect(testutil.ToFloat64(metrics.ReconcileErrors.WithLabelValues("test", "ns", "requeue"))).To(Equal(float64(0)))
+}
+
 func TestGetOwnerRef(t *testing.T) {
 	g := NewGomegaWithT(t)
 
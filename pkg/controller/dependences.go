@@ -14,7 +14,10 @@
 package controller
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -29,6 +32,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/tikvapi"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	kubefake "k8s.io/client-go/kubernetes/fake"
@@ -55,6 +59,13 @@ type CLIConfig struct {
 	// wide TiDB clusters
 	ClusterScoped bool
 
+	// Namespaces is a comma separated list of the namespaces to watch, and
+	// NamespaceSelector selects the namespaces to watch by labels. They are
+	// only used if ClusterScoped is false, and the namespace of the operator
+	// is watched if neither of them is set
+	Namespaces        string
+	NamespaceSelector string
+
 	ClusterPermissionNode bool
 	ClusterPermissionPV   bool
 	ClusterPermissionSC   bool
@@ -83,6 +94,17 @@ type CLIConfig struct {
 	// Selector is used to filter CR labels to decide
 	// what resources should be watched and synced by controller
 	Selector string
+	// NodeDrainTaintKeys is a comma separated list of taint keys, nodes with
+	// these taints are considered draining besides the cordoned nodes
+	NodeDrainTaintKeys string
+	// Sharding enables several active controller-manager replicas, each of
+	// them syncs the objects assigned to it by consistent hashing instead
+	// of electing a single leader
+	Sharding bool
+	// EventQPS and EventBurst limit the events recorded on the same object,
+	// e.g. a TidbCluster, see pkg/manager/member/events.go for the reasons
+	EventQPS   float64
+	EventBurst int
 }
 
 // DefaultCLIConfig returns the default command line configuration
@@ -105,6 +127,8 @@ func DefaultCLIConfig() *CLIConfig {
 		TiDBBackupManagerImage: "pingcap/tidb-backup-manager:latest",
 		TiDBDiscoveryImage:     "pingcap/tidb-operator:latest",
 		Selector:               "",
+		EventQPS:               1,
+		EventBurst:             25,
 	}
 }
 
@@ -114,6 +138,8 @@ func (c *CLIConfig) AddFlag(_ *flag.FlagSet) {
 	flag.BoolVar(&c.PrintVersion, "version", false, "Show version and quit")
 	flag.IntVar(&c.Workers, "workers", c.Workers, "The number of workers that are allowed to sync concurrently. Larger number = more responsive management, but more CPU (and network) load")
 	flag.BoolVar(&c.ClusterScoped, "cluster-scoped", c.ClusterScoped, "Whether tidb-operator should manage kubernetes cluster wide TiDB Clusters")
+	flag.StringVar(&c.Namespaces, "namespaces", c.Namespaces, "Comma separated namespaces to watch if cluster-scoped is false, default to the namespace of tidb-operator")
+	flag.StringVar(&c.NamespaceSelector, "namespace-selector", c.NamespaceSelector, "Label selector of the namespaces to watch if cluster-scoped is false, the namespaces selected or unselected at runtime are watched or unwatched accordingly and it requires the permission to list and watch namespaces")
 	flag.BoolVar(&c.ClusterPermissionNode, "cluster-permission-node", c.ClusterPermissionNode, "Whether tidb-operator should have node permissions even if cluster-scoped is false")
 	flag.BoolVar(&c.ClusterPermissionPV, "cluster-permission-pv", c.ClusterPermissionPV, "Whether tidb-operator should have persistent volume permissions even if cluster-scoped is false")
 	flag.BoolVar(&c.ClusterPermissionSC, "cluster-permission-sc", c.ClusterPermissionSC, "Whether tidb-operator should have storage class permissions even if cluster-scoped is false")
@@ -131,6 +157,10 @@ func (c *CLIConfig) AddFlag(_ *flag.FlagSet) {
 	flag.StringVar(&c.TiDBDiscoveryImage, "tidb-discovery-image", c.TiDBDiscoveryImage, "The image of the tidb discovery service")
 	flag.BoolVar(&c.PodWebhookEnabled, "pod-webhook-enabled", false, "Whether Pod admission webhook is enabled")
 	flag.StringVar(&c.Selector, "selector", c.Selector, "Selector (label query) to filter on, supports '=', '==', and '!='")
+	flag.StringVar(&c.NodeDrainTaintKeys, "node-drain-taint-keys", c.NodeDrainTaintKeys, "Comma separated taint keys which mark a node as draining besides cordon, only used when NodeDrainAwareness feature is enabled")
+	flag.Float64Var(&c.EventQPS, "event-qps", c.EventQPS, "The average number of events per second recorded on a TidbCluster or DMCluster after the burst is exhausted")
+	flag.IntVar(&c.EventBurst, "event-burst", c.EventBurst, "The maximum burst of events recorded on a TidbCluster or DMCluster")
+	flag.BoolVar(&c.Sharding, "sharding", c.Sharding, "Whether all the replicas of tidb-controller-manager are active and sync the objects of their own shards, the shards are rebalanced through leases instead of electing a leader")
 
 	// see https://pkg.go.dev/k8s.io/client-go/tools/leaderelection#LeaderElectionConfig for the config
 	flag.DurationVar(&c.LeaseDuration, "leader-lease-duration", c.LeaseDuration, "leader-lease-duration is the duration that non-leader candidates will wait to force acquire leadership")
@@ -153,6 +183,17 @@ func (c *CLIConfig) HasSCPermission() bool {
 	return c.ClusterScoped || c.ClusterPermissionSC
 }
 
+// GetNodeDrainTaintKeys returns the taint keys which mark a node as draining.
+func (c *CLIConfig) GetNodeDrainTaintKeys() []string {
+	var keys []string
+	for _, key := range strings.Split(c.NodeDrainTaintKeys, ",") {
+		if key = strings.TrimSpace(key); len(key) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 type Controls struct {
 	JobControl         JobControlInterface
 	ConfigMapControl   ConfigMapControlInterface
@@ -189,6 +230,9 @@ type Dependencies struct {
 	KubeInformerFactory            kubeinformers.SharedInformerFactory
 	LabelFilterKubeInformerFactory kubeinformers.SharedInformerFactory
 	Recorder                       record.EventRecorder
+	// Sharder decides which objects are synced by this replica, all
+	// controllers must skip the objects not owned by it
+	Sharder Sharder
 
 	// Listers
 	ServiceLister               corelisterv1.ServiceLister
@@ -307,6 +351,7 @@ func newDependencies(
 		KubeInformerFactory:            kubeInformerFactory,
 		LabelFilterKubeInformerFactory: labelFilterKubeInformerFactory,
 		Recorder:                       recorder,
+		Sharder:                        NewUnsharded(),
 
 		// Listers
 		ServiceLister:               kubeInformerFactory.Core().V1().Services().Lister(),
@@ -333,15 +378,53 @@ func newDependencies(
 	}
 }
 
-// NewDependencies is used to construct the dependencies
-func NewDependencies(ns string, cliCfg *CLIConfig, clientset versioned.Interface, kubeClientset kubernetes.Interface, genericCli client.Client) *Dependencies {
+// WatchNamespaces resolves the namespaces watched by the controllers at startup, nil means all
+// namespaces. The namespaces given by --namespaces and selected by --namespace-selector are merged,
+// and the namespace of the operator is used if none of the flags is given. The namespaces selected
+// later are watched by the Dependencies at runtime.
+func (c *CLIConfig) WatchNamespaces(kubeCli kubernetes.Interface, ns string) ([]string, error) {
+	if c.ClusterScoped {
+		return nil, nil
+	}
+	set := c.givenNamespaces()
+	if len(c.NamespaceSelector) > 0 {
+		list, err := kubeCli.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: c.NamespaceSelector})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespaces by selector %q: %v", c.NamespaceSelector, err)
+		}
+		for _, item := range list.Items {
+			set.Insert(item.Name)
+		}
+	}
+	if set.Len() == 0 && len(c.NamespaceSelector) == 0 {
+		set.Insert(ns)
+	}
+	return set.List(), nil
+}
+
+// givenNamespaces returns the namespaces given by --namespaces
+func (c *CLIConfig) givenNamespaces() sets.String {
+	set := sets.NewString()
+	for _, name := range strings.Split(c.Namespaces, ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			set.Insert(name)
+		}
+	}
+	return set
+}
+
+// NewDependencies is used to construct the dependencies, the namespaces are the ones returned by
+// CLIConfig.WatchNamespaces
+func NewDependencies(namespaces []string, cliCfg *CLIConfig, clientset versioned.Interface, kubeClientset kubernetes.Interface, genericCli client.Client) *Dependencies {
 	var (
 		options     []informers.SharedInformerOption
 		kubeoptions []kubeinformers.SharedInformerOption
 	)
-	if !cliCfg.ClusterScoped {
-		options = append(options, informers.WithNamespace(ns))
-		kubeoptions = append(kubeoptions, kubeinformers.WithNamespace(ns))
+	// the namespaces selected by labels change at runtime, so the informers of them are always merged
+	watchSelected := namespaces != nil && len(cliCfg.NamespaceSelector) > 0
+	if len(namespaces) == 1 && !watchSelected {
+		options = append(options, informers.WithNamespace(namespaces[0]))
+		kubeoptions = append(kubeoptions, kubeinformers.WithNamespace(namespaces[0]))
 	}
 	tweakListOptionsFunc := func(options *metav1.ListOptions) {
 		if len(options.LabelSelector) > 0 {
@@ -362,9 +445,27 @@ func NewDependencies(ns string, cliCfg *CLIConfig, clientset versioned.Interface
 	informerFactory := informers.NewSharedInformerFactoryWithOptions(clientset, cliCfg.ResyncDuration, options...)
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, kubeoptions...)
 	labelFilterKubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, labelKubeOptions...)
+	if len(namespaces) > 1 || watchSelected {
+		// the shared factories are created without namespace, and the informers of the namespaced
+		// resources are replaced by the merged ones of the factories of the namespaces
+		multiNamespaceInformers := registerMultiNamespaceInformers(informerFactory, kubeInformerFactory, labelFilterKubeInformerFactory,
+			namespaces, func(ns string) *namespaceInformerFactories {
+				return &namespaceInformerFactories{
+					informerFactory:                informers.NewSharedInformerFactoryWithOptions(clientset, cliCfg.ResyncDuration, append(options, informers.WithNamespace(ns))...),
+					kubeInformerFactory:            kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, append(kubeoptions, kubeinformers.WithNamespace(ns))...),
+					labelFilterKubeInformerFactory: kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, append(labelKubeOptions, kubeinformers.WithNamespace(ns))...),
+				}
+			})
+		if watchSelected {
+			watchSelectedNamespaces(kubeInformerFactory, cliCfg, multiNamespaceInformers)
+		}
+	}
 
 	// Initialize the event recorder
-	eventBroadcaster := record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{QPS: 1})
+	eventBroadcaster := record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{
+		QPS:       float32(cliCfg.EventQPS),
+		BurstSize: cliCfg.EventBurst,
+	})
 	eventBroadcaster.StartLogging(klog.V(2).Infof)
 	eventBroadcaster.StartRecordingToSink(&eventv1.EventSinkImpl{
 		Interface: eventv1.New(kubeClientset.CoreV1().RESTClient()).Events("")})
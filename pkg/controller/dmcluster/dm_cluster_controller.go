@@ -80,6 +80,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 		},
 		DeleteFunc: c.deleteStatefulSet,
 	})
+	controller.RequeueOnRebalance(deps.Sharder, dmClusterInformer.Informer(), c.queue)
 	return c
 }
 
@@ -112,7 +113,14 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	if !c.deps.Sharder.Owns(key.(string)) {
+		c.queue.Forget(key)
+		return true
+	}
+	startTime := time.Now()
+	err := c.sync(key.(string))
+	controller.ObserveReconcile("dmcluster", key.(string), startTime, err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("DMCluster: %v, still need sync: %v, requeuing", key.(string), err)
 		} else {
@@ -0,0 +1,579 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions"
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+)
+
+// multiNamespaceInformer runs an informer in each of the watched namespaces and merges them, so that
+// an operator serving a set of namespaces doesn't need the permission to list and watch all namespaces.
+// It's registered in the shared informer factories, so the informers and listers got from the
+// factories by the controllers are the merged ones. The namespaces can be added and removed at any
+// time, the informer of an added namespace gets the event handlers and indexers added before.
+type multiNamespaceInformer struct {
+	// newInformer returns the informer of the namespace
+	newInformer func(ns string) cache.SharedIndexInformer
+
+	lock sync.Mutex
+	// informers of the namespaces
+	informers         map[string]cache.SharedIndexInformer
+	nsStopChs         map[string]chan struct{}
+	handlers          []resyncHandler
+	addedIndexers     cache.Indexers
+	watchErrorHandler cache.WatchErrorHandler
+	// stopCh is set when the informer is run
+	stopCh  <-chan struct{}
+	indexer *multiNamespaceIndexer
+}
+
+type resyncHandler struct {
+	handler      cache.ResourceEventHandler
+	resyncPeriod time.Duration
+}
+
+var _ cache.SharedIndexInformer = &multiNamespaceInformer{}
+
+func newMultiNamespaceInformer(newInformer func(ns string) cache.SharedIndexInformer) *multiNamespaceInformer {
+	return &multiNamespaceInformer{
+		newInformer:   newInformer,
+		informers:     map[string]cache.SharedIndexInformer{},
+		nsStopChs:     map[string]chan struct{}{},
+		addedIndexers: cache.Indexers{},
+		indexer:       &multiNamespaceIndexer{indexers: map[string]cache.Indexer{}},
+	}
+}
+
+// addNamespace adds the informer of the namespace, it's run at once if the merged informer is running
+func (i *multiNamespaceInformer) addNamespace(ns string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if _, ok := i.informers[ns]; ok {
+		return
+	}
+	informer := i.newInformer(ns)
+	for _, h := range i.handlers {
+		informer.AddEventHandlerWithResyncPeriod(h.handler, h.resyncPeriod)
+	}
+	if len(i.addedIndexers) > 0 {
+		if err := informer.AddIndexers(i.addedIndexers); err != nil {
+			klog.Errorf("failed to add indexers to the informer of namespace %s: %v", ns, err)
+		}
+	}
+	if i.watchErrorHandler != nil {
+		if err := informer.SetWatchErrorHandler(i.watchErrorHandler); err != nil {
+			klog.Errorf("failed to set the watch error handler of the informer of namespace %s: %v", ns, err)
+		}
+	}
+	i.informers[ns] = informer
+	i.indexer.add(ns, informer.GetIndexer())
+	if i.stopCh != nil {
+		i.runNamespace(ns, informer)
+	}
+}
+
+// removeNamespace stops the informer of the namespace, the objects of the namespace are dropped
+// without delete events
+func (i *multiNamespaceInformer) removeNamespace(ns string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if _, ok := i.informers[ns]; !ok {
+		return
+	}
+	if stopCh, ok := i.nsStopChs[ns]; ok {
+		close(stopCh)
+		delete(i.nsStopChs, ns)
+	}
+	delete(i.informers, ns)
+	i.indexer.remove(ns)
+}
+
+// runNamespace runs the informer of the namespace until it's removed or the merged informer is stopped,
+// it must be called with the lock held
+func (i *multiNamespaceInformer) runNamespace(ns string, informer cache.SharedIndexInformer) {
+	nsStopCh := make(chan struct{})
+	i.nsStopChs[ns] = nsStopCh
+	stopCh := i.stopCh
+	informerStopCh := make(chan struct{})
+	go func() {
+		select {
+		case <-nsStopCh:
+		case <-stopCh:
+		}
+		close(informerStopCh)
+	}()
+	go informer.Run(informerStopCh)
+}
+
+func (i *multiNamespaceInformer) AddEventHandler(handler cache.ResourceEventHandler) {
+	i.AddEventHandlerWithResyncPeriod(handler, 0)
+}
+
+func (i *multiNamespaceInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.handlers = append(i.handlers, resyncHandler{handler: handler, resyncPeriod: resyncPeriod})
+	for _, informer := range i.informers {
+		informer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	}
+}
+
+func (i *multiNamespaceInformer) GetStore() cache.Store {
+	return i.indexer
+}
+
+// GetController returns the merged informer itself, which runs the informers of the namespaces
+func (i *multiNamespaceInformer) GetController() cache.Controller {
+	return i
+}
+
+func (i *multiNamespaceInformer) Run(stopCh <-chan struct{}) {
+	i.lock.Lock()
+	i.stopCh = stopCh
+	for ns, informer := range i.informers {
+		i.runNamespace(ns, informer)
+	}
+	i.lock.Unlock()
+	<-stopCh
+}
+
+func (i *multiNamespaceInformer) HasSynced() bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for _, informer := range i.informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// LastSyncResourceVersion returns an empty string as the informers are synced separately
+func (i *multiNamespaceInformer) LastSyncResourceVersion() string {
+	return ""
+}
+
+func (i *multiNamespaceInformer) SetWatchErrorHandler(handler cache.WatchErrorHandler) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for _, informer := range i.informers {
+		if err := informer.SetWatchErrorHandler(handler); err != nil {
+			return err
+		}
+	}
+	i.watchErrorHandler = handler
+	return nil
+}
+
+func (i *multiNamespaceInformer) AddIndexers(indexers cache.Indexers) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if err := i.indexer.AddIndexers(indexers); err != nil {
+		return err
+	}
+	for name, indexFunc := range indexers {
+		i.addedIndexers[name] = indexFunc
+	}
+	return nil
+}
+
+func (i *multiNamespaceInformer) GetIndexer() cache.Indexer {
+	return i.indexer
+}
+
+// multiNamespaceIndexer is a read-only indexer merging the indexers of the namespaces, the lookups by
+// key or by namespace index only read the indexer of the namespace.
+type multiNamespaceIndexer struct {
+	lock     sync.RWMutex
+	indexers map[string]cache.Indexer
+}
+
+func (m *multiNamespaceIndexer) add(ns string, indexer cache.Indexer) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.indexers[ns] = indexer
+}
+
+func (m *multiNamespaceIndexer) remove(ns string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.indexers, ns)
+}
+
+var _ cache.Indexer = &multiNamespaceIndexer{}
+
+func (m *multiNamespaceIndexer) Add(_ interface{}) error {
+	return fmt.Errorf("multiNamespaceIndexer is read-only")
+}
+
+func (m *multiNamespaceIndexer) Update(_ interface{}) error {
+	return fmt.Errorf("multiNamespaceIndexer is read-only")
+}
+
+func (m *multiNamespaceIndexer) Delete(_ interface{}) error {
+	return fmt.Errorf("multiNamespaceIndexer is read-only")
+}
+
+func (m *multiNamespaceIndexer) Replace(_ []interface{}, _ string) error {
+	return fmt.Errorf("multiNamespaceIndexer is read-only")
+}
+
+func (m *multiNamespaceIndexer) Resync() error {
+	return nil
+}
+
+func (m *multiNamespaceIndexer) List() []interface{} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var objs []interface{}
+	for _, indexer := range m.indexers {
+		objs = append(objs, indexer.List()...)
+	}
+	return objs
+}
+
+func (m *multiNamespaceIndexer) ListKeys() []string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var keys []string
+	for _, indexer := range m.indexers {
+		keys = append(keys, indexer.ListKeys()...)
+	}
+	return keys
+}
+
+func (m *multiNamespaceIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	indexer, ok := m.indexers[accessor.GetNamespace()]
+	if !ok {
+		return nil, false, nil
+	}
+	return indexer.Get(obj)
+}
+
+func (m *multiNamespaceIndexer) GetByKey(key string) (interface{}, bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	ns, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+	indexer, ok := m.indexers[ns]
+	if !ok {
+		return nil, false, nil
+	}
+	return indexer.GetByKey(key)
+}
+
+func (m *multiNamespaceIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var objs []interface{}
+	for _, indexer := range m.indexers {
+		items, err := indexer.Index(indexName, obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, items...)
+	}
+	return objs, nil
+}
+
+func (m *multiNamespaceIndexer) IndexKeys(indexName, indexedValue string) ([]string, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if indexName == cache.NamespaceIndex {
+		indexer, ok := m.indexers[indexedValue]
+		if !ok {
+			return nil, nil
+		}
+		return indexer.IndexKeys(indexName, indexedValue)
+	}
+	var keys []string
+	for _, indexer := range m.indexers {
+		items, err := indexer.IndexKeys(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, items...)
+	}
+	return keys, nil
+}
+
+func (m *multiNamespaceIndexer) ListIndexFuncValues(indexName string) []string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	values := map[string]struct{}{}
+	for _, indexer := range m.indexers {
+		for _, value := range indexer.ListIndexFuncValues(indexName) {
+			values[value] = struct{}{}
+		}
+	}
+	var result []string
+	for value := range values {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (m *multiNamespaceIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if indexName == cache.NamespaceIndex {
+		indexer, ok := m.indexers[indexedValue]
+		if !ok {
+			return nil, nil
+		}
+		return indexer.ByIndex(indexName, indexedValue)
+	}
+	var objs []interface{}
+	for _, indexer := range m.indexers {
+		items, err := indexer.ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, items...)
+	}
+	return objs, nil
+}
+
+func (m *multiNamespaceIndexer) GetIndexers() cache.Indexers {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for _, indexer := range m.indexers {
+		return indexer.GetIndexers()
+	}
+	return cache.Indexers{}
+}
+
+func (m *multiNamespaceIndexer) AddIndexers(newIndexers cache.Indexers) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, indexer := range m.indexers {
+		if err := indexer.AddIndexers(newIndexers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namespaceInformerFactories are the informer factories of a watched namespace, they are created with
+// the same options as the shared ones except the namespace
+type namespaceInformerFactories struct {
+	informerFactory                informers.SharedInformerFactory
+	kubeInformerFactory            kubeinformers.SharedInformerFactory
+	labelFilterKubeInformerFactory kubeinformers.SharedInformerFactory
+}
+
+// multiNamespaceInformers are the merged informers registered in the shared informer factories
+type multiNamespaceInformers struct {
+	newFactories func(ns string) *namespaceInformerFactories
+
+	lock      sync.Mutex
+	factories map[string]*namespaceInformerFactories
+	informers []*multiNamespaceInformer
+}
+
+// factoriesFor returns the informer factories of the namespace, the ones of a removed namespace are
+// dropped as the stopped informers can't be run again
+func (m *multiNamespaceInformers) factoriesFor(ns string) *namespaceInformerFactories {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	f, ok := m.factories[ns]
+	if !ok {
+		f = m.newFactories(ns)
+		m.factories[ns] = f
+	}
+	return f
+}
+
+// AddNamespace starts watching the namespace
+func (m *multiNamespaceInformers) AddNamespace(ns string) {
+	for _, informer := range m.informers {
+		informer.addNamespace(ns)
+	}
+}
+
+// RemoveNamespace stops watching the namespace
+func (m *multiNamespaceInformers) RemoveNamespace(ns string) {
+	for _, informer := range m.informers {
+		informer.removeNamespace(ns)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.factories, ns)
+}
+
+// registerMultiNamespaceInformers registers the merged informers of all the namespaced resources
+// watched by the controllers in the shared informer factories, and adds the namespaces to them.
+func registerMultiNamespaceInformers(
+	informerFactory informers.SharedInformerFactory,
+	kubeInformerFactory kubeinformers.SharedInformerFactory,
+	labelFilterKubeInformerFactory kubeinformers.SharedInformerFactory,
+	namespaces []string,
+	newFactories func(ns string) *namespaceInformerFactories) *multiNamespaceInformers {
+
+	m := &multiNamespaceInformers{
+		newFactories: newFactories,
+		factories:    map[string]*namespaceInformerFactories{},
+	}
+	pingcapResources := map[runtime.Object]func(informers.SharedInformerFactory) cache.SharedIndexInformer{
+		&v1alpha1.TidbCluster{}: func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Pingcap().V1alpha1().TidbClusters().Informer()
+		},
+		&v1alpha1.TidbClusterAutoScaler{}: func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Pingcap().V1alpha1().TidbClusterAutoScalers().Informer()
+		},
+		&v1alpha1.DMCluster{}: func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Pingcap().V1alpha1().DMClusters().Informer()
+		},
+		&v1alpha1.Backup{}: func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Pingcap().V1alpha1().Backups().Informer()
+		},
+		&v1alpha1.Restore{}: func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Pingcap().V1alpha1().Restores().Informer()
+		},
+		&v1alpha1.BackupSchedule{}: func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Pingcap().V1alpha1().BackupSchedules().Informer()
+		},
+		&v1alpha1.TidbInitializer{}: func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Pingcap().V1alpha1().TidbInitializers().Informer()
+		},
+		&v1alpha1.TidbMonitor{}: func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Pingcap().V1alpha1().TidbMonitors().Informer()
+		},
+	}
+	for obj, informerFunc := range pingcapResources {
+		informerFunc := informerFunc
+		informer := newMultiNamespaceInformer(func(ns string) cache.SharedIndexInformer {
+			return informerFunc(m.factoriesFor(ns).informerFactory)
+		})
+		m.informers = append(m.informers, informer)
+		informerFactory.InformerFor(obj, func(_ versioned.Interface, _ time.Duration) cache.SharedIndexInformer {
+			return informer
+		})
+	}
+
+	kubeResources := map[runtime.Object]func(kubeinformers.SharedInformerFactory) cache.SharedIndexInformer{
+		&corev1.Pod{}: func(f kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Core().V1().Pods().Informer()
+		},
+		&corev1.Service{}: func(f kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Core().V1().Services().Informer()
+		},
+		&corev1.Endpoints{}: func(f kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Core().V1().Endpoints().Informer()
+		},
+		&corev1.PersistentVolumeClaim{}: func(f kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Core().V1().PersistentVolumeClaims().Informer()
+		},
+		&corev1.Secret{}: func(f kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Core().V1().Secrets().Informer()
+		},
+		&apps.StatefulSet{}: func(f kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Apps().V1().StatefulSets().Informer()
+		},
+		&apps.Deployment{}: func(f kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Apps().V1().Deployments().Informer()
+		},
+		&batchv1.Job{}: func(f kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Batch().V1().Jobs().Informer()
+		},
+		&extensionsv1beta1.Ingress{}: func(f kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+			return f.Extensions().V1beta1().Ingresses().Informer()
+		},
+	}
+	for obj, informerFunc := range kubeResources {
+		informerFunc := informerFunc
+		m.registerKubeInformer(kubeInformerFactory, obj, func(ns string) cache.SharedIndexInformer {
+			return informerFunc(m.factoriesFor(ns).kubeInformerFactory)
+		})
+	}
+	// only the configmaps are watched with the label filter
+	m.registerKubeInformer(labelFilterKubeInformerFactory, &corev1.ConfigMap{}, func(ns string) cache.SharedIndexInformer {
+		return m.factoriesFor(ns).labelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer()
+	})
+
+	for _, ns := range namespaces {
+		m.AddNamespace(ns)
+	}
+	return m
+}
+
+func (m *multiNamespaceInformers) registerKubeInformer(
+	shared kubeinformers.SharedInformerFactory,
+	obj runtime.Object,
+	newInformer func(ns string) cache.SharedIndexInformer) {
+	informer := newMultiNamespaceInformer(newInformer)
+	m.informers = append(m.informers, informer)
+	shared.InformerFor(obj, func(_ kubernetes.Interface, _ time.Duration) cache.SharedIndexInformer {
+		return informer
+	})
+}
+
+// watchSelectedNamespaces registers an informer of the namespaces selected by --namespace-selector in
+// the kube informer factory, the selected namespaces are added to the merged informers when they are
+// created or labeled, and removed when they are deleted or unlabeled unless given by --namespaces.
+func watchSelectedNamespaces(kubeInformerFactory kubeinformers.SharedInformerFactory, cliCfg *CLIConfig, m *multiNamespaceInformers) {
+	given := cliCfg.givenNamespaces()
+	informer := kubeInformerFactory.InformerFor(&corev1.Namespace{}, func(cli kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+		return coreinformers.NewFilteredNamespaceInformer(cli, resyncPeriod, cache.Indexers{}, func(options *metav1.ListOptions) {
+			options.LabelSelector = cliCfg.NamespaceSelector
+		})
+	})
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			ns := obj.(*corev1.Namespace)
+			klog.Infof("start watching namespace %s selected by %q", ns.Name, cliCfg.NamespaceSelector)
+			m.AddNamespace(ns.Name)
+		},
+		DeleteFunc: func(obj interface{}) {
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				klog.Errorf("couldn't get key for object %+v: %v", obj, err)
+				return
+			}
+			if given.Has(key) {
+				return
+			}
+			klog.Infof("stop watching namespace %s no longer selected by %q", key, cliCfg.NamespaceSelector)
+			m.RemoveNamespace(key)
+		},
+	})
+}
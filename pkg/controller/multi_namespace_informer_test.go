@@ -0,0 +1,180 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestMultiNamespaceInformers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var kubeObjects, objects []runtime.Object
+	for _, ns := range []string{"ns-1", "ns-2", "ns-3"} {
+		kubeObjects = append(kubeObjects, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "pod"}})
+		objects = append(objects, &v1alpha1.TidbCluster{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "tc"}})
+	}
+	kubeCli := kubefake.NewSimpleClientset(kubeObjects...)
+	cli := fake.NewSimpleClientset(objects...)
+
+	informerFactory := informers.NewSharedInformerFactory(cli, 0)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+	labelFilterKubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+	newFactories := func(ns string) *namespaceInformerFactories {
+		return &namespaceInformerFactories{
+			informerFactory:                informers.NewSharedInformerFactoryWithOptions(cli, 0, informers.WithNamespace(ns)),
+			kubeInformerFactory:            kubeinformers.NewSharedInformerFactoryWithOptions(kubeCli, 0, kubeinformers.WithNamespace(ns)),
+			labelFilterKubeInformerFactory: kubeinformers.NewSharedInformerFactoryWithOptions(kubeCli, 0, kubeinformers.WithNamespace(ns)),
+		}
+	}
+	m := registerMultiNamespaceInformers(informerFactory, kubeInformerFactory, labelFilterKubeInformerFactory,
+		[]string{"ns-1", "ns-2"}, newFactories)
+
+	var (
+		lock  sync.Mutex
+		added []string
+	)
+	informerFactory.Pingcap().V1alpha1().TidbClusters().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			lock.Lock()
+			defer lock.Unlock()
+			added = append(added, obj.(*v1alpha1.TidbCluster).Namespace)
+		},
+	})
+	podLister := kubeInformerFactory.Core().V1().Pods().Lister()
+	tcLister := informerFactory.Pingcap().V1alpha1().TidbClusters().Lister()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	kubeInformerFactory.Start(stopCh)
+	for _, synced := range informerFactory.WaitForCacheSync(stopCh) {
+		g.Expect(synced).To(BeTrue())
+	}
+	for _, synced := range kubeInformerFactory.WaitForCacheSync(stopCh) {
+		g.Expect(synced).To(BeTrue())
+	}
+
+	pods, err := podLister.List(labels.Everything())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pods).To(HaveLen(2))
+	_, err = podLister.Pods("ns-2").Get("pod")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = podLister.Pods("ns-3").Get("pod")
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+	pods, err = podLister.Pods("ns-1").List(labels.Everything())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pods).To(HaveLen(1))
+
+	tcs, err := tcLister.List(labels.Everything())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(2))
+	g.Eventually(func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, added...)
+	}, 5*time.Second).Should(ConsistOf("ns-1", "ns-2"))
+
+	// the namespaces added and removed at runtime
+	m.AddNamespace("ns-3")
+	m.RemoveNamespace("ns-1")
+	g.Eventually(func() int {
+		tcs, err := tcLister.List(labels.Everything())
+		g.Expect(err).NotTo(HaveOccurred())
+		return len(tcs)
+	}, 5*time.Second).Should(Equal(2))
+	_, err = podLister.Pods("ns-1").Get("pod")
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+	g.Eventually(func() error {
+		_, err := podLister.Pods("ns-3").Get("pod")
+		return err
+	}, 5*time.Second).Should(Succeed())
+	g.Eventually(func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, added...)
+	}, 5*time.Second).Should(ConsistOf("ns-1", "ns-2", "ns-3"))
+	g.Expect(kubeInformerFactory.Core().V1().Pods().Informer().HasSynced()).To(BeTrue())
+}
+
+func TestWatchSelectedNamespaces(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	kubeCli := kubefake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1", Labels: map[string]string{"watched": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-2"}},
+	)
+	cliCfg := DefaultCLIConfig()
+	cliCfg.ClusterScoped = false
+	cliCfg.Namespaces = "ns-2"
+	cliCfg.NamespaceSelector = "watched=true"
+	namespaces, err := cliCfg.WatchNamespaces(kubeCli, "pingcap")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(namespaces).To(Equal([]string{"ns-1", "ns-2"}))
+
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+	m := registerMultiNamespaceInformers(informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0),
+		kubeInformerFactory, kubeinformers.NewSharedInformerFactory(kubeCli, 0), namespaces,
+		func(ns string) *namespaceInformerFactories {
+			return &namespaceInformerFactories{
+				informerFactory:                informers.NewSharedInformerFactoryWithOptions(fake.NewSimpleClientset(), 0, informers.WithNamespace(ns)),
+				kubeInformerFactory:            kubeinformers.NewSharedInformerFactoryWithOptions(kubeCli, 0, kubeinformers.WithNamespace(ns)),
+				labelFilterKubeInformerFactory: kubeinformers.NewSharedInformerFactoryWithOptions(kubeCli, 0, kubeinformers.WithNamespace(ns)),
+			}
+		})
+	watchSelectedNamespaces(kubeInformerFactory, cliCfg, m)
+	podInformer := kubeInformerFactory.Core().V1().Pods().Informer().(*multiNamespaceInformer)
+	watched := func() []string {
+		podInformer.lock.Lock()
+		defer podInformer.lock.Unlock()
+		var namespaces []string
+		for ns := range podInformer.informers {
+			namespaces = append(namespaces, ns)
+		}
+		return namespaces
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	kubeInformerFactory.Start(stopCh)
+	kubeInformerFactory.WaitForCacheSync(stopCh)
+
+	_, err = kubeCli.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns-3", Labels: map[string]string{"watched": "true"}},
+	}, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Eventually(watched, 5*time.Second).Should(ConsistOf("ns-1", "ns-2", "ns-3"))
+
+	// the namespaces given by --namespaces are always watched
+	for _, ns := range []string{"ns-1", "ns-2"} {
+		g.Expect(kubeCli.CoreV1().Namespaces().Delete(context.TODO(), ns, metav1.DeleteOptions{})).To(Succeed())
+	}
+	g.Eventually(watched, 5*time.Second).Should(ConsistOf("ns-2", "ns-3"))
+}
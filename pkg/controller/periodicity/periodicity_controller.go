@@ -82,6 +82,9 @@ func (c *Controller) syncStatefulSetTimeStamp() error {
 		if !ok {
 			continue
 		}
+		if !c.deps.Sharder.Owns(sts.Namespace + "/" + tcRef.Name) {
+			continue
+		}
 		_, err := c.deps.TiDBClusterLister.TidbClusters(sts.Namespace).Get(tcRef.Name)
 		if err != nil {
 			errs = append(errs, err)
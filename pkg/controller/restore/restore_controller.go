@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/restore"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -56,10 +57,16 @@ func NewController(deps *controller.Dependencies) *Controller {
 	restoreInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: c.updateRestore,
 		UpdateFunc: func(old, cur interface{}) {
+			newRestore := cur.(*v1alpha1.Restore)
+			// all replicas watch the restore, only the one owning it records the metrics
+			if c.deps.Sharder.Owns(newRestore.Namespace + "/" + newRestore.Name) {
+				observeRestoreFinished(old.(*v1alpha1.Restore), newRestore)
+			}
 			c.updateRestore(cur)
 		},
 		DeleteFunc: c.enqueueRestore,
 	})
+	controller.RequeueOnRebalance(deps.Sharder, restoreInformer.Informer(), c.queue)
 	return c
 }
 
@@ -92,7 +99,14 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	if !c.deps.Sharder.Owns(key.(string)) {
+		c.queue.Forget(key)
+		return true
+	}
+	startTime := time.Now()
+	err := c.sync(key.(string))
+	controller.ObserveReconcile("restore", key.(string), startTime, err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("Restore: %v, still need sync: %v, requeuing", key.(string), err)
 			c.queue.AddRateLimited(key)
@@ -198,3 +212,34 @@ func (c *Controller) enqueueRestore(obj interface{}) {
 	}
 	c.queue.Add(key)
 }
+
+// observeRestoreFinished records the duration and the result of the restore when it turns complete or failed
+func observeRestoreFinished(old, cur *v1alpha1.Restore) {
+	var result string
+	switch {
+	case v1alpha1.IsRestoreComplete(cur) && !v1alpha1.IsRestoreComplete(old):
+		result = metrics.RestoreComplete
+	case v1alpha1.IsRestoreFailed(cur) && !v1alpha1.IsRestoreFailed(old):
+		result = metrics.RestoreFailed
+	default:
+		return
+	}
+
+	var cluster string
+	if cur.Spec.BR != nil {
+		cluster = cur.Spec.BR.Cluster
+	}
+	backupType := cur.Spec.Type
+	if backupType == "" {
+		backupType = v1alpha1.BackupTypeFull
+	}
+	startTime := cur.Status.TimeStarted.Time
+	if startTime.IsZero() {
+		startTime = cur.CreationTimestamp.Time
+	}
+	endTime := cur.Status.TimeCompleted.Time
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+	metrics.RestoreDuration.WithLabelValues(cur.Namespace, cluster, string(backupType), result).Observe(endTime.Sub(startTime).Seconds())
+}
@@ -39,9 +39,16 @@ type RestoreUpdateStatus struct {
 	TimeCompleted *metav1.Time
 	// CommitTs is the snapshot time point of tidb cluster.
 	CommitTs *string
+	// Progress is the latest progress reported by BR.
+	Progress *v1alpha1.BRProgress
+	// LastError is the last error line printed by BR.
+	LastError *string
+	// PitrRestoredTs is the TSO the change log is replayed to.
+	PitrRestoredTs *string
 }
 
-// RestoreConditionUpdaterInterface enables updating Restore conditions.
+// RestoreConditionUpdaterInterface enables updating Restore conditions,
+// a nil condition updates only the status fields in RestoreUpdateStatus.
 type RestoreConditionUpdaterInterface interface {
 	Update(restore *v1alpha1.Restore, condition *v1alpha1.RestoreCondition, newStatus *RestoreUpdateStatus) error
 }
@@ -70,7 +77,8 @@ func (u *realRestoreConditionUpdater) Update(restore *v1alpha1.Restore, conditio
 	var isUpdate bool
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		updateRestoreStatus(&restore.Status, newStatus)
-		isUpdate = v1alpha1.UpdateRestoreCondition(&restore.Status, condition)
+		// a nil condition only updates the status fields, e.g. the progress
+		isUpdate = condition == nil || v1alpha1.UpdateRestoreCondition(&restore.Status, condition)
 		if isUpdate {
 			_, updateErr := u.cli.PingcapV1alpha1().Restores(ns).Update(context.TODO(), restore, metav1.UpdateOptions{})
 			if updateErr == nil {
@@ -106,6 +114,15 @@ func updateRestoreStatus(status *v1alpha1.RestoreStatus, newStatus *RestoreUpdat
 	if newStatus.CommitTs != nil {
 		status.CommitTs = *newStatus.CommitTs
 	}
+	if newStatus.Progress != nil {
+		status.Progress = newStatus.Progress
+	}
+	if newStatus.LastError != nil {
+		status.LastError = *newStatus.LastError
+	}
+	if newStatus.PitrRestoredTs != nil {
+		status.PitrRestoredTs = *newStatus.PitrRestoredTs
+	}
 }
 
 var _ RestoreConditionUpdaterInterface = &realRestoreConditionUpdater{}
@@ -62,9 +62,12 @@ func newUpdateRestoreStatus() *RestoreUpdateStatus {
 	start, _ := time.Parse(time.RFC3339, "2020-12-25T21:46:59Z")
 	end, _ := time.Parse(time.RFC3339, "2020-12-25T21:50:59Z")
 	return &RestoreUpdateStatus{
-		CommitTs:      &ts,
-		TimeCompleted: &metav1.Time{Time: end},
-		TimeStarted:   &metav1.Time{Time: start},
+		CommitTs:       &ts,
+		TimeCompleted:  &metav1.Time{Time: end},
+		TimeStarted:    &metav1.Time{Time: start},
+		Progress:       &v1alpha1.BRProgress{Step: "Full restore", Percentage: "50.00%"},
+		LastError:      &ts,
+		PitrRestoredTs: &ts,
 	}
 }
 
@@ -101,5 +104,8 @@ func newExpectRestoreStatus() *v1alpha1.RestoreStatus {
 	s.CommitTs = ts
 	s.TimeStarted = metav1.Time{Time: start}
 	s.TimeCompleted = metav1.Time{Time: end}
+	s.Progress = &v1alpha1.BRProgress{Step: "Full restore", Percentage: "50.00%"}
+	s.LastError = ts
+	s.PitrRestoredTs = ts
 	return s
 }
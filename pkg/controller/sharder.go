@@ -0,0 +1,56 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Sharder decides whether an object is owned by the current controller-manager replica. When sharding is
+// enabled, several controller-manager replicas are active at the same time and every object is synced by
+// the only replica owning it, see pkg/controller/sharding for the implementation.
+type Sharder interface {
+	// Owns returns whether the object with the key in the format of namespace/name is owned by this replica.
+	// The controllers drop the objects not owned, they are synced by the replicas owning them, and
+	// the objects become owned are enqueued again by the rebalance handlers.
+	Owns(key string) bool
+	// AddRebalanceHandler adds a handler which is called after the objects are reassigned among the replicas
+	AddRebalanceHandler(handler func())
+}
+
+type unsharded struct{}
+
+// NewUnsharded returns a Sharder owning all objects, it's used when sharding is disabled and only the
+// leader replica is active.
+func NewUnsharded() Sharder {
+	return unsharded{}
+}
+
+func (unsharded) Owns(_ string) bool {
+	return true
+}
+
+func (unsharded) AddRebalanceHandler(_ func()) {
+}
+
+// RequeueOnRebalance enqueues all objects of the informer after the objects are reassigned, so the objects
+// newly owned by this replica are synced without waiting for the next resync of the informer.
+func RequeueOnRebalance(sharder Sharder, informer cache.SharedIndexInformer, queue workqueue.Interface) {
+	sharder.AddRebalanceHandler(func() {
+		for _, key := range informer.GetStore().ListKeys() {
+			queue.Add(key)
+		}
+	})
+}
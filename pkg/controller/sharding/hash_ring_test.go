@@ -0,0 +1,52 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHashRing(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(newHashRing(nil).get("ns/demo")).To(Equal(""))
+
+	var keys []string
+	for i := 0; i < 1000; i++ {
+		keys = append(keys, fmt.Sprintf("ns-%d/demo", i))
+	}
+	ring := newHashRing([]string{"a", "b", "c"})
+	owners := map[string]string{}
+	counts := map[string]int{}
+	for _, key := range keys {
+		owners[key] = ring.get(key)
+		counts[owners[key]]++
+	}
+	for _, member := range []string{"a", "b", "c"} {
+		g.Expect(counts[member]).To(BeNumerically(">", 200), "keys of member %s", member)
+	}
+
+	// only the keys of the removed member are reassigned
+	ring = newHashRing([]string{"a", "c"})
+	for _, key := range keys {
+		if owners[key] != "b" {
+			g.Expect(ring.get(key)).To(Equal(owners[key]))
+		} else {
+			g.Expect(ring.get(key)).To(BeElementOf("a", "c"))
+		}
+	}
+}
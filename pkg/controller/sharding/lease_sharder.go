@@ -0,0 +1,277 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// LabelShardGroup is the label of the member leases, the value is the name of the group of
+// controller-manager replicas sharing the objects
+const LabelShardGroup = "tidb.pingcap.com/shard-group"
+
+// expiredLeaseRetention is how long the lease of a member is kept after it is expired. The members are
+// named by the pod names, so the lease of a member left behind by a crash or a rolling update is never
+// renewed again and is deleted by the other members after this period.
+const expiredLeaseRetention = time.Hour
+
+// LeaseSharder assigns objects to the controller-manager replicas by consistent hashing of their
+// namespace/name. Every replica holds a member lease which is renewed every retry period, a replica
+// whose lease is not renewed within the lease duration is considered down and its objects are
+// reassigned to the remaining replicas.
+//
+// The expiry of the leases is judged by the time they are observed to be renewed locally instead
+// of the renew time written by other replicas, so it's not affected by clock skew.
+//
+// The objects are handed off with a fence: a replica stops syncing the objects reassigned to others
+// as soon as it observes the new members, but it only starts syncing the objects newly assigned to
+// it one lease duration after the rebalance. By then the previous owners have either observed the
+// new members or stopped syncing all objects for their own leases are expired, so an object is
+// never synced by two replicas at the same time.
+type LeaseSharder struct {
+	kubeCli       kubernetes.Interface
+	namespace     string
+	group         string
+	identity      string
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+	now           func() time.Time
+
+	lock sync.RWMutex
+	ring *hashRing
+	// fencedRing is the ring before the rebalances in the last lease duration, the objects owned
+	// in both rings are synced while the ones newly assigned are fenced until fenceUntil
+	fencedRing *hashRing
+	fenceUntil time.Time
+	fencing    bool
+	lastRenew  time.Time
+	observed   map[string]observedLease
+	handlers   []func()
+}
+
+type observedLease struct {
+	renewTime  metav1.MicroTime
+	observedAt time.Time
+}
+
+// NewLeaseSharder returns a LeaseSharder, the identity must be unique in the group, e.g. the pod name
+func NewLeaseSharder(kubeCli kubernetes.Interface, namespace, group, identity string, leaseDuration, retryPeriod time.Duration) *LeaseSharder {
+	return &LeaseSharder{
+		kubeCli:       kubeCli,
+		namespace:     namespace,
+		group:         group,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		retryPeriod:   retryPeriod,
+		now:           time.Now,
+		observed:      map[string]observedLease{},
+	}
+}
+
+// Run renews the member lease and rebalances the objects until stopCh is closed, the member lease
+// is deleted on exit so the objects are taken over by other replicas without waiting for its expiry.
+func (s *LeaseSharder) Run(stopCh <-chan struct{}) {
+	klog.Infof("sharding: start member %s of group %s", s.identity, s.group)
+	wait.Until(s.sync, s.retryPeriod, stopCh)
+	err := s.kubeCli.CoordinationV1().Leases(s.namespace).Delete(context.TODO(), s.leaseName(), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		klog.Errorf("sharding: failed to delete lease %s/%s, error: %v", s.namespace, s.leaseName(), err)
+	}
+}
+
+// Owns implements controller.Sharder
+func (s *LeaseSharder) Owns(key string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.ring == nil || s.ring.get(key) != s.identity {
+		return false
+	}
+	if s.now().Before(s.fenceUntil) {
+		return s.fencedRing != nil && s.fencedRing.get(key) == s.identity
+	}
+	return true
+}
+
+// AddRebalanceHandler implements controller.Sharder
+func (s *LeaseSharder) AddRebalanceHandler(handler func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+func (s *LeaseSharder) leaseName() string {
+	return s.group + "-shard-" + s.identity
+}
+
+func (s *LeaseSharder) sync() {
+	now := s.now()
+	if err := s.renew(now); err != nil {
+		klog.Errorf("sharding: failed to renew lease %s/%s, error: %v", s.namespace, s.leaseName(), err)
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.ring != nil && now.Sub(s.lastRenew) > s.leaseDuration {
+			// other replicas consider this replica down and take over its objects
+			klog.Warningf("sharding: lease %s/%s expired, stop syncing all objects", s.namespace, s.leaseName())
+			s.ring = nil
+			s.fencedRing = nil
+		}
+		return
+	}
+	s.lock.Lock()
+	s.lastRenew = now
+	s.lock.Unlock()
+
+	members, err := s.members(now)
+	if err != nil {
+		klog.Errorf("sharding: failed to list the member leases of group %s, error: %v", s.group, err)
+		return
+	}
+	s.setMembers(members, now)
+	s.endFence(now)
+}
+
+func (s *LeaseSharder) renew(now time.Time) error {
+	leases := s.kubeCli.CoordinationV1().Leases(s.namespace)
+	renewTime := metav1.NewMicroTime(now)
+	durationSeconds := int32(s.leaseDuration.Seconds())
+
+	lease, err := leases.Get(context.TODO(), s.leaseName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.leaseName(),
+				Namespace: s.namespace,
+				Labels:    map[string]string{LabelShardGroup: s.group},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &s.identity,
+				LeaseDurationSeconds: &durationSeconds,
+				AcquireTime:          &renewTime,
+				RenewTime:            &renewTime,
+			},
+		}
+		_, err = leases.Create(context.TODO(), lease, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	lease.Spec.HolderIdentity = &s.identity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &renewTime
+	_, err = leases.Update(context.TODO(), lease, metav1.UpdateOptions{})
+	return err
+}
+
+// members returns the sorted identities of the replicas whose leases are not expired
+func (s *LeaseSharder) members(now time.Time) ([]string, error) {
+	list, err := s.kubeCli.CoordinationV1().Leases(s.namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: LabelShardGroup + "=" + s.group,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	observed := map[string]observedLease{}
+	var members []string
+	for _, lease := range list.Items {
+		spec := lease.Spec
+		if spec.HolderIdentity == nil || spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		ol := observedLease{renewTime: *spec.RenewTime, observedAt: now}
+		if last, ok := s.observed[lease.Name]; ok && last.renewTime.Equal(spec.RenewTime) {
+			ol.observedAt = last.observedAt
+		}
+		observed[lease.Name] = ol
+		if expired := now.Sub(ol.observedAt); expired > time.Duration(*spec.LeaseDurationSeconds)*time.Second {
+			if expired > expiredLeaseRetention {
+				s.deleteExpiredLease(&lease)
+				delete(observed, lease.Name)
+			}
+			continue
+		}
+		members = append(members, *spec.HolderIdentity)
+	}
+	s.observed = observed
+	sort.Strings(members)
+	return members, nil
+}
+
+// deleteExpiredLease deletes the lease of a member which is not renewed for expiredLeaseRetention,
+// the deletion is skipped if the lease is renewed meanwhile
+func (s *LeaseSharder) deleteExpiredLease(lease *coordinationv1.Lease) {
+	klog.Infof("sharding: delete lease %s/%s not renewed for more than %v", s.namespace, lease.Name, expiredLeaseRetention)
+	err := s.kubeCli.CoordinationV1().Leases(s.namespace).Delete(context.TODO(), lease.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &lease.ResourceVersion},
+	})
+	if err != nil && !errors.IsNotFound(err) && !errors.IsConflict(err) {
+		klog.Errorf("sharding: failed to delete expired lease %s/%s, error: %v", s.namespace, lease.Name, err)
+	}
+}
+
+// setMembers rebuilds the hash ring, fences the objects newly assigned to this replica and calls the
+// rebalance handlers if the members changed
+func (s *LeaseSharder) setMembers(members []string, now time.Time) {
+	s.lock.Lock()
+	if s.ring != nil && reflect.DeepEqual(s.ring.members, members) {
+		s.lock.Unlock()
+		return
+	}
+	// the objects assigned by a rebalance in the fence are still fenced, so the ring before it is kept
+	if !s.fencing {
+		s.fencedRing = s.ring
+	}
+	s.ring = newHashRing(members)
+	s.fenceUntil = now.Add(s.leaseDuration)
+	s.fencing = true
+	handlers := s.handlers
+	s.lock.Unlock()
+
+	klog.Infof("sharding: members of group %s changed to %v, rebalance the objects, the newly assigned ones are synced after %v",
+		s.group, members, s.fenceUntil)
+	for _, handler := range handlers {
+		handler()
+	}
+}
+
+// endFence calls the rebalance handlers when the fence ends, so the objects newly assigned to this
+// replica are synced
+func (s *LeaseSharder) endFence(now time.Time) {
+	s.lock.Lock()
+	if !s.fencing || now.Before(s.fenceUntil) {
+		s.lock.Unlock()
+		return
+	}
+	s.fencing = false
+	s.fencedRing = nil
+	handlers := s.handlers
+	s.lock.Unlock()
+
+	klog.Infof("sharding: fence of the objects newly assigned to member %s of group %s ended", s.identity, s.group)
+	for _, handler := range handlers {
+		handler()
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLeaseSharder(t *testing.T) {
+	g := NewGomegaWithT(t)
+	kubeCli := kubefake.NewSimpleClientset()
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	a := NewLeaseSharder(kubeCli, "pingcap", "tidb-controller-manager", "a", 15*time.Second, 2*time.Second)
+	b := NewLeaseSharder(kubeCli, "pingcap", "tidb-controller-manager", "b", 15*time.Second, 2*time.Second)
+	a.now, b.now = clock, clock
+	rebalanced := 0
+	a.AddRebalanceHandler(func() { rebalanced++ })
+
+	// the objects are not synced until the previous owners must have stopped syncing them
+	g.Expect(a.Owns("ns/demo")).To(BeFalse())
+	a.sync()
+	g.Expect(rebalanced).To(Equal(1))
+	g.Expect(a.Owns("ns/demo")).To(BeFalse())
+	now = now.Add(16 * time.Second)
+	a.sync()
+	g.Expect(rebalanced).To(Equal(2))
+	g.Expect(a.Owns("ns/demo")).To(BeTrue())
+
+	// a stops syncing the objects assigned to b at once, while b waits for the fence
+	b.sync()
+	a.sync()
+	g.Expect(rebalanced).To(Equal(3))
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("ns-%d/demo", i)
+		g.Expect(a.Owns(key)).To(Equal(a.ring.get(key) == "a"), "key %s", key)
+		g.Expect(b.Owns(key)).To(BeFalse(), "key %s", key)
+	}
+	for i := 0; i < 8; i++ {
+		now = now.Add(2 * time.Second)
+		b.sync()
+		a.sync()
+	}
+	g.Expect(rebalanced).To(Equal(4))
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("ns-%d/demo", i)
+		g.Expect(a.Owns(key)).NotTo(Equal(b.Owns(key)), "key %s must be owned by exactly one member", key)
+	}
+
+	// no rebalance if the members are not changed
+	now = now.Add(2 * time.Second)
+	b.sync()
+	a.sync()
+	g.Expect(rebalanced).To(Equal(4))
+
+	// b is down, its lease is not renewed and a takes over all the objects one lease duration after the lease expires
+	now = now.Add(10 * time.Second)
+	a.sync()
+	g.Expect(rebalanced).To(Equal(4))
+	now = now.Add(10 * time.Second)
+	a.sync()
+	g.Expect(rebalanced).To(Equal(5))
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("ns-%d/demo", i)
+		g.Expect(a.Owns(key)).To(Equal(b.ring.get(key) == "a"), "key %s", key)
+	}
+	now = now.Add(16 * time.Second)
+	a.sync()
+	g.Expect(rebalanced).To(Equal(6))
+	for i := 0; i < 100; i++ {
+		g.Expect(a.Owns(fmt.Sprintf("ns-%d/demo", i))).To(BeTrue())
+	}
+
+	// the lease of b is deleted after it is expired for a long time
+	_, err := kubeCli.CoordinationV1().Leases("pingcap").Get(context.TODO(), "tidb-controller-manager-shard-b", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	now = now.Add(expiredLeaseRetention)
+	a.sync()
+	_, err = kubeCli.CoordinationV1().Leases("pingcap").Get(context.TODO(), "tidb-controller-manager-shard-b", metav1.GetOptions{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+	g.Expect(rebalanced).To(Equal(6))
+
+	// the lease is deleted when the member exits
+	stopCh := make(chan struct{})
+	close(stopCh)
+	a.Run(stopCh)
+	_, err = kubeCli.CoordinationV1().Leases("pingcap").Get(context.TODO(), "tidb-controller-manager-shard-a", metav1.GetOptions{})
+	g.Expect(err).To(HaveOccurred())
+}
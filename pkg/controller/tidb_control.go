@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -34,7 +36,16 @@ const (
 )
 
 type DBInfo struct {
-	IsOwner bool `json:"is_owner"`
+	IsOwner bool   `json:"is_owner"`
+	Version string `json:"version,omitempty"`
+	GitHash string `json:"git_hash,omitempty"`
+}
+
+// DBStatus is the status returned by the status API of TiDB
+type DBStatus struct {
+	Connections int    `json:"connections"`
+	Version     string `json:"version"`
+	GitHash     string `json:"git_hash"`
 }
 
 // TiDBControlInterface is the interface that knows how to manage tidb peers
@@ -45,6 +56,12 @@ type TiDBControlInterface interface {
 	GetInfo(tc *v1alpha1.TidbCluster, ordinal int32) (*DBInfo, error)
 	// GetSettings return the TiDB instance settings
 	GetSettings(tc *v1alpha1.TidbCluster, ordinal int32) (*config.Config, error)
+	// GetStatus returns the status of tidb, including the number of connections
+	GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*DBStatus, error)
+	// ResignDDLOwner makes tidb resign the DDL owner, it returns false if tidb is not the DDL owner
+	ResignDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)
+	// UpdateSettings changes the settings of tidb online, the settings are the form fields of its settings API
+	UpdateSettings(tc *v1alpha1.TidbCluster, ordinal int32, settings map[string]string) error
 }
 
 // defaultTiDBControl is default implementation of TiDBControlInterface.
@@ -137,6 +154,83 @@ func (c *defaultTiDBControl) GetSettings(tc *v1alpha1.TidbCluster, ordinal int32
 	return &info, nil
 }
 
+func (c *defaultTiDBControl) GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*DBStatus, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := c.getBaseURL(tc, ordinal)
+	url := fmt.Sprintf("%s/status", baseURL)
+	body, err := getBodyOK(httpClient, url)
+	if err != nil {
+		return nil, err
+	}
+	status := DBStatus{}
+	err = json.Unmarshal(body, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (c *defaultTiDBControl) ResignDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return false, err
+	}
+
+	baseURL := c.getBaseURL(tc, ordinal)
+	url := fmt.Sprintf("%s/ddl/owner/resign", baseURL)
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return false, err
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer httputil.DeferClose(res.Body)
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return false, err
+	}
+	if res.StatusCode == http.StatusOK {
+		return true, nil
+	}
+	if strings.Contains(string(body), NotDDLOwnerError) {
+		return false, nil
+	}
+	return false, fmt.Errorf("Error response %s:%v URL: %s", string(body), res.StatusCode, url)
+}
+
+func (c *defaultTiDBControl) UpdateSettings(tc *v1alpha1.TidbCluster, ordinal int32, settings map[string]string) error {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	for k, v := range settings {
+		form.Set(k, v)
+	}
+	baseURL := c.getBaseURL(tc, ordinal)
+	apiURL := fmt.Sprintf("%s/settings", baseURL)
+	res, err := httpClient.PostForm(apiURL, form)
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("Error response %s:%v URL: %s", string(body), res.StatusCode, apiURL)
+}
+
 func getBodyOK(httpClient *http.Client, apiURL string) ([]byte, error) {
 	res, err := httpClient.Get(apiURL)
 	if err != nil {
@@ -174,6 +268,13 @@ type FakeTiDBControl struct {
 	tiDBInfo     *DBInfo
 	getInfoError error
 	tidbConfig   *config.Config
+	infos        map[string]*DBInfo
+	statuses     map[string]*DBStatus
+	// ResignedDDLOwners records the pods resigned the DDL owner
+	ResignedDDLOwners []string
+	// Settings records the settings updated of each pod
+	Settings          map[string]map[string]string
+	updateSettingsErr error
 }
 
 // NewFakeTiDBControl returns a FakeTiDBControl instance
@@ -197,10 +298,59 @@ func (c *FakeTiDBControl) GetHealth(tc *v1alpha1.TidbCluster, ordinal int32) (bo
 	return false, nil
 }
 
+// SetInfo set the info of each pod for FakeTiDBControl
+func (c *FakeTiDBControl) SetInfo(infos map[string]*DBInfo) {
+	c.infos = infos
+}
+
+// SetStatus set the status of each pod for FakeTiDBControl
+func (c *FakeTiDBControl) SetStatus(statuses map[string]*DBStatus) {
+	c.statuses = statuses
+}
+
 func (c *FakeTiDBControl) GetInfo(tc *v1alpha1.TidbCluster, ordinal int32) (*DBInfo, error) {
+	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	if info, ok := c.infos[podName]; ok {
+		return info, c.getInfoError
+	}
 	return c.tiDBInfo, c.getInfoError
 }
 
+func (c *FakeTiDBControl) GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*DBStatus, error) {
+	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	if status, ok := c.statuses[podName]; ok {
+		return status, nil
+	}
+	return &DBStatus{}, nil
+}
+
+func (c *FakeTiDBControl) ResignDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	info, ok := c.infos[podName]
+	if !ok || !info.IsOwner {
+		return false, nil
+	}
+	c.ResignedDDLOwners = append(c.ResignedDDLOwners, podName)
+	return true, nil
+}
+
+// SetUpdateSettingsError sets the error returned by UpdateSettings
+func (c *FakeTiDBControl) SetUpdateSettingsError(err error) {
+	c.updateSettingsErr = err
+}
+
+func (c *FakeTiDBControl) UpdateSettings(tc *v1alpha1.TidbCluster, ordinal int32, settings map[string]string) error {
+	if c.updateSettingsErr != nil {
+		return c.updateSettingsErr
+	}
+	if c.Settings == nil {
+		c.Settings = map[string]map[string]string{}
+	}
+	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	c.Settings[podName] = settings
+	return nil
+}
+
 func (c *FakeTiDBControl) GetSettings(tc *v1alpha1.TidbCluster, ordinal int32) (*config.Config, error) {
 	return c.tidbConfig, c.getInfoError
 }
@@ -265,6 +265,152 @@ func TestSettings(t *testing.T) {
 	}
 }
 
+func TestStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		caseName string
+		failed   bool
+		resp     DBStatus
+		expected *DBStatus
+	}{
+		{
+			caseName: "GetStatus",
+			resp:     DBStatus{Connections: 3, Version: "5.7.25-TiDB-v4.0.9", GitHash: "69f05ea55e8409152a7721b2dd8822af011355ea"},
+			expected: &DBStatus{Connections: 3, Version: "5.7.25-TiDB-v4.0.9", GitHash: "69f05ea55e8409152a7721b2dd8822af011355ea"},
+		},
+		{
+			caseName: "GetStatus failed",
+			failed:   true,
+		},
+	}
+
+	for _, c := range cases {
+		svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.Method).To(Equal("GET"), "check method")
+			g.Expect(request.URL.Path).To(Equal("/status"), "check url")
+
+			w.Header().Set("Content-Type", ContentTypeJSON)
+			if c.failed {
+				w.WriteHeader(http.StatusInternalServerError)
+			} else {
+				data, err := json.Marshal(c.resp)
+				g.Expect(err).NotTo(HaveOccurred())
+				w.Write(data)
+			}
+		})
+		defer svc.Close()
+
+		fakeClient := &fake.Clientset{}
+		control := NewDefaultTiDBControl(fakeClient)
+		control.testURL = svc.URL
+		tc := getTidbCluster()
+		result, err := control.GetStatus(tc, 0)
+		if c.failed {
+			g.Expect(err).To(HaveOccurred(), c.caseName)
+		} else {
+			g.Expect(err).NotTo(HaveOccurred(), c.caseName)
+		}
+		g.Expect(result).To(Equal(c.expected), c.caseName)
+	}
+}
+
+func TestResignDDLOwner(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		caseName string
+		status   int
+		body     string
+		resigned bool
+		failed   bool
+	}{
+		{
+			caseName: "resign the DDL owner",
+			status:   http.StatusOK,
+			body:     `"success!"`,
+			resigned: true,
+		},
+		{
+			caseName: "not the DDL owner",
+			status:   http.StatusBadRequest,
+			body:     NotDDLOwnerError,
+		},
+		{
+			caseName: "failed",
+			status:   http.StatusInternalServerError,
+			body:     "internal error",
+			failed:   true,
+		},
+	}
+
+	for _, c := range cases {
+		svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.Method).To(Equal("POST"), "check method")
+			g.Expect(request.URL.Path).To(Equal("/ddl/owner/resign"), "check url")
+
+			w.WriteHeader(c.status)
+			w.Write([]byte(c.body))
+		})
+		defer svc.Close()
+
+		fakeClient := &fake.Clientset{}
+		control := NewDefaultTiDBControl(fakeClient)
+		control.testURL = svc.URL
+		tc := getTidbCluster()
+		resigned, err := control.ResignDDLOwner(tc, 0)
+		if c.failed {
+			g.Expect(err).To(HaveOccurred(), c.caseName)
+		} else {
+			g.Expect(err).NotTo(HaveOccurred(), c.caseName)
+		}
+		g.Expect(resigned).To(Equal(c.resigned), c.caseName)
+	}
+}
+
+func TestUpdateSettings(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		caseName string
+		status   int
+		failed   bool
+	}{
+		{
+			caseName: "update settings",
+			status:   http.StatusOK,
+		},
+		{
+			caseName: "failed",
+			status:   http.StatusBadRequest,
+			failed:   true,
+		},
+	}
+
+	for _, c := range cases {
+		svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.Method).To(Equal("POST"), "check method")
+			g.Expect(request.URL.Path).To(Equal("/settings"), "check url")
+			g.Expect(request.ParseForm()).To(Succeed())
+			g.Expect(request.PostForm.Get("log_level")).To(Equal("warn"))
+
+			w.WriteHeader(c.status)
+		})
+		defer svc.Close()
+
+		fakeClient := &fake.Clientset{}
+		control := NewDefaultTiDBControl(fakeClient)
+		control.testURL = svc.URL
+		tc := getTidbCluster()
+		err := control.UpdateSettings(tc, 0, map[string]string{"log_level": "warn"})
+		if c.failed {
+			g.Expect(err).To(HaveOccurred(), c.caseName)
+		} else {
+			g.Expect(err).NotTo(HaveOccurred(), c.caseName)
+		}
+	}
+}
+
 func TestGetHTTPClient(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -14,6 +14,8 @@
 package tidbcluster
 
 import (
+	"time"
+
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
 	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
@@ -21,6 +23,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/manager"
 	"github.com/pingcap/tidb-operator/pkg/manager/member"
 	"github.com/pingcap/tidb-operator/pkg/metrics"
+	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
@@ -51,6 +54,7 @@ func NewDefaultTidbClusterControl(
 	pumpMemberManager manager.Manager,
 	tiflashMemberManager manager.Manager,
 	ticdcMemberManager manager.Manager,
+	nodeDrainManager manager.Manager,
 	discoveryManager member.TidbDiscoveryManager,
 	tidbClusterStatusManager manager.Manager,
 	conditionUpdater TidbClusterConditionUpdater,
@@ -68,6 +72,7 @@ func NewDefaultTidbClusterControl(
 		pumpMemberManager:        pumpMemberManager,
 		tiflashMemberManager:     tiflashMemberManager,
 		ticdcMemberManager:       ticdcMemberManager,
+		nodeDrainManager:         nodeDrainManager,
 		discoveryManager:         discoveryManager,
 		tidbClusterStatusManager: tidbClusterStatusManager,
 		conditionUpdater:         conditionUpdater,
@@ -88,6 +93,7 @@ type defaultTidbClusterControl struct {
 	pumpMemberManager        manager.Manager
 	tiflashMemberManager     manager.Manager
 	ticdcMemberManager       manager.Manager
+	nodeDrainManager         manager.Manager
 	discoveryManager         member.TidbDiscoveryManager
 	tidbClusterStatusManager manager.Manager
 	conditionUpdater         TidbClusterConditionUpdater
@@ -139,6 +145,12 @@ func (c *defaultTidbClusterControl) defaulting(tc *v1alpha1.TidbCluster) {
 
 func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster) error {
 	c.recordMetrics(tc)
+	// refreshing the maintenance windows status, disruptive operations out of
+	// maintenance windows will be recorded as pending by the member managers
+	if err := member.SyncMaintenanceStatus(tc, time.Now()); err != nil {
+		return err
+	}
+
 	// syncing all PVs managed by operator's reclaim policy to Retain
 	if err := c.reclaimPolicyManager.Sync(tc); err != nil {
 		return err
@@ -226,6 +238,14 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// works that should be done to make the pd and tikv pods on draining nodes safe to be evicted:
+	//   - transfer pd leader away from the draining nodes
+	//   - evict region leaders from the tikv stores on the draining nodes
+	//   - end the eviction after the tikv pods are rescheduled
+	if err := c.nodeDrainManager.Sync(tc); err != nil {
+		return err
+	}
+
 	// syncing the labels from Pod to PVC and PV, these labels include:
 	//   - label.StoreIDLabelKey
 	//   - label.MemberIDLabelKey
@@ -276,6 +296,35 @@ func (c *defaultTidbClusterControl) recordMetrics(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.Pump != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "pump").Set(float64(tc.Spec.Pump.Replicas))
 	}
+
+	// the status of the last round, the progress of upgrading is updated replicas out of the status replicas
+	recordStatefulSetMetrics(ns, tcName, "pd", tc.Status.PD.StatefulSet)
+	recordStatefulSetMetrics(ns, tcName, "tikv", tc.Status.TiKV.StatefulSet)
+	recordStatefulSetMetrics(ns, tcName, "tidb", tc.Status.TiDB.StatefulSet)
+	recordStatefulSetMetrics(ns, tcName, "tiflash", tc.Status.TiFlash.StatefulSet)
+	recordStatefulSetMetrics(ns, tcName, "ticdc", tc.Status.TiCDC.StatefulSet)
+	recordStatefulSetMetrics(ns, tcName, "pump", tc.Status.Pump.StatefulSet)
+
+	if tc.Spec.PD != nil {
+		metrics.ClusterFailureMembers.WithLabelValues(ns, tcName, "pd").Set(float64(len(tc.Status.PD.FailureMembers)))
+	}
+	if tc.Spec.TiKV != nil {
+		metrics.ClusterFailureMembers.WithLabelValues(ns, tcName, "tikv").Set(float64(len(tc.Status.TiKV.FailureStores)))
+	}
+	if tc.Spec.TiDB != nil {
+		metrics.ClusterFailureMembers.WithLabelValues(ns, tcName, "tidb").Set(float64(len(tc.Status.TiDB.FailureMembers)))
+	}
+	if tc.Spec.TiFlash != nil {
+		metrics.ClusterFailureMembers.WithLabelValues(ns, tcName, "tiflash").Set(float64(len(tc.Status.TiFlash.FailureStores)))
+	}
+}
+
+func recordStatefulSetMetrics(ns, tcName, component string, status *apps.StatefulSetStatus) {
+	if status == nil {
+		return
+	}
+	metrics.ClusterStatusReplicas.WithLabelValues(ns, tcName, component).Set(float64(status.Replicas))
+	metrics.ClusterUpdatedReplicas.WithLabelValues(ns, tcName, component).Set(float64(status.UpdatedReplicas))
 }
 
 var _ ControlInterface = &defaultTidbClusterControl{}
@@ -319,6 +319,7 @@ func newFakeTidbClusterControl() (
 	pumpMemberManager := mm.NewFakePumpMemberManager()
 	tiflashMemberManager := mm.NewFakeTiFlashMemberManager()
 	ticdcMemberManager := mm.NewFakeTiCDCMemberManager()
+	nodeDrainManager := mm.NewFakeNodeDrainManager()
 	discoveryManager := mm.NewFakeDiscoveryManger()
 	statusManager := mm.NewFakeTidbClusterStatusManager()
 	pvcResizer := mm.NewFakePVCResizer()
@@ -335,6 +336,7 @@ func newFakeTidbClusterControl() (
 		pumpMemberManager,
 		tiflashMemberManager,
 		ticdcMemberManager,
+		nodeDrainManager,
 		discoveryManager,
 		statusManager,
 		&tidbClusterConditionUpdater{},
@@ -18,11 +18,15 @@ import (
 	"time"
 
 	perrors "github.com/pingcap/errors"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
 	mm "github.com/pingcap/tidb-operator/pkg/manager/member"
 	"github.com/pingcap/tidb-operator/pkg/manager/meta"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -59,6 +63,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 			mm.NewPumpMemberManager(deps, mm.NewPumpScaler(deps)),
 			mm.NewTiFlashMemberManager(deps, mm.NewTiFlashFailover(deps), mm.NewTiFlashScaler(deps), mm.NewTiFlashUpgrader(deps)),
 			mm.NewTiCDCMemberManager(deps, mm.NewTiCDCScaler(deps), mm.NewTiCDCUpgrader(deps)),
+			mm.NewNodeDrainManager(deps),
 			mm.NewTidbDiscoveryManager(deps),
 			mm.NewTidbClusterStatusManager(deps),
 			&tidbClusterConditionUpdater{},
@@ -77,7 +82,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 		UpdateFunc: func(old, cur interface{}) {
 			c.enqueueTidbCluster(cur)
 		},
-		DeleteFunc: c.enqueueTidbCluster,
+		DeleteFunc: c.deleteTidbCluster,
 	})
 	statefulsetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: c.addStatefulSet,
@@ -86,7 +91,24 @@ func NewController(deps *controller.Dependencies) *Controller {
 		},
 		DeleteFunc: c.deleteStatefulSet,
 	})
+	if deps.NodeLister != nil && features.DefaultFeatureGate.Enabled(features.NodeDrainAwareness) {
+		deps.KubeInformerFactory.Core().V1().Nodes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: c.updateNode,
+		})
+	}
 
+	controller.RequeueOnRebalance(deps.Sharder, tidbClusterInformer.Informer(), c.queue)
+	deps.Sharder.AddRebalanceHandler(func() {
+		// the metrics of the clusters reassigned to other replicas are exported by their new owners
+		for _, key := range tidbClusterInformer.Informer().GetStore().ListKeys() {
+			if deps.Sharder.Owns(key) {
+				continue
+			}
+			if ns, name, err := cache.SplitMetaNamespaceKey(key); err == nil {
+				metrics.DeleteClusterMetrics(ns, name)
+			}
+		}
+	})
 	return c
 }
 
@@ -119,7 +141,14 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	if !c.deps.Sharder.Owns(key.(string)) {
+		c.queue.Forget(key)
+		return true
+	}
+	startTime := time.Now()
+	err := c.sync(key.(string))
+	controller.ObserveReconcile("tidbcluster", key.(string), startTime, err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("TidbCluster: %v, still need sync: %v, requeuing", key.(string), err)
 		} else {
@@ -169,6 +198,22 @@ func (c *Controller) enqueueTidbCluster(obj interface{}) {
 	c.queue.Add(key)
 }
 
+// deleteTidbCluster deletes the metrics of the tidbcluster and enqueues it accounting for deletion tombstones.
+func (c *Controller) deleteTidbCluster(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("Cound't get key for object %+v: %v", obj, err))
+		return
+	}
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("Cound't split key %s: %v", key, err))
+		return
+	}
+	metrics.DeleteClusterMetrics(ns, name)
+	c.queue.Add(key)
+}
+
 // addStatefulSet adds the tidbcluster for the statefulset to the sync queue
 func (c *Controller) addStatefulSet(obj interface{}) {
 	set := obj.(*apps.StatefulSet)
@@ -244,6 +289,45 @@ func (c *Controller) deleteStatefulSet(obj interface{}) {
 	c.enqueueTidbCluster(tc)
 }
 
+// updateNode enqueues the tidbclusters which have pods on the node when the node starts or stops draining.
+func (c *Controller) updateNode(old, cur interface{}) {
+	curNode := cur.(*corev1.Node)
+	oldNode := old.(*corev1.Node)
+	if curNode.ResourceVersion == oldNode.ResourceVersion {
+		return
+	}
+	taintKeys := c.deps.CLIConfig.GetNodeDrainTaintKeys()
+	if mm.IsNodeDraining(curNode, taintKeys) == mm.IsNodeDraining(oldNode, taintKeys) {
+		return
+	}
+
+	selector, err := label.New().Selector()
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	pods, err := c.deps.PodLister.List(selector)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list pods on node %s, error: %v", curNode.Name, err))
+		return
+	}
+	keys := map[string]struct{}{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != curNode.Name {
+			continue
+		}
+		instance := pod.Labels[label.InstanceLabelKey]
+		if instance == "" {
+			continue
+		}
+		keys[fmt.Sprintf("%s/%s", pod.Namespace, instance)] = struct{}{}
+	}
+	for key := range keys {
+		klog.V(4).Infof("Node %s draining state changed, TidbCluster: %s", curNode.Name, key)
+		c.queue.Add(key)
+	}
+}
+
 // resolveTidbClusterFromSet returns the TidbCluster by a StatefulSet,
 // or nil if the StatefulSet could not be resolved to a matching TidbCluster
 // of the correct Kind.
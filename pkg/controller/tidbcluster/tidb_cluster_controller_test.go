@@ -22,6 +22,8 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -47,6 +49,63 @@ func TestTidbClusterControllerEnqueueTidbClusterFailed(t *testing.T) {
 	g.Expect(tcc.queue.Len()).To(Equal(0))
 }
 
+func TestTidbClusterControllerDeleteTidbCluster(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbCluster()
+	tcc := NewController(controller.NewFakeDependencies())
+	tcc.control = NewFakeTidbClusterControlInterface()
+
+	metrics.ClusterSpecReplicas.WithLabelValues(tc.Namespace, tc.Name, "pd").Set(3)
+	metrics.ClusterScaleTotal.WithLabelValues(tc.Namespace, tc.Name, "tikv", metrics.ScaleOut).Inc()
+	metrics.ClusterSpecReplicas.WithLabelValues(tc.Namespace, "other", "pd").Set(3)
+	defer metrics.DeleteClusterMetrics(tc.Namespace, "other")
+
+	tcc.deleteTidbCluster(cache.DeletedFinalStateUnknown{Key: tc.Namespace + "/" + tc.Name, Obj: tc})
+	g.Expect(tcc.queue.Len()).To(Equal(1))
+	g.Expect(testutil.CollectAndCount(metrics.ClusterSpecReplicas)).To(Equal(1))
+	g.Expect(testutil.CollectAndCount(metrics.ClusterScaleTotal)).To(Equal(0))
+}
+
+type fakeSharder struct {
+	owned    map[string]bool
+	handlers []func()
+}
+
+func (s *fakeSharder) Owns(key string) bool {
+	return s.owned[key]
+}
+
+func (s *fakeSharder) AddRebalanceHandler(handler func()) {
+	s.handlers = append(s.handlers, handler)
+}
+
+func TestTidbClusterControllerRebalance(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fakeDeps := controller.NewFakeDependencies()
+	sharder := &fakeSharder{owned: map[string]bool{}}
+	fakeDeps.Sharder = sharder
+	tcc := NewController(fakeDeps)
+	tcc.control = NewFakeTidbClusterControlInterface()
+
+	tcIndexer := fakeDeps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	for _, name := range []string{"owned", "reassigned"} {
+		tc := newTidbCluster()
+		tc.Name = name
+		g.Expect(tcIndexer.Add(tc)).To(Succeed())
+		metrics.ClusterSpecReplicas.WithLabelValues(tc.Namespace, tc.Name, "pd").Set(3)
+	}
+	defer metrics.DeleteClusterMetrics(corev1.NamespaceDefault, "owned")
+	sharder.owned[corev1.NamespaceDefault+"/owned"] = true
+
+	// the metrics of the cluster reassigned to another replica are deleted
+	for _, handler := range sharder.handlers {
+		handler()
+	}
+	g.Expect(tcc.queue.Len()).To(Equal(2))
+	g.Expect(testutil.CollectAndCount(metrics.ClusterSpecReplicas)).To(Equal(1))
+	g.Expect(testutil.ToFloat64(metrics.ClusterSpecReplicas.WithLabelValues(corev1.NamespaceDefault, "owned", "pd"))).To(Equal(float64(3)))
+}
+
 func TestTidbClusterControllerAddStatefulSet(t *testing.T) {
 	g := NewGomegaWithT(t)
 	type testcase struct {
@@ -58,6 +58,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 		return c.deps.TiDBInitializerLister.TidbInitializers(ns).Get(name)
 	}, m)
 
+	controller.RequeueOnRebalance(deps.Sharder, tidbInitializerInformer.Informer(), c.queue)
 	return c
 }
 
@@ -90,7 +91,14 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	if !c.deps.Sharder.Owns(key.(string)) {
+		c.queue.Forget(key)
+		return true
+	}
+	startTime := time.Now()
+	err := c.sync(key.(string))
+	controller.ObserveReconcile("tidbinitializer", key.(string), startTime, err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("TiDBInitializer: %v, still need sync: %v, requeuing", key.(string), err)
 		} else {
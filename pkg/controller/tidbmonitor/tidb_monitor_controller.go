@@ -55,6 +55,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 		return c.deps.TiDBMonitorLister.TidbMonitors(ns).Get(name)
 	}, nil)
 
+	controller.RequeueOnRebalance(deps.Sharder, tidbMonitorInformer.Informer(), c.queue)
 	return c
 }
 
@@ -85,7 +86,14 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	if !c.deps.Sharder.Owns(key.(string)) {
+		c.queue.Forget(key)
+		return true
+	}
+	startTime := time.Now()
+	err := c.sync(key.(string))
+	controller.ObserveReconcile("tidbmonitor", key.(string), startTime, err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("TidbMonitor: %v, still need sync: %v, requeuing", key.(string), err)
 		} else {
@@ -26,18 +26,20 @@ import (
 )
 
 var (
-	allFeatures     = sets.NewString(StableScheduling)
+	allFeatures     = sets.NewString(StableScheduling, NodeDrainAwareness, PodDisruptionBudget)
 	defaultFeatures = map[string]bool{
 		StableScheduling:    true,
 		AdvancedStatefulSet: false,
 		AutoScaling:         false,
+		NodeDrainAwareness:  false,
+		PodDisruptionBudget: false,
 	}
 	// DefaultFeatureGate is a shared global FeatureGate.
 	DefaultFeatureGate FeatureGate = NewDefaultFeatureGate()
 )
 
 const (
-	// StableScheduling controls stable scheduling of TiDB members.
+	// StableScheduling controls stable scheduling of TiDB, PD and TiKV members.
 	StableScheduling string = "StableScheduling"
 
 	// AdvancedStatefulSet controls whether to use AdvancedStatefulSet to manage pods
@@ -45,6 +47,13 @@ const (
 
 	// AutoScaling controls whether to use TidbClusterAutoScaler to auto scale-in/out pods
 	AutoScaling string = "AutoScaling"
+
+	// NodeDrainAwareness controls whether to evict TiKV region leaders and transfer PD leader
+	// away from the nodes which are cordoned or draining
+	NodeDrainAwareness string = "NodeDrainAwareness"
+
+	// PodDisruptionBudget controls whether to create and maintain PodDisruptionBudgets for PD, TiKV and TiDB
+	PodDisruptionBudget string = "PodDisruptionBudget"
 )
 
 type FeatureGate interface {
@@ -60,7 +60,7 @@ func (f *masterFailover) Failover(dc *v1alpha1.DMCluster) error {
 		if masterMember.Health {
 			healthCount++
 		} else {
-			f.deps.Recorder.Eventf(dc, apiv1.EventTypeWarning, "MasterMemberUnhealthy",
+			f.deps.Recorder.Eventf(dc, apiv1.EventTypeWarning, DMMasterMemberUnhealthy,
 				"%s(%s) is unhealthy", podName, masterMember.ID)
 		}
 	}
@@ -92,6 +92,9 @@ func (f *masterFailover) Failover(dc *v1alpha1.DMCluster) error {
 }
 
 func (f *masterFailover) Recover(dc *v1alpha1.DMCluster) {
+	if len(dc.Status.Master.FailureMembers) > 0 {
+		f.deps.Recorder.Eventf(dc, apiv1.EventTypeNormal, FailoverRecovered, "dm-master failure members recovered, remove the %d replicas added for failover", len(dc.Status.Master.FailureMembers))
+	}
 	dc.Status.Master.FailureMembers = nil
 	klog.Infof("dm-master failover: clearing dm-master failoverMembers, %s/%s", dc.GetNamespace(), dc.GetName())
 }
@@ -130,7 +133,7 @@ func (f *masterFailover) tryToMarkAPeerAsFailure(dc *v1alpha1.DMCluster) error {
 		}
 
 		msg := fmt.Sprintf("dm-master member[%s] is unhealthy", masterMember.ID)
-		f.deps.Recorder.Event(dc, apiv1.EventTypeWarning, unHealthEventReason, fmt.Sprintf(unHealthEventMsgPattern, "dm-master", podName, msg))
+		f.deps.Recorder.Event(dc, apiv1.EventTypeWarning, Unhealthy, fmt.Sprintf(unHealthEventMsgPattern, "dm-master", podName, msg))
 
 		// mark a peer member failed and return an error to skip reconciliation
 		// note that status of dm cluster will be updated always
@@ -141,6 +144,7 @@ func (f *masterFailover) tryToMarkAPeerAsFailure(dc *v1alpha1.DMCluster) error {
 			MemberDeleted: false,
 			CreatedAt:     metav1.Now(),
 		}
+		f.deps.Recorder.Eventf(dc, apiv1.EventTypeNormal, FailoverReplicaAdded, "add a dm-master replica to replace failure member %s/%s", ns, podName)
 		return controller.RequeueErrorf("marking Pod: %s/%s dm-master member: %s as failure", ns, podName, masterMember.Name)
 	}
 
@@ -176,7 +180,7 @@ func (f *masterFailover) tryToDeleteAFailureMember(dc *v1alpha1.DMCluster) error
 		return err
 	}
 	klog.Infof("dm-master failover: delete member: [%s/%s] successfully", ns, failurePodName)
-	f.deps.Recorder.Eventf(dc, apiv1.EventTypeWarning, "DMMasterMemberDeleted",
+	f.deps.Recorder.Eventf(dc, apiv1.EventTypeWarning, DMMasterMemberDeleted,
 		"[%s/%s] deleted from dmcluster", ns, failurePodName)
 
 	// The order of old PVC deleting and the new Pod creating is not guaranteed by Kubernetes.
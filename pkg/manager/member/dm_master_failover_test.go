@@ -241,9 +241,10 @@ func TestMasterFailoverFailover(t *testing.T) {
 				g.Expect(string(failureMembers.PVCUID)).To(Equal("pvc-1-uid"))
 				g.Expect(failureMembers.MemberDeleted).To(BeFalse())
 				events := collectEvents(recorder.Events)
-				g.Expect(events).To(HaveLen(2))
+				g.Expect(events).To(HaveLen(3))
 				g.Expect(events[0]).To(ContainSubstring("test-dm-master-1(12891273174085095651) is unhealthy"))
 				g.Expect(events[1]).To(ContainSubstring("Unhealthy dm-master pod[test-dm-master-1] is unhealthy, msg:dm-master member[12891273174085095651] is unhealthy"))
+				g.Expect(events[2]).To(ContainSubstring("FailoverReplicaAdded add a dm-master replica to replace failure member default/test-dm-master-1"))
 			},
 		},
 		{
@@ -70,6 +70,7 @@ func (s *masterScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, ne
 		return fmt.Errorf("DMCluster: %s/%s's dm-master status sync failed, can't scale out now", ns, dcName)
 	}
 
+	recordScaled(s.deps.Recorder, meta, v1alpha1.DMMasterMemberType, ordinal, true)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -163,6 +164,7 @@ func (s *masterScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, new
 	klog.Infof("dm-master scale in: set pvc %s/%s annotation: %s to %s",
 		ns, pvcName, label.AnnPVCDeferDeleting, now)
 
+	recordScaled(s.deps.Recorder, meta, v1alpha1.DMMasterMemberType, ordinal, false)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
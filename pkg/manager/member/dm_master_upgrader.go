@@ -120,6 +120,7 @@ func (u *masterUpgrader) upgradeMasterPod(dc *v1alpha1.DMCluster, ordinal int32,
 		return controller.RequeueErrorf("dmcluster: [%s/%s]'s dm-master member: evicting [%s]'s leader", ns, dcName, upgradePodName)
 	}
 
+	recordUpgrading(u.deps.Recorder, dc, v1alpha1.DMMasterMemberType, newSet, ordinal)
 	setUpgradePartition(newSet, ordinal)
 	return nil
 }
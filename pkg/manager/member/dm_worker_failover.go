@@ -70,7 +70,8 @@ func (f *workerFailover) Failover(dc *v1alpha1.DMCluster) error {
 					CreatedAt: metav1.Now(),
 				}
 				msg := fmt.Sprintf("worker[%s/%s] is Offline", ns, worker.Name)
-				f.deps.Recorder.Event(dc, corev1.EventTypeWarning, unHealthEventReason, fmt.Sprintf(unHealthEventMsgPattern, "worker", podName, msg))
+				f.deps.Recorder.Event(dc, corev1.EventTypeWarning, Unhealthy, fmt.Sprintf(unHealthEventMsgPattern, "worker", podName, msg))
+				f.deps.Recorder.Eventf(dc, corev1.EventTypeNormal, FailoverReplicaAdded, "add a dm-worker replica to replace failure member %s/%s", ns, podName)
 			}
 		}
 	}
@@ -79,6 +80,9 @@ func (f *workerFailover) Failover(dc *v1alpha1.DMCluster) error {
 }
 
 func (f *workerFailover) Recover(dc *v1alpha1.DMCluster) {
+	if len(dc.Status.Worker.FailureMembers) > 0 {
+		f.deps.Recorder.Eventf(dc, corev1.EventTypeNormal, FailoverRecovered, "dm-worker failure members recovered, remove the %d replicas added for failover", len(dc.Status.Worker.FailureMembers))
+	}
 	dc.Status.Worker.FailureMembers = nil
 	klog.Infof("dm-worker recover: clear FailureWorkers, %s/%s", dc.GetNamespace(), dc.GetName())
 }
@@ -70,6 +70,7 @@ func (s *workerScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, ne
 		return fmt.Errorf("DMCluster: %s/%s's dm-worker status sync failed, can't scale out now", ns, dcName)
 	}
 
+	recordScaled(s.deps.Recorder, meta, v1alpha1.DMWorkerMemberType, ordinal, true)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -122,6 +123,7 @@ func (s *workerScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, new
 	klog.Infof("dm-worker scale in: set pvc %s/%s annotation: %s to %s",
 		ns, pvcName, label.AnnPVCDeferDeleting, now)
 
+	recordScaled(s.deps.Recorder, meta, v1alpha1.DMWorkerMemberType, ordinal, false)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -0,0 +1,397 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// ObjectAction is the action a sync would take on an object
+type ObjectAction string
+
+const (
+	// ObjectActionCreate means the object does not exist yet and will be created
+	ObjectActionCreate ObjectAction = "Create"
+	// ObjectActionUpdate means the object exists and will be updated
+	ObjectActionUpdate ObjectAction = "Update"
+)
+
+// FieldChange is a single changed field of an object, Old or New is nil if
+// the field is added or removed
+type FieldChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// ObjectDiff describes how an object rendered for the proposed TidbCluster
+// differs from the live one
+type ObjectDiff struct {
+	Name    string        `json:"name"`
+	Action  ObjectAction  `json:"action"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// ComponentDiff is the ConfigMap and StatefulSet changes of one component,
+// an unchanged object is nil
+type ComponentDiff struct {
+	Component   v1alpha1.MemberType `json:"component"`
+	ConfigMap   *ObjectDiff         `json:"configMap,omitempty"`
+	StatefulSet *ObjectDiff         `json:"statefulSet,omitempty"`
+	// RollingRestart is true if the pod template changes, the upgrader will
+	// then restart the pods of the component one by one
+	RollingRestart bool `json:"rollingRestart"`
+	// OnlineItems are the config items that would be applied online with
+	// EnableDynamicConfiguration
+	OnlineItems []string `json:"onlineItems,omitempty"`
+}
+
+type componentBuilder struct {
+	memberType  v1alpha1.MemberType
+	memberName  func(tcName string) string
+	enabled     func(tc *v1alpha1.TidbCluster) bool
+	configMap   func(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error)
+	statefulSet func(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.StatefulSet, error)
+	strategy    func(tc *v1alpha1.TidbCluster) v1alpha1.ConfigUpdateStrategy
+	// online is set if the component applies config online with EnableDynamicConfiguration
+	online func(key string) bool
+}
+
+// componentBuilders mirror the ConfigMap and StatefulSet sync of the member
+// managers, including the conditions under which a ConfigMap is synced
+var componentBuilders = []componentBuilder{
+	{
+		memberType: v1alpha1.PDMemberType,
+		memberName: controller.PDMemberName,
+		enabled:    func(tc *v1alpha1.TidbCluster) bool { return tc.Spec.PD != nil },
+		configMap: func(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+			if tc.Spec.PD.Config == nil {
+				return nil, nil
+			}
+			return getPDConfigMap(tc)
+		},
+		statefulSet: getNewPDSetForTidbCluster,
+		strategy: func(tc *v1alpha1.TidbCluster) v1alpha1.ConfigUpdateStrategy {
+			return tc.BasePDSpec().ConfigUpdateStrategy()
+		},
+		online: isPDOnlineConfigItem,
+	},
+	{
+		memberType: v1alpha1.TiKVMemberType,
+		memberName: controller.TiKVMemberName,
+		enabled:    func(tc *v1alpha1.TidbCluster) bool { return tc.Spec.TiKV != nil },
+		configMap: func(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+			if tc.Spec.TiKV.Config == nil {
+				return nil, nil
+			}
+			return getTikVConfigMap(tc)
+		},
+		statefulSet: getNewTiKVSetForTidbCluster,
+		strategy: func(tc *v1alpha1.TidbCluster) v1alpha1.ConfigUpdateStrategy {
+			return tc.BaseTiKVSpec().ConfigUpdateStrategy()
+		},
+		online: isTiKVOnlineConfigItem,
+	},
+	{
+		memberType:  v1alpha1.TiFlashMemberType,
+		memberName:  controller.TiFlashMemberName,
+		enabled:     func(tc *v1alpha1.TidbCluster) bool { return tc.Spec.TiFlash != nil },
+		configMap:   getTiFlashConfigMap,
+		statefulSet: getNewStatefulSet,
+		strategy: func(tc *v1alpha1.TidbCluster) v1alpha1.ConfigUpdateStrategy {
+			return tc.BaseTiFlashSpec().ConfigUpdateStrategy()
+		},
+	},
+	{
+		memberType:  v1alpha1.PumpMemberType,
+		memberName:  controller.PumpMemberName,
+		enabled:     func(tc *v1alpha1.TidbCluster) bool { return tc.Spec.Pump != nil },
+		configMap:   getNewPumpConfigMap,
+		statefulSet: getNewPumpStatefulSet,
+		strategy: func(tc *v1alpha1.TidbCluster) v1alpha1.ConfigUpdateStrategy {
+			return tc.BasePumpSpec().ConfigUpdateStrategy()
+		},
+	},
+	{
+		memberType: v1alpha1.TiDBMemberType,
+		memberName: controller.TiDBMemberName,
+		enabled:    func(tc *v1alpha1.TidbCluster) bool { return tc.Spec.TiDB != nil },
+		configMap: func(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+			if tc.Spec.TiDB.Config == nil {
+				return nil, nil
+			}
+			return getTiDBConfigMap(tc)
+		},
+		statefulSet: getNewTiDBSetForTidbCluster,
+		strategy: func(tc *v1alpha1.TidbCluster) v1alpha1.ConfigUpdateStrategy {
+			return tc.BaseTiDBSpec().ConfigUpdateStrategy()
+		},
+		online: isTiDBOnlineConfigItem,
+	},
+	{
+		memberType: v1alpha1.TiCDCMemberType,
+		memberName: controller.TiCDCMemberName,
+		enabled:    func(tc *v1alpha1.TidbCluster) bool { return tc.Spec.TiCDC != nil },
+		configMap: func(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+			if tc.Spec.TiCDC.Config == nil || tc.Spec.TiCDC.Config.OnlyOldItems() {
+				return nil, nil
+			}
+			return getTiCDCConfigMap(tc)
+		},
+		statefulSet: getNewTiCDCStatefulSet,
+		strategy: func(tc *v1alpha1.TidbCluster) v1alpha1.ConfigUpdateStrategy {
+			return tc.BaseTiCDCSpec().ConfigUpdateStrategy()
+		},
+	},
+}
+
+// DiffTidbCluster runs the ConfigMap and StatefulSet builders of the member
+// managers against the proposed TidbCluster, and returns the changes a sync of
+// it would make to the live objects in setLister and cmLister. Like the member
+// managers, the spec of a live StatefulSet is compared by its last applied
+// configuration. Only changed components are returned.
+func DiffTidbCluster(proposed *v1alpha1.TidbCluster, setLister appslisters.StatefulSetLister, cmLister corelisters.ConfigMapLister) ([]ComponentDiff, error) {
+	if proposed == nil {
+		return nil, fmt.Errorf("proposed TidbCluster is nil")
+	}
+	proposed = proposed.DeepCopy()
+	defaulting.SetTidbClusterDefault(proposed)
+	ns := proposed.Namespace
+
+	var diffs []ComponentDiff
+	for _, b := range componentBuilders {
+		if !b.enabled(proposed) {
+			continue
+		}
+
+		setName := b.memberName(proposed.Name)
+		liveSet, err := setLister.StatefulSets(ns).Get(setName)
+		if errors.IsNotFound(err) {
+			liveSet = nil
+		} else if err != nil {
+			return nil, fmt.Errorf("get statefulset %s/%s failed: %v", ns, setName, err)
+		}
+		var inUseName string
+		if liveSet != nil {
+			inUseName = FindConfigMapVolume(&liveSet.Spec.Template.Spec, func(name string) bool {
+				return strings.HasPrefix(name, setName)
+			})
+		}
+
+		newCm, newSet, onlineItems, err := b.render(proposed, cmLister, inUseName)
+		if err != nil {
+			return nil, fmt.Errorf("render %s of tidbcluster %s/%s failed: %v", b.memberType, ns, proposed.Name, err)
+		}
+		if liveSet != nil && b.memberType == v1alpha1.TiDBMemberType {
+			if err := keepPickedDeleteSlots(liveSet, newSet); err != nil {
+				return nil, err
+			}
+		}
+
+		diff := ComponentDiff{Component: b.memberType, OnlineItems: onlineItems}
+		if newCm != nil {
+			liveCm, err := cmLister.ConfigMaps(ns).Get(newCm.Name)
+			if errors.IsNotFound(err) {
+				liveCm = nil
+			} else if err != nil {
+				return nil, fmt.Errorf("get configmap %s/%s failed: %v", ns, newCm.Name, err)
+			}
+			if diff.ConfigMap, err = diffConfigMap(liveCm, newCm); err != nil {
+				return nil, err
+			}
+		}
+		if diff.StatefulSet, err = diffStatefulSet(liveSet, newSet); err != nil {
+			return nil, err
+		}
+		if liveSet != nil {
+			// decide the same way as the member managers do, by comparing
+			// against the last applied pod template
+			diff.RollingRestart = !templateEqual(newSet, liveSet)
+		}
+		if diff.ConfigMap != nil || diff.StatefulSet != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, nil
+}
+
+// render builds the ConfigMap and StatefulSet of the component, inUseName is the
+// ConfigMap currently mounted by the live StatefulSet, if any. It also returns the
+// config items that would be applied online.
+func (b *componentBuilder) render(tc *v1alpha1.TidbCluster, cmLister corelisters.ConfigMapLister, inUseName string) (*corev1.ConfigMap, *apps.StatefulSet, []string, error) {
+	cm, err := b.configMap(tc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var onlineItems []string
+	if cm != nil {
+		if b.online != nil && tc.IsDynamicConfigurationEnabled() {
+			noop := func(map[string]interface{}) error { return nil }
+			onlineItems, err = updateConfigMapOnline(cmLister, b.strategy(tc), inUseName, cm, b.online, noop)
+		} else {
+			err = updateConfigMapIfNeed(cmLister, b.strategy(tc), inUseName, cm)
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	set, err := b.statefulSet(tc, cm)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cm, set, onlineItems, nil
+}
+
+// diffConfigMap compares the labels and data of the ConfigMaps, the config
+// files equal in toml are not changed
+func diffConfigMap(live, new *corev1.ConfigMap) (*ObjectDiff, error) {
+	if live == nil {
+		return &ObjectDiff{Name: new.Name, Action: ObjectActionCreate}, nil
+	}
+	new = new.DeepCopy()
+	if _, err := updateConfigMap(live, new); err != nil {
+		return nil, err
+	}
+	project := func(cm *corev1.ConfigMap) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Labels: cm.Labels},
+			Data:       cm.Data,
+		}
+	}
+	return diffObject(new.Name, project(live), project(new))
+}
+
+// diffStatefulSet compares the labels, annotations and the fields of the
+// StatefulSets synced by UpdateStatefulSet
+func diffStatefulSet(live, new *apps.StatefulSet) (*ObjectDiff, error) {
+	if live == nil {
+		return &ObjectDiff{Name: new.Name, Action: ObjectActionCreate}, nil
+	}
+	liveSpec, _, err := GetLastAppliedConfig(live)
+	if err != nil {
+		// a StatefulSet not created by the operator, compare the live spec
+		liveSpec = live.Spec.DeepCopy()
+	}
+	liveSet := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Labels: live.Labels, Annotations: map[string]string{}},
+		Spec:       *liveSpec,
+	}
+	for k, v := range live.Annotations {
+		if k != LastAppliedConfigAnnotation && k != label.AnnStsLastSyncTimestamp {
+			liveSet.Annotations[k] = v
+		}
+	}
+	liveSet.Spec.Template = *liveSet.Spec.Template.DeepCopy()
+	delete(liveSet.Spec.Template.Annotations, LastAppliedConfigAnnotation)
+	project := func(set *apps.StatefulSet) *apps.StatefulSet {
+		p := &apps.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Labels: set.Labels, Annotations: set.Annotations},
+		}
+		p.Spec.Replicas = set.Spec.Replicas
+		p.Spec.UpdateStrategy = set.Spec.UpdateStrategy
+		p.Spec.Template = set.Spec.Template
+		if len(p.Annotations) == 0 {
+			p.Annotations = nil
+		}
+		return p
+	}
+	return diffObject(new.Name, project(liveSet), project(new))
+}
+
+// diffObject returns nil if old and new are equal
+func diffObject(name string, old, new runtime.Object) (*ObjectDiff, error) {
+	oldObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(old)
+	if err != nil {
+		return nil, err
+	}
+	newObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(new)
+	if err != nil {
+		return nil, err
+	}
+	r := &fieldChangeReporter{}
+	if cmp.Equal(oldObj, newObj, cmp.Reporter(r)) {
+		return nil, nil
+	}
+	return &ObjectDiff{Name: name, Action: ObjectActionUpdate, Changes: r.changes}, nil
+}
+
+// fieldChangeReporter collects the unequal leaves reported by cmp.Equal
+type fieldChangeReporter struct {
+	path    cmp.Path
+	changes []FieldChange
+}
+
+func (r *fieldChangeReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *fieldChangeReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *fieldChangeReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	change := FieldChange{Path: fieldPath(r.path)}
+	vx, vy := r.path.Last().Values()
+	if vx.IsValid() {
+		change.Old = vx.Interface()
+	}
+	if vy.IsValid() {
+		change.New = vy.Interface()
+	}
+	r.changes = append(r.changes, change)
+}
+
+// fieldPath formats a path into unstructured content like
+// spec.template.spec.containers[0].image
+func fieldPath(path cmp.Path) string {
+	var sb strings.Builder
+	for _, step := range path {
+		switch s := step.(type) {
+		case cmp.MapIndex:
+			key := fmt.Sprint(s.Key().Interface())
+			if strings.ContainsAny(key, "./") {
+				fmt.Fprintf(&sb, "[%s]", key)
+				continue
+			}
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(key)
+		case cmp.SliceIndex:
+			ix, iy := s.SplitKeys()
+			if iy < 0 {
+				iy = ix
+			}
+			fmt.Fprintf(&sb, "[%d]", iy)
+		}
+	}
+	return sb.String()
+}
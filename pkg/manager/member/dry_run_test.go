@@ -0,0 +1,279 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newTidbClusterForDryRun() *v1alpha1.TidbCluster {
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "demo",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "uid",
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			Version: "v4.0.8",
+			PD: &v1alpha1.PDSpec{
+				Replicas: 3,
+				Config:   v1alpha1.NewPDConfig(),
+			},
+			TiKV: &v1alpha1.TiKVSpec{
+				Replicas: 3,
+				Config:   v1alpha1.NewTiKVConfig(),
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("10Gi"),
+					},
+				},
+			},
+		},
+	}
+	tc.Spec.TiKV.Config.Set("log-level", "info")
+	return tc
+}
+
+// applyTidbClusterForDryRun creates the ConfigMaps and StatefulSets of the
+// TidbCluster like the member managers do, with the fields set by the apiserver
+func applyTidbClusterForDryRun(g *GomegaWithT, tc *v1alpha1.TidbCluster, setIndexer, cmIndexer cache.Indexer) {
+	tc = tc.DeepCopy()
+	defaulting.SetTidbClusterDefault(tc)
+	empty := corelisters.NewConfigMapLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}))
+	for _, b := range componentBuilders {
+		if !b.enabled(tc) {
+			continue
+		}
+		cm, set, _, err := b.render(tc, empty, "")
+		g.Expect(err).NotTo(HaveOccurred())
+		if cm != nil {
+			cm.ResourceVersion = "1"
+			cm.UID = "cm-uid"
+			g.Expect(cmIndexer.Add(cm)).To(Succeed())
+		}
+		g.Expect(SetStatefulSetLastAppliedConfigAnnotation(set)).To(Succeed())
+		set.ResourceVersion = "1"
+		set.UID = "set-uid"
+		set.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+		set.Status = apps.StatefulSetStatus{Replicas: *set.Spec.Replicas, ObservedGeneration: 1}
+		g.Expect(setIndexer.Add(set)).To(Succeed())
+	}
+}
+
+func TestDiffTidbCluster(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name     string
+		current  func() *v1alpha1.TidbCluster
+		proposed func(tc *v1alpha1.TidbCluster)
+		// live changes the live objects after the current TidbCluster is applied
+		live     func(setIndexer, cmIndexer cache.Indexer)
+		expectFn func(diffs []ComponentDiff)
+	}
+
+	tests := []testcase{
+		{
+			name:     "no changes",
+			current:  newTidbClusterForDryRun,
+			proposed: func(tc *v1alpha1.TidbCluster) {},
+			expectFn: func(diffs []ComponentDiff) {
+				g.Expect(diffs).To(BeEmpty())
+			},
+		},
+		{
+			name:     "tidbcluster does not exist",
+			current:  func() *v1alpha1.TidbCluster { return nil },
+			proposed: func(tc *v1alpha1.TidbCluster) {},
+			expectFn: func(diffs []ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(2))
+				for _, diff := range diffs {
+					g.Expect(diff.ConfigMap.Action).To(Equal(ObjectActionCreate))
+					g.Expect(diff.StatefulSet.Action).To(Equal(ObjectActionCreate))
+					g.Expect(diff.RollingRestart).To(BeFalse())
+				}
+			},
+		},
+		{
+			name:    "change tikv config with in-place update",
+			current: newTidbClusterForDryRun,
+			proposed: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("log-level", "warn")
+			},
+			expectFn: func(diffs []ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(1))
+				diff := diffs[0]
+				g.Expect(diff.Component).To(Equal(v1alpha1.TiKVMemberType))
+				g.Expect(diff.StatefulSet).To(BeNil())
+				g.Expect(diff.RollingRestart).To(BeFalse())
+				g.Expect(diff.ConfigMap.Action).To(Equal(ObjectActionUpdate))
+				g.Expect(diff.ConfigMap.Name).To(Equal("demo-tikv"))
+				g.Expect(diff.ConfigMap.Changes).To(HaveLen(1))
+				g.Expect(diff.ConfigMap.Changes[0].Path).To(Equal("data.config-file"))
+				g.Expect(diff.ConfigMap.Changes[0].New).To(ContainSubstring(`log-level = "warn"`))
+			},
+		},
+		{
+			name: "change tikv config with rolling update",
+			current: func() *v1alpha1.TidbCluster {
+				tc := newTidbClusterForDryRun()
+				s := v1alpha1.ConfigUpdateStrategyRollingUpdate
+				tc.Spec.TiKV.ConfigUpdateStrategy = &s
+				return tc
+			},
+			proposed: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("log-level", "warn")
+			},
+			expectFn: func(diffs []ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(1))
+				diff := diffs[0]
+				g.Expect(diff.Component).To(Equal(v1alpha1.TiKVMemberType))
+				g.Expect(diff.RollingRestart).To(BeTrue())
+				g.Expect(diff.ConfigMap.Name).To(HavePrefix("demo-tikv-"))
+				g.Expect(diff.StatefulSet.Action).To(Equal(ObjectActionUpdate))
+				var paths []string
+				for _, c := range diff.StatefulSet.Changes {
+					paths = append(paths, c.Path)
+				}
+				g.Expect(paths).To(ContainElement(MatchRegexp(`^spec\.template\.spec\.volumes\[\d+\]\.configMap\.name$`)))
+			},
+		},
+		{
+			name: "change tikv config online",
+			current: func() *v1alpha1.TidbCluster {
+				tc := newTidbClusterForDryRun()
+				enabled := true
+				tc.Spec.EnableDynamicConfiguration = &enabled
+				return tc
+			},
+			proposed: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("raftstore.sync-log", false)
+			},
+			expectFn: func(diffs []ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(1))
+				diff := diffs[0]
+				g.Expect(diff.RollingRestart).To(BeFalse())
+				g.Expect(diff.StatefulSet).To(BeNil())
+				g.Expect(diff.OnlineItems).To(Equal([]string{"raftstore.sync-log"}))
+				g.Expect(diff.ConfigMap.Action).To(Equal(ObjectActionUpdate))
+			},
+		},
+		{
+			name:    "change tikv resources",
+			current: newTidbClusterForDryRun,
+			proposed: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Requests[corev1.ResourceCPU] = resource.MustParse("2")
+			},
+			expectFn: func(diffs []ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(1))
+				diff := diffs[0]
+				g.Expect(diff.ConfigMap).To(BeNil())
+				g.Expect(diff.RollingRestart).To(BeTrue())
+				g.Expect(diff.StatefulSet.Changes).To(ContainElement(FieldChange{
+					Path: "spec.template.spec.containers[0].resources.requests",
+					New:  map[string]interface{}{"cpu": "2"},
+				}))
+			},
+		},
+		{
+			name:    "scale out tikv",
+			current: newTidbClusterForDryRun,
+			proposed: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Replicas = 4
+			},
+			expectFn: func(diffs []ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(1))
+				diff := diffs[0]
+				g.Expect(diff.RollingRestart).To(BeFalse())
+				g.Expect(diff.StatefulSet.Changes).To(Equal([]FieldChange{
+					{Path: "spec.replicas", Old: int64(3), New: int64(4)},
+					{Path: "spec.updateStrategy.rollingUpdate.partition", Old: int64(3), New: int64(4)},
+				}))
+			},
+		},
+		{
+			name:     "live configmap changed out of band",
+			current:  newTidbClusterForDryRun,
+			proposed: func(tc *v1alpha1.TidbCluster) {},
+			live: func(setIndexer, cmIndexer cache.Indexer) {
+				obj, exists, err := cmIndexer.GetByKey("default/demo-tikv")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(exists).To(BeTrue())
+				cm := obj.(*corev1.ConfigMap).DeepCopy()
+				cm.Data["config-file"] = "log-level = \"debug\"\n"
+				g.Expect(cmIndexer.Update(cm)).To(Succeed())
+			},
+			expectFn: func(diffs []ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(1))
+				diff := diffs[0]
+				g.Expect(diff.Component).To(Equal(v1alpha1.TiKVMemberType))
+				g.Expect(diff.StatefulSet).To(BeNil())
+				g.Expect(diff.ConfigMap.Action).To(Equal(ObjectActionUpdate))
+				g.Expect(diff.ConfigMap.Changes).To(HaveLen(1))
+				g.Expect(diff.ConfigMap.Changes[0].Old).To(Equal("log-level = \"debug\"\n"))
+			},
+		},
+		{
+			name:     "live statefulset deleted",
+			current:  newTidbClusterForDryRun,
+			proposed: func(tc *v1alpha1.TidbCluster) {},
+			live: func(setIndexer, cmIndexer cache.Indexer) {
+				obj, exists, err := setIndexer.GetByKey("default/demo-pd")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(exists).To(BeTrue())
+				g.Expect(setIndexer.Delete(obj)).To(Succeed())
+			},
+			expectFn: func(diffs []ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(1))
+				diff := diffs[0]
+				g.Expect(diff.Component).To(Equal(v1alpha1.PDMemberType))
+				g.Expect(diff.ConfigMap).To(BeNil())
+				g.Expect(diff.StatefulSet.Action).To(Equal(ObjectActionCreate))
+				g.Expect(diff.RollingRestart).To(BeFalse())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		current := test.current()
+		proposed := newTidbClusterForDryRun()
+		if current != nil {
+			proposed = current.DeepCopy()
+		}
+		test.proposed(proposed)
+		setIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		cmIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		if current != nil {
+			applyTidbClusterForDryRun(g, current, setIndexer, cmIndexer)
+		}
+		if test.live != nil {
+			test.live(setIndexer, cmIndexer)
+		}
+		diffs, err := DiffTidbCluster(proposed, appslisters.NewStatefulSetLister(setIndexer), corelisters.NewConfigMapLister(cmIndexer))
+		g.Expect(err).NotTo(HaveOccurred())
+		test.expectFn(diffs)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+// The reasons of the events recorded on TidbCluster and DMCluster for the lifecycle actions of the members. Users and
+// alerting rules rely on them, e.g. `kubectl describe tc` or `kubectl get events --field-selector reason=...`, so
+// an existing reason must not be renamed.
+//
+// The events of a cluster are rate limited by the event correlator of the controller manager, which allows a burst
+// of --event-burst events and refills --event-qps events per second. Identical events are aggregated into one event
+// with a count.
+const (
+	// ScaledOut is a Normal event, a pod is added to a member statefulset
+	ScaledOut = "ScaledOut"
+	// ScaledIn is a Normal event, a pod is removed from a member statefulset
+	ScaledIn = "ScaledIn"
+	// FailedScaleIn is a Warning event, a member can not be scaled in because the cluster would be unavailable
+	FailedScaleIn = "FailedScaleIn"
+	// PDMemberDeleted is recorded when a PD member is deleted from the PD cluster, it's a Normal event for
+	// scaling in and a Warning event for failover
+	PDMemberDeleted = "PDMemberDeleted"
+	// StoreOffline is a Normal event, a TiKV or TiFlash store is marked offline before scaling in, and its regions
+	// are being moved to other stores
+	StoreOffline = "StoreOffline"
+	// StoreTombstone is a Normal event, a TiKV or TiFlash store being scaled in becomes tombstone
+	StoreTombstone = "StoreTombstone"
+
+	// UpgradingPod is a Normal event, a pod is being upgraded by the rolling update of a member statefulset
+	UpgradingPod = "UpgradingPod"
+	// PDLeaderTransferred is a Normal event, the PD leader is transferred away from a pod before the pod is upgraded,
+	// scaled in or drained
+	PDLeaderTransferred = "PDLeaderTransferred"
+	// EvictLeaderBegan is a Normal event, the region leaders of a TiKV store begin being evicted
+	EvictLeaderBegan = "EvictLeaderBegan"
+	// EvictLeaderEnded is a Normal event, the leader eviction of a TiKV store ends
+	EvictLeaderEnded = "EvictLeaderEnded"
+	// DDLOwnerResigned is a Normal event, a TiDB pod resigns the DDL owner before being upgraded or scaled in
+	DDLOwnerResigned = "DDLOwnerResigned"
+	// ConfigAppliedOnline is a Normal event, some config items are applied to the running members without restart
+	ConfigAppliedOnline = "ConfigAppliedOnline"
+
+	// Unhealthy is a Warning event, a member is unhealthy longer than the failover period and is taken as failed
+	Unhealthy = "Unhealthy"
+	// PDMemberUnhealthy is a Warning event, a PD member is unhealthy
+	PDMemberUnhealthy = "PDMemberUnhealthy"
+	// PDPeerMemberUnhealthy is a Warning event, a PD member of the peer cluster is unhealthy
+	PDPeerMemberUnhealthy = "PDPeerMemberUnhealthy"
+	// DMMasterMemberUnhealthy is a Warning event, a dm-master member is unhealthy
+	DMMasterMemberUnhealthy = "MasterMemberUnhealthy"
+	// DMMasterMemberDeleted is a Warning event, a failed dm-master member is deleted from the dm-master cluster
+	DMMasterMemberDeleted = "DMMasterMemberDeleted"
+	// FailoverReplicaAdded is a Normal event, a replica is added to replace a failed member
+	FailoverReplicaAdded = "FailoverReplicaAdded"
+	// FailoverRecovered is a Normal event, the failed members recover and the replicas added for failover are removed
+	FailoverRecovered = "FailoverRecovered"
+
+	// PVCResizing is a Normal event, the storage request of a PVC is increased to resize the volume
+	PVCResizing = "PVCResizing"
+	// FailedResizePVC is a Warning event, a PVC can not be resized
+	FailedResizePVC = "FailedResizePVC"
+
+	// FailedSetStoreLabels is a Warning event, the labels of a TiKV store can not be set
+	FailedSetStoreLabels = "FailedSetStoreLabels"
+)
+
+// unHealthEventMsgPattern is the message of Unhealthy events
+const unHealthEventMsgPattern = "%s pod[%s] is unhealthy, msg:%s"
+
+// recordScaled records the event and the metric of the pod added or removed by scaling on the cluster
+func recordScaled(recorder record.EventRecorder, meta metav1.Object, memberType v1alpha1.MemberType, ordinal int32, scaleOut bool) {
+	scaleType := metrics.ScaleIn
+	if scaleOut {
+		scaleType = metrics.ScaleOut
+	}
+	metrics.ClusterScaleTotal.WithLabelValues(meta.GetNamespace(), meta.GetName(), memberType.String(), scaleType).Inc()
+
+	obj, ok := meta.(runtime.Object)
+	if !ok {
+		return
+	}
+	podName := ordinalPodName(memberType, meta.GetName(), ordinal)
+	if scaleOut {
+		endOperation(meta, v1alpha1.OperationTypeScaleOut, memberType, podName, v1alpha1.OperationSucceeded, "")
+		recorder.Eventf(obj, corev1.EventTypeNormal, ScaledOut, "scale out %s, add pod %s", memberType, podName)
+		return
+	}
+	endOperation(meta, v1alpha1.OperationTypeScaleIn, memberType, podName, v1alpha1.OperationSucceeded, "")
+	recorder.Eventf(obj, corev1.EventTypeNormal, ScaledIn, "scale in %s, remove pod %s", memberType, podName)
+}
+
+// recordUpgrading records the event of the pod to be upgraded on the cluster, it's called before the partition of the
+// statefulset is decreased to the ordinal of the pod, and records nothing if the pod is being upgraded already
+func recordUpgrading(recorder record.EventRecorder, meta metav1.Object, memberType v1alpha1.MemberType, set *apps.StatefulSet, ordinal int32) {
+	obj, ok := meta.(runtime.Object)
+	if !ok {
+		return
+	}
+	if ru := set.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil && *ru.Partition <= ordinal {
+		return
+	}
+	podName := ordinalPodName(memberType, meta.GetName(), ordinal)
+	// the pods are upgraded one by one, so the upgrade of the previous pod has finished
+	endOperations(meta, v1alpha1.OperationTypeUpgrade, memberType, v1alpha1.OperationSucceeded)
+	startOperation(meta, v1alpha1.OperationTypeUpgrade, memberType, podName, "")
+	recorder.Eventf(obj, corev1.EventTypeNormal, UpgradingPod, "upgrade %s pod %s", memberType, podName)
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+)
+
+func TestRecordScaled(t *testing.T) {
+	g := NewGomegaWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	tc := newTidbClusterForPD()
+	scaledOut := metrics.ClusterScaleTotal.WithLabelValues(tc.Namespace, tc.Name, "tikv", metrics.ScaleOut)
+	scaledIn := metrics.ClusterScaleTotal.WithLabelValues(tc.Namespace, tc.Name, "tikv", metrics.ScaleIn)
+	scaledOutBefore, scaledInBefore := testutil.ToFloat64(scaledOut), testutil.ToFloat64(scaledIn)
+
+	recordScaled(recorder, tc, v1alpha1.TiKVMemberType, 3, true)
+	recordScaled(recorder, tc, v1alpha1.TiKVMemberType, 3, false)
+
+	events := collectEvents(recorder.Events)
+	g.Expect(events).To(Equal([]string{
+		"Normal ScaledOut scale out tikv, add pod test-tikv-3",
+		"Normal ScaledIn scale in tikv, remove pod test-tikv-3",
+	}))
+	g.Expect(testutil.ToFloat64(scaledOut) - scaledOutBefore).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(scaledIn) - scaledInBefore).To(Equal(float64(1)))
+}
+
+func TestRecordUpgrading(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition *int32
+		ordinal   int32
+		expect    []string
+	}{
+		{
+			name:      "partition is decreased to the ordinal",
+			partition: pointer.Int32Ptr(3),
+			ordinal:   2,
+			expect:    []string{"Normal UpgradingPod upgrade pd pod test-pd-2"},
+		},
+		{
+			name:    "no partition",
+			ordinal: 2,
+			expect:  []string{"Normal UpgradingPod upgrade pd pod test-pd-2"},
+		},
+		{
+			name:      "pod is being upgraded",
+			partition: pointer.Int32Ptr(2),
+			ordinal:   2,
+			expect:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			recorder := record.NewFakeRecorder(10)
+			tc := newTidbClusterForPD()
+			set := &apps.StatefulSet{}
+			if tt.partition != nil {
+				set.Spec.UpdateStrategy.RollingUpdate = &apps.RollingUpdateStatefulSetStrategy{Partition: tt.partition}
+			}
+
+			recordUpgrading(recorder, tc, v1alpha1.PDMemberType, set, tt.ordinal)
+
+			g.Expect(collectEvents(recorder.Events)).To(Equal(tt.expect))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/robfig/cron"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// SyncMaintenanceStatus refreshes the maintenance status of the TidbCluster at the beginning of a sync loop,
+// pending operations will be recorded again by the member managers during the sync.
+func SyncMaintenanceStatus(tc *v1alpha1.TidbCluster, now time.Time) error {
+	if len(tc.Spec.MaintenanceWindows) == 0 {
+		tc.Status.Maintenance = nil
+		return nil
+	}
+
+	inWindow, next, err := inMaintenanceWindow(tc.Spec.MaintenanceWindows, now)
+	if err != nil {
+		return fmt.Errorf("cluster %s/%s has invalid maintenance windows, err: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+	nextTime := metav1.NewTime(next)
+	tc.Status.Maintenance = &v1alpha1.MaintenanceStatus{
+		InWindow:       inWindow,
+		NextWindowTime: &nextTime,
+	}
+	return nil
+}
+
+// inMaintenanceWindow returns whether now is in any of the maintenance windows,
+// and the time when the earliest upcoming window opens.
+func inMaintenanceWindow(windows []v1alpha1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	inWindow := false
+	var next time.Time
+	for _, w := range windows {
+		sched, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			return false, next, fmt.Errorf("parse schedule %q failed, err: %v", w.Schedule, err)
+		}
+		duration, err := time.ParseDuration(w.Duration)
+		if err != nil {
+			return false, next, fmt.Errorf("parse duration %q failed, err: %v", w.Duration, err)
+		}
+
+		// the latest window which opens after `now - duration` is still open if it opens no later than now
+		if start := sched.Next(now.Add(-duration)); !start.After(now) {
+			inWindow = true
+		}
+		if start := sched.Next(now); next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return inWindow, next, nil
+}
+
+// deferToMaintenanceWindow returns true if the disruptive operation of the component must wait
+// for the next maintenance window, the operation is recorded in the status as pending in this case.
+// It should be checked only before an operation starts, so that an operation started in a window is
+// allowed to complete.
+func deferToMaintenanceWindow(meta metav1.Object, memberType v1alpha1.MemberType, op v1alpha1.MaintenanceOperationType) bool {
+	tc, ok := meta.(*v1alpha1.TidbCluster)
+	if !ok || len(tc.Spec.MaintenanceWindows) == 0 {
+		return false
+	}
+	if tc.Status.Maintenance == nil {
+		if err := SyncMaintenanceStatus(tc, time.Now()); err != nil {
+			klog.Errorf("failed to sync maintenance status, err: %v", err)
+			return false
+		}
+	}
+
+	status := tc.Status.Maintenance
+	if status.InWindow {
+		return false
+	}
+
+	pending := v1alpha1.PendingMaintenanceOperation{MemberType: memberType, Operation: op}
+	for _, p := range status.PendingOperations {
+		if p == pending {
+			return true
+		}
+	}
+	status.PendingOperations = append(status.PendingOperations, pending)
+	klog.Infof("cluster %s/%s is out of maintenance windows, %s %s is deferred to %s",
+		tc.GetNamespace(), tc.GetName(), memberType, op, status.NextWindowTime)
+	return true
+}
+
+// keepLastAppliedPodTemplate reverts the pod template of the new statefulset to the last applied one,
+// including the labels and annotations of the pods, so that the statefulset will not be rolling updated.
+func keepLastAppliedPodTemplate(oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	spec, _, err := GetLastAppliedConfig(oldSet)
+	if err != nil {
+		return err
+	}
+	newSet.Spec.Template = spec.Template
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// every Saturday 02:00 - 06:00 and every day 12:00 - 12:30
+	windows := []v1alpha1.MaintenanceWindow{
+		{Schedule: "0 2 * * 6", Duration: "4h"},
+		{Schedule: "0 12 * * *", Duration: "30m"},
+	}
+	// 2021-05-01 is a Saturday
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2021, 5, day, hour, min, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		inWindow bool
+		next     time.Time
+	}{
+		{
+			name:     "before the weekly window",
+			now:      at(1, 1, 0),
+			inWindow: false,
+			next:     at(1, 2, 0),
+		},
+		{
+			name:     "in the weekly window",
+			now:      at(1, 5, 59),
+			inWindow: true,
+			next:     at(1, 12, 0),
+		},
+		{
+			name:     "after the weekly window",
+			now:      at(1, 6, 0),
+			inWindow: false,
+			next:     at(1, 12, 0),
+		},
+		{
+			name:     "in the daily window",
+			now:      at(3, 12, 10),
+			inWindow: true,
+			next:     at(4, 12, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inWindow, next, err := inMaintenanceWindow(windows, tt.now)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(inWindow).To(Equal(tt.inWindow))
+			g.Expect(next).To(Equal(tt.next))
+		})
+	}
+
+	_, _, err := inMaintenanceWindow([]v1alpha1.MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: "4"}}, at(1, 1, 0))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestDeferToMaintenanceWindow(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	g.Expect(deferToMaintenanceWindow(tc, v1alpha1.PDMemberType, v1alpha1.MaintenanceOperationUpgrade)).To(BeFalse())
+	g.Expect(tc.Status.Maintenance).To(BeNil())
+
+	tc.Spec.MaintenanceWindows = []v1alpha1.MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: "4h"}}
+	tc.Status.Maintenance = &v1alpha1.MaintenanceStatus{InWindow: true}
+	g.Expect(deferToMaintenanceWindow(tc, v1alpha1.PDMemberType, v1alpha1.MaintenanceOperationUpgrade)).To(BeFalse())
+	g.Expect(tc.Status.Maintenance.PendingOperations).To(BeEmpty())
+
+	tc.Status.Maintenance = &v1alpha1.MaintenanceStatus{InWindow: false}
+	g.Expect(deferToMaintenanceWindow(tc, v1alpha1.PDMemberType, v1alpha1.MaintenanceOperationUpgrade)).To(BeTrue())
+	g.Expect(deferToMaintenanceWindow(tc, v1alpha1.PDMemberType, v1alpha1.MaintenanceOperationUpgrade)).To(BeTrue())
+	g.Expect(deferToMaintenanceWindow(tc, v1alpha1.TiKVMemberType, v1alpha1.MaintenanceOperationScaleIn)).To(BeTrue())
+	g.Expect(tc.Status.Maintenance.PendingOperations).To(Equal([]v1alpha1.PendingMaintenanceOperation{
+		{MemberType: v1alpha1.PDMemberType, Operation: v1alpha1.MaintenanceOperationUpgrade},
+		{MemberType: v1alpha1.TiKVMemberType, Operation: v1alpha1.MaintenanceOperationScaleIn},
+	}))
+
+	// only TidbCluster is restricted by maintenance windows
+	dc := &v1alpha1.DMCluster{}
+	g.Expect(deferToMaintenanceWindow(dc, v1alpha1.DMMasterMemberType, v1alpha1.MaintenanceOperationUpgrade)).To(BeFalse())
+}
+
+func TestPDScalerScaleInOutOfMaintenanceWindow(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.MaintenanceWindows = []v1alpha1.MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: "4h"}}
+	tc.Status.Maintenance = &v1alpha1.MaintenanceStatus{InWindow: false, NextWindowTime: &metav1.Time{}}
+
+	scaler, _, _, _, _ := newFakePDScaler()
+	oldSet := newStatefulSetForPDScale()
+	newSet := oldSet.DeepCopy()
+	newSet.Spec.Replicas = pointer.Int32Ptr(3)
+
+	err := scaler.Scale(tc, oldSet, newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(5)))
+	g.Expect(tc.Status.Maintenance.PendingOperations).To(ConsistOf(v1alpha1.PendingMaintenanceOperation{
+		MemberType: v1alpha1.PDMemberType,
+		Operation:  v1alpha1.MaintenanceOperationScaleIn,
+	}))
+}
+
+func TestKeepLastAppliedPodTemplate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	oldSet := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tidb", Namespace: metav1.NamespaceDefault},
+		Spec: apps.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "tidb"},
+					Annotations: map[string]string{"foo": "bar"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "tidb", Image: "tidb:v5.0.0"}},
+				},
+			},
+		},
+	}
+	g.Expect(SetStatefulSetLastAppliedConfigAnnotation(oldSet)).To(Succeed())
+
+	// the changes of the image, labels and annotations all roll the pods
+	newSet := oldSet.DeepCopy()
+	newSet.Spec.Template.Labels["extra"] = "label"
+	newSet.Spec.Template.Annotations["foo"] = "baz"
+	newSet.Spec.Template.Spec.Containers[0].Image = "tidb:v5.1.0"
+
+	g.Expect(keepLastAppliedPodTemplate(oldSet, newSet)).To(Succeed())
+	g.Expect(newSet.Spec.Template).To(Equal(oldSet.Spec.Template))
+
+	delete(oldSet.Annotations, LastAppliedConfigAnnotation)
+	g.Expect(keepLastAppliedPodTemplate(oldSet, newSet)).NotTo(Succeed())
+}
@@ -0,0 +1,257 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+// nodeDrainManager makes the PD and TiKV Pods on draining nodes safe to be evicted:
+//   - transfers the PD leader to a PD member which is not on a draining node
+//   - evicts region leaders from the TiKV stores on draining nodes, and stops
+//     the eviction after the Pods are rescheduled
+type nodeDrainManager struct {
+	deps *controller.Dependencies
+}
+
+// NewNodeDrainManager returns a manager which handles the PD and TiKV Pods on draining nodes
+func NewNodeDrainManager(deps *controller.Dependencies) manager.Manager {
+	if deps.NodeLister == nil && features.DefaultFeatureGate.Enabled(features.NodeDrainAwareness) {
+		// nodes are not watched when the operator is namespace scoped
+		klog.Warningf("feature %s is enabled but nodes are not watched by the namespace scoped controller manager, draining nodes will not be handled", features.NodeDrainAwareness)
+	}
+	return &nodeDrainManager{
+		deps: deps,
+	}
+}
+
+func (m *nodeDrainManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if m.deps.NodeLister == nil || !features.DefaultFeatureGate.Enabled(features.NodeDrainAwareness) {
+		return nil
+	}
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip handling draining nodes", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	var errs []error
+	if tc.Spec.PD != nil && tc.Status.PD.Synced {
+		if err := m.transferPDLeader(tc); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if tc.Spec.TiKV != nil && tc.Status.TiKV.Synced {
+		if err := m.syncTiKVEvictLeader(tc); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// transferPDLeader transfers the PD leader away if it is on a draining node
+func (m *nodeDrainManager) transferPDLeader(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	leaderName := tc.Status.PD.Leader.Name
+	if leaderName == "" {
+		return nil
+	}
+	draining, err := m.podOnDrainingNode(ns, util.PDMemberPodName(leaderName))
+	if err != nil || !draining {
+		return err
+	}
+
+	names := make([]string, 0, len(tc.Status.PD.Members))
+	for name := range tc.Status.PD.Members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == leaderName || !tc.Status.PD.Members[name].Health {
+			continue
+		}
+		draining, err := m.podOnDrainingNode(ns, util.PDMemberPodName(name))
+		if err != nil {
+			return err
+		}
+		if draining {
+			continue
+		}
+		if err := controller.GetPDClient(m.deps.PDControl, tc).TransferPDLeader(name); err != nil {
+			return fmt.Errorf("failed to transfer pd leader from %s to %s for cluster %s/%s, error: %v", leaderName, name, ns, tc.GetName(), err)
+		}
+		klog.Infof("pd leader %s of cluster %s/%s is on a draining node, transfer pd leader to %s", leaderName, ns, tc.GetName(), name)
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, PDLeaderTransferred, "pd leader %s is on a draining node, transferred to %s", leaderName, name)
+		return nil
+	}
+
+	klog.Warningf("pd leader %s of cluster %s/%s is on a draining node, but no healthy pd member is available to transfer to", leaderName, ns, tc.GetName())
+	return nil
+}
+
+// syncTiKVEvictLeader begins evicting region leaders from the stores on draining nodes,
+// and ends the eviction once the Pod has been recreated and is ready, or the node is not draining anymore.
+func (m *nodeDrainManager) syncTiKVEvictLeader(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	for _, store := range tc.Status.TiKV.Stores {
+		if store.State != v1alpha1.TiKVStateUp {
+			continue
+		}
+		if _, ok := tc.Status.TiKV.EvictLeader[store.PodName]; ok {
+			continue
+		}
+		pod, err := m.deps.PodLister.Pods(ns).Get(store.PodName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get pod %s/%s, error: %v", ns, store.PodName, err)
+		}
+		if _, evicting := pod.Annotations[EvictLeaderBeginTime]; evicting {
+			// region leaders are being evicted by tikv upgrader
+			continue
+		}
+		draining, err := m.nodeDraining(pod.Spec.NodeName)
+		if err != nil {
+			return err
+		}
+		if !draining {
+			continue
+		}
+
+		storeID, err := strconv.ParseUint(store.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+		if err := controller.GetPDClient(m.deps.PDControl, tc).BeginEvictLeader(storeID); err != nil {
+			return fmt.Errorf("failed to begin evict leader for store %d of cluster %s/%s, error: %v", storeID, ns, tcName, err)
+		}
+		if tc.Status.TiKV.EvictLeader == nil {
+			tc.Status.TiKV.EvictLeader = map[string]*v1alpha1.EvictLeaderStatus{}
+		}
+		tc.Status.TiKV.EvictLeader[pod.Name] = &v1alpha1.EvictLeaderStatus{
+			StoreID:       store.ID,
+			NodeName:      pod.Spec.NodeName,
+			PodCreateTime: pod.CreationTimestamp,
+			BeginTime:     metav1.Now(),
+		}
+		klog.Infof("node %s of tikv pod %s/%s is draining, begin evict leader for store %d", pod.Spec.NodeName, ns, pod.Name, storeID)
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, EvictLeaderBegan, "node %s of tikv pod %s is draining, begin evicting leaders of store %d", pod.Spec.NodeName, pod.Name, storeID)
+	}
+
+	for podName, status := range tc.Status.TiKV.EvictLeader {
+		pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get pod %s/%s, error: %v", ns, podName, err)
+		}
+		if errors.IsNotFound(err) {
+			if _, ok := tc.Status.TiKV.Stores[status.StoreID]; ok {
+				// wait for the Pod to be recreated
+				continue
+			}
+			// the store has been removed, e.g. scaled in
+		} else if pod.CreationTimestamp.Equal(&status.PodCreateTime) {
+			// the Pod is not evicted yet, end the eviction only if the node is not draining anymore
+			draining, err := m.nodeDraining(pod.Spec.NodeName)
+			if err != nil {
+				return err
+			}
+			if draining {
+				continue
+			}
+		} else if !podutil.IsPodReady(pod) {
+			continue
+		}
+
+		storeID, err := strconv.ParseUint(status.StoreID, 10, 64)
+		if err != nil {
+			return err
+		}
+		if err := endEvictLeaderbyStoreID(m.deps, tc, storeID); err != nil {
+			return err
+		}
+		delete(tc.Status.TiKV.EvictLeader, podName)
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, EvictLeaderEnded, "end evicting leaders of store %d for tikv pod %s", storeID, podName)
+	}
+	return nil
+}
+
+func (m *nodeDrainManager) podOnDrainingNode(ns, podName string) (bool, error) {
+	pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get pod %s/%s, error: %v", ns, podName, err)
+	}
+	return m.nodeDraining(pod.Spec.NodeName)
+}
+
+func (m *nodeDrainManager) nodeDraining(nodeName string) (bool, error) {
+	if nodeName == "" {
+		return false, nil
+	}
+	node, err := m.deps.NodeLister.Get(nodeName)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get node %s, error: %v", nodeName, err)
+	}
+	return IsNodeDraining(node, m.deps.CLIConfig.GetNodeDrainTaintKeys()), nil
+}
+
+// IsNodeDraining returns whether the node is cordoned or has any of the drain taints
+func IsNodeDraining(node *corev1.Node, drainTaintKeys []string) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect != corev1.TaintEffectNoSchedule && taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		for _, key := range drainTaintKeys {
+			if taint.Key == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type FakeNodeDrainManager struct {
+}
+
+func NewFakeNodeDrainManager() *FakeNodeDrainManager {
+	return &FakeNodeDrainManager{}
+}
+
+func (f *FakeNodeDrainManager) Sync(tc *v1alpha1.TidbCluster) error {
+	return nil
+}
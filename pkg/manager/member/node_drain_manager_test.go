@@ -0,0 +1,203 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsNodeDraining(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	node := &corev1.Node{}
+	g.Expect(IsNodeDraining(node, nil)).To(BeFalse())
+
+	node.Spec.Unschedulable = true
+	g.Expect(IsNodeDraining(node, nil)).To(BeTrue())
+
+	node.Spec.Unschedulable = false
+	node.Spec.Taints = []corev1.Taint{{Key: "drain", Effect: corev1.TaintEffectPreferNoSchedule}}
+	g.Expect(IsNodeDraining(node, []string{"drain"})).To(BeFalse())
+
+	node.Spec.Taints = []corev1.Taint{{Key: "drain", Effect: corev1.TaintEffectNoExecute}}
+	g.Expect(IsNodeDraining(node, []string{"other"})).To(BeFalse())
+	g.Expect(IsNodeDraining(node, []string{"other", "drain"})).To(BeTrue())
+}
+
+func TestNodeDrainManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	features.DefaultFeatureGate.Set("NodeDrainAwareness=true")
+	defer features.DefaultFeatureGate.Set("NodeDrainAwareness=false")
+
+	type testcase struct {
+		name             string
+		nodeDraining     bool
+		podRecreated     bool
+		evictLeader      map[string]*v1alpha1.EvictLeaderStatus
+		expectTransfer   bool
+		expectBegin      bool
+		expectEnd        bool
+		expectEvictCount int
+	}
+
+	createTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	testFn := func(test *testcase) {
+		t.Log(test.name)
+
+		deps := controller.NewFakeDependencies()
+		m := &nodeDrainManager{deps: deps}
+		tc := newTidbClusterForNodeDrain()
+		tc.Status.TiKV.EvictLeader = test.evictLeader
+
+		nodeIndexer := deps.KubeInformerFactory.Core().V1().Nodes().Informer().GetIndexer()
+		podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+		addNode := func(name string, draining bool) {
+			nodeIndexer.Add(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       corev1.NodeSpec{Unschedulable: draining},
+			})
+		}
+		addPod := func(name, nodeName string, created metav1.Time) {
+			podIndexer.Add(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: tc.Namespace, CreationTimestamp: created},
+				Spec:       corev1.PodSpec{NodeName: nodeName},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			})
+		}
+		addNode("node-1", test.nodeDraining)
+		addNode("node-2", false)
+		addPod("test-pd-0", "node-1", createTime)
+		addPod("test-pd-1", "node-2", createTime)
+		if test.podRecreated {
+			addPod("test-tikv-0", "node-2", metav1.NewTime(createTime.Add(time.Minute)))
+		} else {
+			addPod("test-tikv-0", "node-1", createTime)
+		}
+
+		pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+		transferred, began, ended := false, false, false
+		pdClient.AddReaction(pdapi.TransferPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+			transferred = true
+			g.Expect(action.Name).To(Equal("test-pd-1"))
+			return nil, nil
+		})
+		pdClient.AddReaction(pdapi.BeginEvictLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+			began = true
+			return nil, nil
+		})
+		pdClient.AddReaction(pdapi.EndEvictLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+			ended = true
+			return nil, nil
+		})
+
+		g.Expect(m.Sync(tc)).To(Succeed())
+		g.Expect(transferred).To(Equal(test.expectTransfer))
+		g.Expect(began).To(Equal(test.expectBegin))
+		g.Expect(ended).To(Equal(test.expectEnd))
+		g.Expect(tc.Status.TiKV.EvictLeader).To(HaveLen(test.expectEvictCount))
+	}
+
+	tests := []testcase{
+		{
+			name:             "node is not draining",
+			nodeDraining:     false,
+			expectEvictCount: 0,
+		},
+		{
+			name:             "node is draining",
+			nodeDraining:     true,
+			expectTransfer:   true,
+			expectBegin:      true,
+			expectEvictCount: 1,
+		},
+		{
+			name:         "node is still draining and pod is not evicted",
+			nodeDraining: true,
+			evictLeader: map[string]*v1alpha1.EvictLeaderStatus{
+				"test-tikv-0": {StoreID: "1", NodeName: "node-1", PodCreateTime: createTime},
+			},
+			expectTransfer:   true,
+			expectEvictCount: 1,
+		},
+		{
+			name:         "node is uncordoned",
+			nodeDraining: false,
+			evictLeader: map[string]*v1alpha1.EvictLeaderStatus{
+				"test-tikv-0": {StoreID: "1", NodeName: "node-1", PodCreateTime: createTime},
+			},
+			expectEnd:        true,
+			expectEvictCount: 0,
+		},
+		{
+			name:         "pod is recreated on another node",
+			nodeDraining: true,
+			podRecreated: true,
+			evictLeader: map[string]*v1alpha1.EvictLeaderStatus{
+				"test-tikv-0": {StoreID: "1", NodeName: "node-1", PodCreateTime: createTime},
+			},
+			expectTransfer:   true,
+			expectEnd:        true,
+			expectEvictCount: 0,
+		},
+	}
+
+	for i := range tests {
+		testFn(&tests[i])
+	}
+}
+
+func newTidbClusterForNodeDrain() *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "TidbCluster",
+			APIVersion: "pingcap.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD:   &v1alpha1.PDSpec{},
+			TiKV: &v1alpha1.TiKVSpec{},
+		},
+		Status: v1alpha1.TidbClusterStatus{
+			PD: v1alpha1.PDStatus{
+				Synced: true,
+				Leader: v1alpha1.PDMember{Name: "test-pd-0"},
+				Members: map[string]v1alpha1.PDMember{
+					"test-pd-0": {Name: "test-pd-0", Health: true},
+					"test-pd-1": {Name: "test-pd-1", Health: true},
+				},
+			},
+			TiKV: v1alpha1.TiKVStatus{
+				Synced: true,
+				Stores: map[string]v1alpha1.TiKVStore{
+					"1": {ID: "1", PodName: "test-tikv-0", State: v1alpha1.TiKVStateUp},
+				},
+			},
+		},
+	}
+}
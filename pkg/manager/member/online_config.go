@@ -0,0 +1,275 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	perrors "github.com/pingcap/errors"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/toml"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+var (
+	// tikvOnlineConfigItems are the TiKV config items that can be changed online,
+	// see https://docs.pingcap.com/tidb/stable/dynamic-config
+	tikvOnlineConfigItems = sets.NewString(
+		"raftstore.sync-log",
+		"raftstore.raft-entry-max-size",
+		"raftstore.raft-log-gc-tick-interval",
+		"raftstore.raft-log-gc-threshold",
+		"raftstore.raft-log-gc-count-limit",
+		"raftstore.raft-log-gc-size-limit",
+		"raftstore.raft-entry-cache-life-time",
+		"raftstore.raft-reject-transfer-leader-duration",
+		"raftstore.split-region-check-tick-interval",
+		"raftstore.region-split-check-diff",
+		"raftstore.region-compact-check-interval",
+		"raftstore.region-compact-check-step",
+		"raftstore.region-compact-min-tombstones",
+		"raftstore.region-compact-tombstones-percent",
+		"raftstore.pd-heartbeat-tick-interval",
+		"raftstore.pd-store-heartbeat-tick-interval",
+		"raftstore.snap-mgr-gc-tick-interval",
+		"raftstore.snap-gc-timeout",
+		"raftstore.lock-cf-compact-interval",
+		"raftstore.lock-cf-compact-bytes-threshold",
+		"raftstore.messages-per-tick",
+		"raftstore.max-peer-down-duration",
+		"raftstore.max-leader-missing-duration",
+		"raftstore.abnormal-leader-missing-duration",
+		"raftstore.peer-stale-state-check-interval",
+		"raftstore.consistency-check-interval",
+		"raftstore.raft-store-max-leader-lease",
+		"raftstore.allow-remove-leader",
+		"raftstore.merge-check-tick-interval",
+		"raftstore.cleanup-import-sst-interval",
+		"raftstore.local-read-batch-size",
+		"raftstore.hibernate-timeout",
+		"raftstore.apply-pool-size",
+		"raftstore.store-pool-size",
+		"coprocessor.split-region-on-table",
+		"coprocessor.batch-split-limit",
+		"coprocessor.region-max-size",
+		"coprocessor.region-split-size",
+		"coprocessor.region-max-keys",
+		"coprocessor.region-split-keys",
+		"pessimistic-txn.wait-for-lock-timeout",
+		"pessimistic-txn.wake-up-delay-duration",
+		"pessimistic-txn.pipelined",
+		"gc.ratio-threshold",
+		"gc.batch-keys",
+		"gc.max-write-bytes-per-sec",
+		"rocksdb.max-background-jobs",
+		"rocksdb.max-open-files",
+		"rocksdb.compaction-readahead-size",
+		"rocksdb.bytes-per-sync",
+		"rocksdb.wal-bytes-per-sync",
+		"rocksdb.writable-file-max-buffer-size",
+		"raftdb.max-background-jobs",
+		"raftdb.max-open-files",
+		"raftdb.compaction-readahead-size",
+		"raftdb.bytes-per-sync",
+		"raftdb.wal-bytes-per-sync",
+		"raftdb.writable-file-max-buffer-size",
+		"storage.block-cache.capacity",
+		"backup.num-threads",
+		"split.qps-threshold",
+		"split.byte-threshold",
+		"split.split-balance-score",
+		"split.split-contained-score",
+	)
+	// tikvOnlineCFConfigItems are the items of the column families of rocksdb
+	// and raftdb that can be changed online
+	tikvOnlineCFConfigItems = sets.NewString(
+		"block-cache-size",
+		"write-buffer-size",
+		"max-write-buffer-number",
+		"max-bytes-for-level-base",
+		"target-file-size-base",
+		"level0-file-num-compaction-trigger",
+		"level0-slowdown-writes-trigger",
+		"level0-stop-writes-trigger",
+		"max-compaction-bytes",
+		"max-bytes-for-level-multiplier",
+		"disable-auto-compactions",
+		"soft-pending-compaction-bytes-limit",
+		"hard-pending-compaction-bytes-limit",
+		"titan.blob-run-mode",
+	)
+	tikvColumnFamilies = []string{"rocksdb.defaultcf", "rocksdb.writecf", "rocksdb.lockcf", "raftdb.defaultcf"}
+
+	// pdOnlineConfigItems are the PD config items that can be changed online
+	// besides the schedule items, they are persisted by PD and take effect in
+	// the whole cluster
+	pdOnlineConfigItems = sets.NewString(
+		"replication.max-replicas",
+		"replication.location-labels",
+		"replication.strictly-match-label",
+		"replication.enable-placement-rules",
+		"replication.isolation-level",
+		"pd-server.use-region-storage",
+		"pd-server.max-gap-reset-ts",
+		"pd-server.metric-storage",
+	)
+	// pdOfflineScheduleConfigItems are the schedule items that can not be
+	// changed through the config API
+	pdOfflineScheduleConfigItems = sets.NewString(
+		"schedule.schedulers-v2",
+		"schedule.schedulers-payload",
+	)
+
+	// tidbOnlineConfigItems maps the TiDB config items that can be changed
+	// online to the form fields of the settings API of TiDB
+	tidbOnlineConfigItems = map[string]string{
+		"log.level":               "log_level",
+		"check-mb4-value-in-utf8": "check_mb4_value_in_utf8",
+	}
+)
+
+func isTiKVOnlineConfigItem(key string) bool {
+	if tikvOnlineConfigItems.Has(key) {
+		return true
+	}
+	for _, cf := range tikvColumnFamilies {
+		if strings.HasPrefix(key, cf+".") && tikvOnlineCFConfigItems.Has(strings.TrimPrefix(key, cf+".")) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPDOnlineConfigItem(key string) bool {
+	if strings.HasPrefix(key, "schedule.") {
+		return !pdOfflineScheduleConfigItems.Has(key)
+	}
+	return pdOnlineConfigItems.Has(key)
+}
+
+func isTiDBOnlineConfigItem(key string) bool {
+	_, ok := tidbOnlineConfigItems[key]
+	return ok
+}
+
+// updateConfigMapOnline is updateConfigMapIfNeed for the TidbClusters with
+// EnableDynamicConfiguration. If only the config items accepted by isOnline
+// change, they are passed to apply and the configmap in use is updated in-place,
+// otherwise the configmap is updated by configUpdateStrategy. It returns the
+// keys of the items applied online.
+func updateConfigMapOnline(
+	cmLister corelisters.ConfigMapLister,
+	configUpdateStrategy v1alpha1.ConfigUpdateStrategy,
+	inUseName string,
+	desired *corev1.ConfigMap,
+	isOnline func(key string) bool,
+	apply func(items map[string]interface{}) error,
+) ([]string, error) {
+	existing, err := cmLister.ConfigMaps(desired.Namespace).Get(inUseName)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, perrors.AddStack(err)
+	}
+	if err == nil {
+		items, err := onlineConfigItems(existing, desired, isOnline)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) > 0 {
+			if err := apply(items); err != nil {
+				return nil, err
+			}
+			if _, err := updateConfigMap(existing, desired); err != nil {
+				return nil, err
+			}
+			desired.Name = existing.Name
+			keys := make([]string, 0, len(items))
+			for k := range items {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return keys, nil
+		}
+	}
+	return nil, updateConfigMapIfNeed(cmLister, configUpdateStrategy, inUseName, desired)
+}
+
+// onlineConfigItems returns the changed config items if all of them are
+// accepted by isOnline, or nil if any other change is made to the configmap.
+// A removed item needs a restart too, as its default value is unknown here.
+func onlineConfigItems(existing, desired *corev1.ConfigMap, isOnline func(key string) bool) (map[string]interface{}, error) {
+	if len(existing.Data) != len(desired.Data) {
+		return nil, nil
+	}
+	for k, v := range existing.Data {
+		if newV, ok := desired.Data[k]; !ok || (k != "config-file" && newV != v) {
+			return nil, nil
+		}
+	}
+
+	oldItems, err := flattenConfig(existing.Data["config-file"])
+	if err != nil {
+		return nil, err
+	}
+	newItems, err := flattenConfig(desired.Data["config-file"])
+	if err != nil {
+		return nil, err
+	}
+	for k := range oldItems {
+		if _, ok := newItems[k]; !ok {
+			return nil, nil
+		}
+	}
+	items := map[string]interface{}{}
+	for k, v := range newItems {
+		if oldV, ok := oldItems[k]; ok && reflect.DeepEqual(oldV, v) {
+			continue
+		}
+		if !isOnline(k) {
+			return nil, nil
+		}
+		items[k] = v
+	}
+	return items, nil
+}
+
+// flattenConfig decodes a TOML config into items keyed by their dotted path,
+// e.g. raftstore.sync-log
+func flattenConfig(data string) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if err := toml.Unmarshal([]byte(data), &m); err != nil {
+		return nil, err
+	}
+	items := map[string]interface{}{}
+	flattenConfigInto("", m, items)
+	return items, nil
+}
+
+func flattenConfigInto(prefix string, m map[string]interface{}, items map[string]interface{}) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if sub, ok := v.(map[string]interface{}); ok {
+			flattenConfigInto(key, sub, items)
+			continue
+		}
+		items[key] = v
+	}
+}
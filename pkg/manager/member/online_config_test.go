@@ -0,0 +1,268 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/tikvapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestIsOnlineConfigItem(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(isTiKVOnlineConfigItem("raftstore.sync-log")).To(BeTrue())
+	g.Expect(isTiKVOnlineConfigItem("rocksdb.writecf.block-cache-size")).To(BeTrue())
+	g.Expect(isTiKVOnlineConfigItem("raftdb.defaultcf.titan.blob-run-mode")).To(BeTrue())
+	g.Expect(isTiKVOnlineConfigItem("rocksdb.writecf.compression-per-level")).To(BeFalse())
+	g.Expect(isTiKVOnlineConfigItem("storage.data-dir")).To(BeFalse())
+	g.Expect(isTiKVOnlineConfigItem("server.grpc-concurrency")).To(BeFalse())
+
+	g.Expect(isPDOnlineConfigItem("schedule.max-snapshot-count")).To(BeTrue())
+	g.Expect(isPDOnlineConfigItem("replication.location-labels")).To(BeTrue())
+	g.Expect(isPDOnlineConfigItem("schedule.schedulers-v2")).To(BeFalse())
+	g.Expect(isPDOnlineConfigItem("log.level")).To(BeFalse())
+	g.Expect(isPDOnlineConfigItem("security.cacert-path")).To(BeFalse())
+
+	g.Expect(isTiDBOnlineConfigItem("log.level")).To(BeTrue())
+	g.Expect(isTiDBOnlineConfigItem("check-mb4-value-in-utf8")).To(BeTrue())
+	g.Expect(isTiDBOnlineConfigItem("log.slow-threshold")).To(BeFalse())
+}
+
+func TestUpdateConfigMapOnline(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newCm := func(name, config, script string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Data: map[string]string{
+				"config-file":    config,
+				"startup-script": script,
+			},
+		}
+	}
+	existingConfig := `
+[raftstore]
+sync-log = true
+
+[server]
+grpc-concurrency = 4
+`
+
+	type testcase struct {
+		name        string
+		strategy    v1alpha1.ConfigUpdateStrategy
+		existing    *corev1.ConfigMap
+		desired     *corev1.ConfigMap
+		applyErr    error
+		expectErr   bool
+		expectItems map[string]interface{}
+		expectName  func(desired *corev1.ConfigMap) string
+	}
+
+	digestName := func(desired *corev1.ConfigMap) string {
+		cm := desired.DeepCopy()
+		cm.Name = "demo-tikv"
+		g.Expect(AddConfigMapDigestSuffix(cm)).To(Succeed())
+		return cm.Name
+	}
+	inUseName := func(*corev1.ConfigMap) string { return "demo-tikv-in-use" }
+
+	tests := []testcase{
+		{
+			name:       "configmap does not exist",
+			desired:    newCm("demo-tikv", existingConfig, "start"),
+			expectName: digestName,
+		},
+		{
+			name:       "config does not change",
+			existing:   newCm("demo-tikv-in-use", existingConfig, "start"),
+			desired:    newCm("demo-tikv", "[server]\ngrpc-concurrency = 4\n[raftstore]\nsync-log = true\n", "start"),
+			expectName: inUseName,
+		},
+		{
+			name:     "only online items change",
+			existing: newCm("demo-tikv-in-use", existingConfig, "start"),
+			desired: newCm("demo-tikv", `
+[raftstore]
+sync-log = false
+
+[server]
+grpc-concurrency = 4
+
+[split]
+qps-threshold = 3000
+`, "start"),
+			expectItems: map[string]interface{}{
+				"raftstore.sync-log":  false,
+				"split.qps-threshold": int64(3000),
+			},
+			expectName: inUseName,
+		},
+		{
+			name:     "an item that needs restart changes",
+			existing: newCm("demo-tikv-in-use", existingConfig, "start"),
+			desired: newCm("demo-tikv", `
+[raftstore]
+sync-log = false
+
+[server]
+grpc-concurrency = 8
+`, "start"),
+			expectName: digestName,
+		},
+		{
+			name:     "an item that needs restart changes with in-place update",
+			strategy: v1alpha1.ConfigUpdateStrategyInPlace,
+			existing: newCm("demo-tikv-in-use", existingConfig, "start"),
+			desired: newCm("demo-tikv", `
+[raftstore]
+sync-log = false
+
+[server]
+grpc-concurrency = 8
+`, "start"),
+			expectName: inUseName,
+		},
+		{
+			name:       "an item is removed",
+			existing:   newCm("demo-tikv-in-use", existingConfig, "start"),
+			desired:    newCm("demo-tikv", "[server]\ngrpc-concurrency = 4\n", "start"),
+			expectName: digestName,
+		},
+		{
+			name:       "startup script changes",
+			existing:   newCm("demo-tikv-in-use", existingConfig, "start"),
+			desired:    newCm("demo-tikv", "[raftstore]\nsync-log = false\n[server]\ngrpc-concurrency = 4\n", "start again"),
+			expectName: digestName,
+		},
+		{
+			name:      "failed to apply online",
+			existing:  newCm("demo-tikv-in-use", existingConfig, "start"),
+			desired:   newCm("demo-tikv", "[raftstore]\nsync-log = false\n[server]\ngrpc-concurrency = 4\n", "start"),
+			applyErr:  fmt.Errorf("connection refused"),
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		inUse := ""
+		if test.existing != nil {
+			g.Expect(indexer.Add(test.existing)).To(Succeed())
+			inUse = test.existing.Name
+		}
+		var applied map[string]interface{}
+		apply := func(items map[string]interface{}) error {
+			applied = items
+			return test.applyErr
+		}
+
+		strategy := test.strategy
+		if strategy == "" {
+			strategy = v1alpha1.ConfigUpdateStrategyRollingUpdate
+		}
+		keys, err := updateConfigMapOnline(corelisters.NewConfigMapLister(indexer), strategy, inUse, test.desired, isTiKVOnlineConfigItem, apply)
+		if test.expectErr {
+			g.Expect(err).To(HaveOccurred())
+			continue
+		}
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(applied).To(Equal(test.expectItems))
+		g.Expect(keys).To(HaveLen(len(test.expectItems)))
+		for _, k := range keys {
+			g.Expect(test.expectItems).To(HaveKey(k))
+		}
+		g.Expect(test.desired.Name).To(Equal(test.expectName(test.desired)))
+	}
+}
+
+func TestApplyTiKVConfigOnline(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": {ID: "1", PodName: "test-tikv-0", State: v1alpha1.TiKVStateUp},
+		"2": {ID: "2", PodName: "test-tikv-1", State: v1alpha1.TiKVStateDown},
+	}
+	tmm, _, _, _, _, _ := newFakeTiKVMemberManager(tc)
+	tikvControl := tmm.deps.TiKVControl.(*tikvapi.FakeTiKVControl)
+
+	tikvClient := tikvapi.NewFakeTiKVClient()
+	var applied map[string]interface{}
+	tikvClient.AddReaction(tikvapi.UpdateConfigActionType, func(action *tikvapi.Action) (interface{}, error) {
+		applied = action.Config
+		return nil, nil
+	})
+	tikvControl.SetTiKVPodClient(tc.Namespace, tc.Name, "test-tikv-0", tikvClient)
+	// the store that is down must not be called
+	tikvControl.SetTiKVPodClient(tc.Namespace, tc.Name, "test-tikv-1", tikvapi.NewFakeTiKVClient())
+
+	items := map[string]interface{}{"raftstore.sync-log": false}
+	g.Expect(tmm.applyTiKVConfigOnline(tc, items)).To(Succeed())
+	g.Expect(applied).To(Equal(items))
+
+	tikvClient.AddReaction(tikvapi.UpdateConfigActionType, func(action *tikvapi.Action) (interface{}, error) {
+		return nil, fmt.Errorf("connection refused")
+	})
+	g.Expect(tmm.applyTiKVConfigOnline(tc, items)).NotTo(Succeed())
+}
+
+func TestApplyPDConfigOnline(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	pmm, _, _ := newFakePDMemberManager()
+	pdClient := controller.NewFakePDClient(pmm.deps.PDControl.(*pdapi.FakePDControl), tc)
+	var applied map[string]interface{}
+	pdClient.AddReaction(pdapi.UpdateConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		applied = action.Config
+		return nil, nil
+	})
+
+	items := map[string]interface{}{"schedule.max-snapshot-count": int64(8)}
+	g.Expect(pmm.applyPDConfigOnline(tc, items)).To(Succeed())
+	g.Expect(applied).To(Equal(items))
+}
+
+func TestApplyTiDBConfigOnline(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{
+		"test-tidb-0": {Name: "test-tidb-0", Health: true},
+		"test-tidb-1": {Name: "test-tidb-1", Health: false},
+	}
+	tmm, _, tidbControl, _ := newFakeTiDBMemberManager()
+
+	items := map[string]interface{}{"log.level": "warn", "check-mb4-value-in-utf8": false}
+	g.Expect(tmm.applyTiDBConfigOnline(tc, items)).To(Succeed())
+	// the member that is not healthy must not be called
+	g.Expect(tidbControl.Settings).To(Equal(map[string]map[string]string{
+		"test-tidb-0": {"log_level": "warn", "check_mb4_value_in_utf8": "0"},
+	}))
+
+	tidbControl.SetUpdateSettingsError(fmt.Errorf("connection refused"))
+	g.Expect(tmm.applyTiDBConfigOnline(tc, items)).NotTo(Succeed())
+}
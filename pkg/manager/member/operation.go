@@ -0,0 +1,115 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+// maxOperationHistory is the max number of operations kept in the status of a TidbCluster, the oldest finished
+// operations are dropped first when the limit is exceeded.
+const maxOperationHistory = 50
+
+// startOperation records a running operation on the member pod in the operation history of the cluster, it's a
+// no-op if the same operation is running already or the cluster is not a TidbCluster.
+func startOperation(meta metav1.Object, opType v1alpha1.TidbClusterOperationType, memberType v1alpha1.MemberType, podName, message string) {
+	tc, ok := meta.(*v1alpha1.TidbCluster)
+	if !ok {
+		return
+	}
+	if findRunningOperation(tc, opType, memberType, podName) >= 0 {
+		return
+	}
+	tc.Status.OperationHistory = append(tc.Status.OperationHistory, v1alpha1.TidbClusterOperation{
+		Type:       opType,
+		Component:  memberType,
+		PodName:    podName,
+		Generation: tc.GetGeneration(),
+		StartTime:  metav1.Now(),
+		Outcome:    v1alpha1.OperationRunning,
+		Message:    message,
+	})
+	trimOperationHistory(tc)
+}
+
+// endOperation finishes the running operation on the member pod with the outcome. If the operation was not started,
+// e.g. it completes in one step like scaling out, a finished operation is recorded instead.
+func endOperation(meta metav1.Object, opType v1alpha1.TidbClusterOperationType, memberType v1alpha1.MemberType, podName string, outcome v1alpha1.TidbClusterOperationOutcome, message string) {
+	tc, ok := meta.(*v1alpha1.TidbCluster)
+	if !ok {
+		return
+	}
+	now := metav1.Now()
+	if i := findRunningOperation(tc, opType, memberType, podName); i >= 0 {
+		op := &tc.Status.OperationHistory[i]
+		op.EndTime = &now
+		op.Outcome = outcome
+		if message != "" {
+			op.Message = message
+		}
+		return
+	}
+	tc.Status.OperationHistory = append(tc.Status.OperationHistory, v1alpha1.TidbClusterOperation{
+		Type:       opType,
+		Component:  memberType,
+		PodName:    podName,
+		Generation: tc.GetGeneration(),
+		StartTime:  now,
+		EndTime:    &now,
+		Outcome:    outcome,
+		Message:    message,
+	})
+	trimOperationHistory(tc)
+}
+
+// endOperations finishes all the running operations of the type on the member with the outcome
+func endOperations(meta metav1.Object, opType v1alpha1.TidbClusterOperationType, memberType v1alpha1.MemberType, outcome v1alpha1.TidbClusterOperationOutcome) {
+	tc, ok := meta.(*v1alpha1.TidbCluster)
+	if !ok {
+		return
+	}
+	now := metav1.Now()
+	for i := range tc.Status.OperationHistory {
+		op := &tc.Status.OperationHistory[i]
+		if op.Outcome == v1alpha1.OperationRunning && op.Type == opType && op.Component == memberType {
+			op.EndTime = &now
+			op.Outcome = outcome
+		}
+	}
+}
+
+func findRunningOperation(tc *v1alpha1.TidbCluster, opType v1alpha1.TidbClusterOperationType, memberType v1alpha1.MemberType, podName string) int {
+	for i := len(tc.Status.OperationHistory) - 1; i >= 0; i-- {
+		op := tc.Status.OperationHistory[i]
+		if op.Outcome == v1alpha1.OperationRunning && op.Type == opType && op.Component == memberType && op.PodName == podName {
+			return i
+		}
+	}
+	return -1
+}
+
+func trimOperationHistory(tc *v1alpha1.TidbCluster) {
+	for len(tc.Status.OperationHistory) > maxOperationHistory {
+		drop := 0
+		for i, op := range tc.Status.OperationHistory {
+			if op.Outcome != v1alpha1.OperationRunning {
+				drop = i
+				break
+			}
+		}
+		tc.Status.OperationHistory = append(tc.Status.OperationHistory[:drop], tc.Status.OperationHistory[drop+1:]...)
+	}
+}
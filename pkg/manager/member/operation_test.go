@@ -0,0 +1,77 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestOperationHistory(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForPD()
+	tc.Generation = 3
+
+	startOperation(tc, v1alpha1.OperationTypeScaleIn, v1alpha1.TiKVMemberType, "test-tikv-2", "store 4 is set offline")
+	startOperation(tc, v1alpha1.OperationTypeScaleIn, v1alpha1.TiKVMemberType, "test-tikv-2", "store 4 is set offline")
+	g.Expect(tc.Status.OperationHistory).To(HaveLen(1))
+	op := tc.Status.OperationHistory[0]
+	g.Expect(op.Outcome).To(Equal(v1alpha1.OperationRunning))
+	g.Expect(op.Generation).To(Equal(int64(3)))
+	g.Expect(op.EndTime).To(BeNil())
+
+	endOperation(tc, v1alpha1.OperationTypeScaleIn, v1alpha1.TiKVMemberType, "test-tikv-2", v1alpha1.OperationSucceeded, "")
+	g.Expect(tc.Status.OperationHistory).To(HaveLen(1))
+	op = tc.Status.OperationHistory[0]
+	g.Expect(op.Outcome).To(Equal(v1alpha1.OperationSucceeded))
+	g.Expect(op.EndTime).NotTo(BeNil())
+	g.Expect(op.Message).To(Equal("store 4 is set offline"))
+
+	// an operation not started is recorded as finished
+	endOperation(tc, v1alpha1.OperationTypeScaleOut, v1alpha1.TiKVMemberType, "test-tikv-3", v1alpha1.OperationSucceeded, "")
+	g.Expect(tc.Status.OperationHistory).To(HaveLen(2))
+	op = tc.Status.OperationHistory[1]
+	g.Expect(op.Outcome).To(Equal(v1alpha1.OperationSucceeded))
+	g.Expect(op.StartTime).To(Equal(*op.EndTime))
+
+	startOperation(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.PDMemberType, "test-pd-2", "")
+	startOperation(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.PDMemberType, "test-pd-1", "")
+	startOperation(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.TiKVMemberType, "test-tikv-1", "")
+	endOperations(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.PDMemberType, v1alpha1.OperationSucceeded)
+	g.Expect(tc.Status.OperationHistory[2].Outcome).To(Equal(v1alpha1.OperationSucceeded))
+	g.Expect(tc.Status.OperationHistory[3].Outcome).To(Equal(v1alpha1.OperationSucceeded))
+	g.Expect(tc.Status.OperationHistory[4].Outcome).To(Equal(v1alpha1.OperationRunning))
+
+	// DMCluster has no operation history
+	dc := &v1alpha1.DMCluster{}
+	startOperation(dc, v1alpha1.OperationTypeUpgrade, v1alpha1.DMMasterMemberType, "test-dm-master-0", "")
+}
+
+func TestTrimOperationHistory(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForPD()
+
+	startOperation(tc, v1alpha1.OperationTypeFailover, v1alpha1.TiKVMemberType, "test-tikv-0", "")
+	for i := 0; i < maxOperationHistory; i++ {
+		endOperation(tc, v1alpha1.OperationTypeScaleOut, v1alpha1.TiDBMemberType, fmt.Sprintf("test-tidb-%d", i), v1alpha1.OperationSucceeded, "")
+	}
+
+	// the oldest finished operation is dropped and the running one is kept
+	g.Expect(tc.Status.OperationHistory).To(HaveLen(maxOperationHistory))
+	g.Expect(tc.Status.OperationHistory[0].Type).To(Equal(v1alpha1.OperationTypeFailover))
+	g.Expect(tc.Status.OperationHistory[1].PodName).To(Equal("test-tidb-1"))
+}
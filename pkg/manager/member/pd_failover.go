@@ -82,7 +82,11 @@ func (f *pdFailover) Failover(tc *v1alpha1.TidbCluster) error {
 }
 
 func (f *pdFailover) Recover(tc *v1alpha1.TidbCluster) {
+	if len(tc.Status.PD.FailureMembers) > 0 {
+		f.deps.Recorder.Eventf(tc, apiv1.EventTypeNormal, FailoverRecovered, "pd failure members recovered, remove the %d replicas added for failover", len(tc.Status.PD.FailureMembers))
+	}
 	tc.Status.PD.FailureMembers = nil
+	endOperations(tc, v1alpha1.OperationTypeFailover, v1alpha1.PDMemberType, v1alpha1.OperationSucceeded)
 	klog.Infof("pd failover: clearing pd failoverMembers, %s/%s", tc.GetNamespace(), tc.GetName())
 }
 
@@ -118,7 +122,7 @@ func (f *pdFailover) tryToMarkAPeerAsFailure(tc *v1alpha1.TidbCluster) error {
 			return fmt.Errorf("tryToMarkAPeerAsFailure: failed to get pvcs for pod %s/%s, error: %s", ns, pod.Name, err)
 		}
 
-		f.deps.Recorder.Eventf(tc, apiv1.EventTypeWarning, "PDMemberUnhealthy", "%s/%s(%s) is unhealthy", ns, podName, pdMember.ID)
+		f.deps.Recorder.Eventf(tc, apiv1.EventTypeWarning, PDMemberUnhealthy, "%s/%s(%s) is unhealthy", ns, podName, pdMember.ID)
 
 		// mark a peer member failed and return an error to skip reconciliation
 		// note that status of tidb cluster will be updated always
@@ -173,7 +177,7 @@ func (f *pdFailover) tryToDeleteAFailureMember(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 	klog.Infof("pd failover[tryToDeleteAFailureMember]: delete member %s/%s(%d) successfully", ns, failurePodName, memberID)
-	f.deps.Recorder.Eventf(tc, apiv1.EventTypeWarning, "PDMemberDeleted", "failure member %s/%s(%d) deleted from PD cluster", ns, failurePodName, memberID)
+	f.deps.Recorder.Eventf(tc, apiv1.EventTypeWarning, PDMemberDeleted, "failure member %s/%s(%d) deleted from PD cluster", ns, failurePodName, memberID)
 
 	// The order of old PVC deleting and the new Pod creating is not guaranteed by Kubernetes.
 	// If new Pod is created before old PVCs are deleted, the Statefulset will try to use the old PVCs and skip creating new PVCs.
@@ -227,6 +231,8 @@ func (f *pdFailover) tryToDeleteAFailureMember(tc *v1alpha1.TidbCluster) error {
 	}
 
 	setMemberDeleted(tc, failurePDName)
+	f.deps.Recorder.Eventf(tc, apiv1.EventTypeNormal, FailoverReplicaAdded, "add a pd replica to replace failure member %s/%s", ns, failurePodName)
+	startOperation(tc, v1alpha1.OperationTypeFailover, v1alpha1.PDMemberType, failurePodName, "")
 	return nil
 }
 
@@ -258,14 +264,14 @@ func (f *pdFailover) isPDInQuorum(tc *v1alpha1.TidbCluster) (bool, int) {
 		if pdMember.Health {
 			healthCount++
 		} else {
-			f.deps.Recorder.Eventf(tc, apiv1.EventTypeWarning, "PDMemberUnhealthy", "%s/%s(%s) is unhealthy", ns, podName, pdMember.ID)
+			f.deps.Recorder.Eventf(tc, apiv1.EventTypeWarning, PDMemberUnhealthy, "%s/%s(%s) is unhealthy", ns, podName, pdMember.ID)
 		}
 	}
 	for _, pdMember := range tc.Status.PD.PeerMembers {
 		if pdMember.Health {
 			healthCount++
 		} else {
-			f.deps.Recorder.Eventf(tc, apiv1.EventTypeWarning, "PDPeerMemberUnhealthy", "%s(%s) is unhealthy", pdMember.Name, pdMember.ID)
+			f.deps.Recorder.Eventf(tc, apiv1.EventTypeWarning, PDPeerMemberUnhealthy, "%s(%s) is unhealthy", pdMember.Name, pdMember.ID)
 		}
 	}
 	return healthCount > (len(tc.Status.PD.Members)+len(tc.Status.PD.PeerMembers))/2, healthCount
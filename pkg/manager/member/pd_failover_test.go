@@ -192,8 +192,9 @@ func TestPDFailoverFailover(t *testing.T) {
 				g.Expect(ok).To(Equal(true))
 				g.Expect(pd1.MemberDeleted).To(Equal(true))
 				events := collectEvents(recorder.Events)
-				g.Expect(events).To(HaveLen(1))
+				g.Expect(events).To(HaveLen(2))
 				g.Expect(events[0]).To(ContainSubstring("failure member default/test-pd-1(12891273174085095651) deleted from PD cluster"))
+				g.Expect(events[1]).To(ContainSubstring("FailoverReplicaAdded add a pd replica to replace failure member default/test-pd-1"))
 			},
 		},
 		{
@@ -341,9 +342,10 @@ func TestPDFailoverFailover(t *testing.T) {
 				g.Expect(ok).To(Equal(true))
 				g.Expect(pd1.MemberDeleted).To(Equal(true))
 				events := collectEvents(recorder.Events)
-				g.Expect(events).To(HaveLen(2))
+				g.Expect(events).To(HaveLen(3))
 				g.Expect(events[0]).To(ContainSubstring("test-pd-1(12891273174085095651) is unhealthy"))
 				g.Expect(events[1]).To(ContainSubstring("failure member default/test-pd-1(12891273174085095651) deleted from PD cluster"))
+				g.Expect(events[2]).To(ContainSubstring("FailoverReplicaAdded add a pd replica to replace failure member default/test-pd-1"))
 			},
 		},
 		{
@@ -487,9 +489,10 @@ func TestPDFailoverFailover(t *testing.T) {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(errors.IsNotFound(err)).To(BeTrue())
 				events := collectEvents(recorder.Events)
-				g.Expect(events).To(HaveLen(2))
+				g.Expect(events).To(HaveLen(3))
 				g.Expect(events[0]).To(ContainSubstring("test-pd-1(12891273174085095651) is unhealthy"))
 				g.Expect(events[1]).To(ContainSubstring("failure member default/test-pd-1(12891273174085095651) deleted from PD cluster"))
+				g.Expect(events[2]).To(ContainSubstring("FailoverReplicaAdded add a pd replica to replace failure member default/test-pd-1"))
 			},
 		},
 		{
@@ -520,9 +523,10 @@ func TestPDFailoverFailover(t *testing.T) {
 				_, err = pf.deps.PVCLister.PersistentVolumeClaims(metav1.NamespaceDefault).Get(pvcName + "-2")
 				g.Expect(err).NotTo(HaveOccurred())
 				events := collectEvents(recorder.Events)
-				g.Expect(events).To(HaveLen(2))
+				g.Expect(events).To(HaveLen(3))
 				g.Expect(events[0]).To(ContainSubstring("test-pd-1(12891273174085095651) is unhealthy"))
 				g.Expect(events[1]).To(ContainSubstring("failure member default/test-pd-1(12891273174085095651) deleted from PD cluster"))
+				g.Expect(events[2]).To(ContainSubstring("FailoverReplicaAdded add a pd replica to replace failure member default/test-pd-1"))
 			},
 		},
 	}
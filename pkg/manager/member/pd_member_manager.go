@@ -69,9 +69,9 @@ func NewPDMemberManager(dependencies *controller.Dependencies, pdScaler Scaler,
 }
 
 func (m *pdMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
-	// If pd is not specified return
+	// If pd is not specified, remove its pdb and return
 	if tc.Spec.PD == nil {
-		return nil
+		return syncPodDisruptionBudget(m.deps, tc, v1alpha1.PDMemberType)
 	}
 
 	// Sync PD Service
@@ -85,7 +85,12 @@ func (m *pdMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 	}
 
 	// Sync PD StatefulSet
-	return m.syncPDStatefulSetForTidbCluster(tc)
+	if err := m.syncPDStatefulSetForTidbCluster(tc); err != nil {
+		return err
+	}
+
+	// Sync PD PodDisruptionBudget
+	return syncPodDisruptionBudget(m.deps, tc, v1alpha1.PDMemberType)
 }
 
 func (m *pdMemberManager) syncPDServiceForTidbCluster(tc *v1alpha1.TidbCluster) error {
@@ -232,7 +237,9 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 
 	if m.deps.CLIConfig.AutoFailover {
 		if m.shouldRecover(tc) {
-			m.failover.Recover(tc)
+			if !deferToMaintenanceWindow(tc, v1alpha1.PDMemberType, v1alpha1.MaintenanceOperationFailoverRecovery) {
+				m.failover.Recover(tc)
+			}
 		} else if tc.PDAllPodsStarted() && !tc.PDAllMembersReady() || tc.PDAutoFailovering() {
 			if err := m.failover.Failover(tc); err != nil {
 				return err
@@ -270,7 +277,7 @@ func (m *pdMemberManager) shouldRecover(tc *v1alpha1.TidbCluster) bool {
 		}
 		ok := false
 		for pdName, pdMember := range tc.Status.PD.Members {
-			if strings.Split(pdName, ".")[0] == pod.Name {
+			if util.PDMemberPodName(pdName) == pod.Name {
 				if !pdMember.Health {
 					return false
 				}
@@ -308,6 +315,7 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 		tc.Status.PD.Phase = v1alpha1.UpgradePhase
 	} else {
 		tc.Status.PD.Phase = v1alpha1.NormalPhase
+		endOperations(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.PDMemberType, v1alpha1.OperationSucceeded)
 	}
 
 	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
@@ -369,8 +377,20 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 		// matching `rePDMembers` means `clientURL` is a PD in current tc
 		if rePDMembers.Match([]byte(clientURL)) {
 			oldPDMember, exist := tc.Status.PD.Members[name]
-			if exist && status.Health == oldPDMember.Health {
-				status.LastTransitionTime = oldPDMember.LastTransitionTime
+			if exist {
+				status.NodeName = oldPDMember.NodeName
+				if status.Health == oldPDMember.Health {
+					status.LastTransitionTime = oldPDMember.LastTransitionTime
+				}
+			}
+			podName := util.PDMemberPodName(name)
+			pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+			if err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("syncTidbClusterStatus: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
+			}
+			if pod != nil && pod.Spec.NodeName != "" {
+				// Update assigned node if pod exists and is scheduled
+				status.NodeName = pod.Spec.NodeName
 			}
 			pdStatus[name] = status
 		} else {
@@ -419,6 +439,23 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 		})
 	}
 
+	if tc.IsDynamicConfigurationEnabled() {
+		applied, err := updateConfigMapOnline(m.deps.ConfigMapLister, tc.BasePDSpec().ConfigUpdateStrategy(), inUseName, newCm, isPDOnlineConfigItem, func(items map[string]interface{}) error {
+			return m.applyPDConfigOnline(tc, items)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(applied) > 0 {
+			tc.Status.PD.OnlineConfig = &v1alpha1.OnlineConfigStatus{
+				Items:           applied,
+				LastAppliedTime: metav1.Now(),
+			}
+			m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, ConfigAppliedOnline, "pd config items %s are applied online", strings.Join(applied, ", "))
+		}
+		return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+	}
+
 	err = updateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BasePDSpec().ConfigUpdateStrategy(), inUseName, newCm)
 	if err != nil {
 		return nil, err
@@ -426,6 +463,15 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
+// applyPDConfigOnline updates the config through the PD cluster, the items
+// are persisted by PD and take effect in all members
+func (m *pdMemberManager) applyPDConfigOnline(tc *v1alpha1.TidbCluster, items map[string]interface{}) error {
+	if err := controller.GetPDClient(m.deps.PDControl, tc).UpdateConfig(items); err != nil {
+		return fmt.Errorf("failed to apply config online to pd of tidbcluster %s/%s: %v", tc.Namespace, tc.Name, err)
+	}
+	return nil
+}
+
 func (m *pdMemberManager) getNewPDServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Service {
 	ns := tc.Namespace
 	tcName := tc.Name
@@ -42,6 +42,10 @@ func (s *pdScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet *a
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
 	} else if scaling < 0 {
+		if deferToMaintenanceWindow(meta, v1alpha1.PDMemberType, v1alpha1.MaintenanceOperationScaleIn) {
+			resetReplicas(newSet, oldSet)
+			return nil
+		}
 		return s.ScaleIn(meta, oldSet, newSet)
 	}
 	return nil
@@ -68,6 +72,7 @@ func (s *pdScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		return fmt.Errorf("TidbCluster: %s/%s's pd status sync failed, can't scale out now", ns, tcName)
 	}
 
+	recordScaled(s.deps.Recorder, meta, v1alpha1.PDMemberType, ordinal, true)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -94,6 +99,7 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 	klog.Infof("scaling in pd statefulset %s/%s, ordinal: %d (replicas: %d, delete slots: %v)", oldSet.Namespace, oldSet.Name, ordinal, replicas, deleteSlots.List())
 
 	if s.deps.CLIConfig.PodWebhookEnabled {
+		recordScaled(s.deps.Recorder, meta, v1alpha1.PDMemberType, ordinal, false)
 		setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 		return nil
 	}
@@ -127,6 +133,7 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 			if err != nil {
 				return err
 			}
+			s.deps.Recorder.Eventf(tc, v1.EventTypeNormal, PDLeaderTransferred, "pd leader %s is being scaled in, transferred to %s", memberName, targetPdName)
 		} else {
 			for _, member := range tc.Status.PD.PeerMembers {
 				if member.Health && member.Name != memberName {
@@ -134,6 +141,7 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 					if err != nil {
 						return err
 					}
+					s.deps.Recorder.Eventf(tc, v1.EventTypeNormal, PDLeaderTransferred, "pd leader %s is being scaled in, transferred to %s", memberName, member.Name)
 					return controller.RequeueErrorf("tc[%s/%s]'s pd pod[%s/%s] is transferring pd leader,can't scale-in now", ns, tcName, ns, memberName)
 				}
 			}
@@ -146,6 +154,7 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		return err
 	}
 	klog.Infof("pdScaler.ScaleIn: delete member %s successfully", memberName)
+	s.deps.Recorder.Eventf(tc, v1.EventTypeNormal, PDMemberDeleted, "member %s of pd pod %s is deleted from PD cluster", memberName, pdPodName)
 
 	pod, err := s.deps.PodLister.Pods(ns).Get(pdPodName)
 	if err != nil {
@@ -163,6 +172,7 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		}
 	}
 
+	recordScaled(s.deps.Recorder, meta, v1alpha1.PDMemberType, ordinal, false)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -183,7 +193,7 @@ func (s *pdScaler) preCheckUpMembers(tc *v1alpha1.TidbCluster, podName string) b
 	if upComponents != 0 && tc.Spec.PD.Replicas == 0 {
 		errMsg := fmt.Sprintf("The PD is in use by TidbCluster [%s/%s], can't scale in PD, podname %s", tc.GetNamespace(), tc.GetName(), podName)
 		klog.Error(errMsg)
-		s.deps.Recorder.Event(tc, v1.EventTypeWarning, "FailedScaleIn", errMsg)
+		s.deps.Recorder.Event(tc, v1.EventTypeWarning, FailedScaleIn, errMsg)
 		return false
 	}
 
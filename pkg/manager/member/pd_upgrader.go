@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog"
 )
 
@@ -55,6 +56,11 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 		return nil
 	}
 
+	if tc.Status.PD.Phase != v1alpha1.UpgradePhase &&
+		deferToMaintenanceWindow(tc, v1alpha1.PDMemberType, v1alpha1.MaintenanceOperationUpgrade) {
+		return keepLastAppliedPodTemplate(oldSet, newSet)
+	}
+
 	tc.Status.PD.Phase = v1alpha1.UpgradePhase
 	if !templateEqual(newSet, oldSet) {
 		return nil
@@ -97,6 +103,7 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 		}
 
 		if u.deps.CLIConfig.PodWebhookEnabled {
+			recordUpgrading(u.deps.Recorder, tc, v1alpha1.PDMemberType, newSet, i)
 			setUpgradePartition(newSet, i)
 			return nil
 		}
@@ -138,9 +145,11 @@ func (u *pdUpgrader) upgradePDPod(tc *v1alpha1.TidbCluster, ordinal int32, newSe
 				return err
 			}
 			klog.Infof("pd upgrader: transfer pd leader to: %s successfully", targetName)
+			u.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, PDLeaderTransferred, "pd leader %s is being upgraded, transferred to %s", upgradePodName, targetName)
 			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s pd member: [%s] is transferring leader to pd member: [%s]", ns, tcName, upgradePdName, targetName)
 		}
 	}
+	recordUpgrading(u.deps.Recorder, tc, v1alpha1.PDMemberType, newSet, ordinal)
 	setUpgradePartition(newSet, ordinal)
 	return nil
 }
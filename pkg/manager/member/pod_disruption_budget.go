@@ -0,0 +1,161 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// defaultRegionMaxReplicas is the default number of replicas for each region in PD
+	defaultRegionMaxReplicas = 3
+)
+
+// syncPodDisruptionBudget creates or updates the PodDisruptionBudget of the component,
+// the PodDisruptionBudget is deleted if the component is not specified in the TidbCluster or
+// the PodDisruptionBudget feature is disabled.
+func syncPodDisruptionBudget(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) error {
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for %s pdb", tc.GetNamespace(), tc.GetName(), memberType)
+		return nil
+	}
+	if !features.DefaultFeatureGate.Enabled(features.PodDisruptionBudget) {
+		// clean up the pdb created before the feature is disabled, the operator may be
+		// not allowed to access pdbs if the feature has never been enabled
+		if err := deletePodDisruptionBudget(deps, tc, memberType); err != nil && !errors.IsForbidden(err) {
+			return fmt.Errorf("syncPodDisruptionBudget: failed to delete %s pdb of cluster %s/%s, error: %v", memberType, tc.GetNamespace(), tc.GetName(), err)
+		}
+		return nil
+	}
+
+	pdb := getNewPodDisruptionBudget(tc, memberType)
+	if pdb == nil {
+		return deletePodDisruptionBudget(deps, tc, memberType)
+	}
+	_, err := deps.TypedControl.CreateOrUpdatePodDisruptionBudget(tc, pdb)
+	if err != nil {
+		return fmt.Errorf("syncPodDisruptionBudget: failed to sync %s pdb of cluster %s/%s, error: %v", memberType, tc.GetNamespace(), tc.GetName(), err)
+	}
+	return nil
+}
+
+// deletePodDisruptionBudget deletes the PodDisruptionBudget of the component if it exists
+func deletePodDisruptionBudget(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) error {
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	exist, err := deps.TypedControl.Exist(client.ObjectKey{
+		Namespace: tc.GetNamespace(),
+		Name:      podDisruptionBudgetName(tc.GetName(), memberType),
+	}, pdb)
+	if err != nil || !exist {
+		return err
+	}
+	if !metav1.IsControlledBy(pdb, tc) {
+		return nil
+	}
+	klog.Infof("%s of cluster %s/%s is removed, delete pdb %s", memberType, tc.GetNamespace(), tc.GetName(), pdb.GetName())
+	return deps.TypedControl.Delete(tc, pdb)
+}
+
+// getNewPodDisruptionBudget returns the desired PodDisruptionBudget of the component,
+// or nil if the component is not specified in the TidbCluster.
+//   - PD: minAvailable is the majority of the members, so that the quorum is kept
+//   - TiKV: maxUnavailable is the minority of the region replicas, so that each region keeps
+//     the majority of its replicas, and at least 1 so that nodes can still be drained when
+//     there are fewer than 3 replicas
+//   - TiDB: maxUnavailable is 1, so that the TiDB servers are evicted one by one
+func getNewPodDisruptionBudget(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) *policyv1beta1.PodDisruptionBudget {
+	tcName := tc.GetName()
+	instanceName := tc.GetInstanceName()
+
+	var l label.Label
+	spec := policyv1beta1.PodDisruptionBudgetSpec{}
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		if tc.Spec.PD == nil {
+			return nil
+		}
+		l = label.New().Instance(instanceName).PD()
+		replicas := tc.PDStsDesiredReplicas()
+		if tc.Status.PD.StatefulSet != nil && tc.Status.PD.StatefulSet.Replicas > replicas {
+			// scaling in, the members to be removed are still counted in the quorum
+			replicas = tc.Status.PD.StatefulSet.Replicas
+		}
+		minAvailable := intstr.FromInt(int(replicas/2 + 1))
+		spec.MinAvailable = &minAvailable
+	case v1alpha1.TiKVMemberType:
+		if tc.Spec.TiKV == nil {
+			return nil
+		}
+		l = label.New().Instance(instanceName).TiKV()
+		minority := (regionMaxReplicas(tc) - 1) / 2
+		if minority < 1 {
+			// the majority can't be kept anyway, evict the stores one by one
+			minority = 1
+		}
+		maxUnavailable := intstr.FromInt(int(minority))
+		spec.MaxUnavailable = &maxUnavailable
+	case v1alpha1.TiDBMemberType:
+		if tc.Spec.TiDB == nil {
+			return nil
+		}
+		l = label.New().Instance(instanceName).TiDB()
+		maxUnavailable := intstr.FromInt(1)
+		spec.MaxUnavailable = &maxUnavailable
+	default:
+		return nil
+	}
+	spec.Selector = l.LabelSelector()
+
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            podDisruptionBudgetName(tcName, memberType),
+			Namespace:       tc.GetNamespace(),
+			Labels:          l.Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: spec,
+	}
+}
+
+// regionMaxReplicas returns the number of replicas for each region configured in the PD spec
+func regionMaxReplicas(tc *v1alpha1.TidbCluster) int64 {
+	if tc.Spec.PD == nil || tc.Spec.PD.Config == nil {
+		return defaultRegionMaxReplicas
+	}
+	v := tc.Spec.PD.Config.Get("replication.max-replicas")
+	if v == nil {
+		return defaultRegionMaxReplicas
+	}
+	maxReplicas, err := v.AsInt()
+	if err != nil || maxReplicas <= 0 {
+		klog.Warningf("invalid replication.max-replicas %v of cluster %s/%s, use the default value %d", v.Interface(), tc.GetNamespace(), tc.GetName(), defaultRegionMaxReplicas)
+		return defaultRegionMaxReplicas
+	}
+	return maxReplicas
+}
+
+func podDisruptionBudgetName(tcName string, memberType v1alpha1.MemberType) string {
+	return fmt.Sprintf("%s-%s", tcName, memberType.String())
+}
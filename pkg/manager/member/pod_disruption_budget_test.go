@@ -0,0 +1,172 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetNewPodDisruptionBudget(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPodDisruptionBudget()
+
+	pdb := getNewPodDisruptionBudget(tc, v1alpha1.PDMemberType)
+	g.Expect(pdb.Name).To(Equal("test-pd"))
+	g.Expect(pdb.Spec.MinAvailable).To(Equal(intstrPtr(intstr.FromInt(2))))
+	g.Expect(pdb.Spec.MaxUnavailable).To(BeNil())
+	g.Expect(pdb.Spec.Selector.MatchLabels).To(HaveKeyWithValue("app.kubernetes.io/component", "pd"))
+
+	// scaling in from 5 to 3, the quorum of 5 members is kept
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{Replicas: 5}
+	pdb = getNewPodDisruptionBudget(tc, v1alpha1.PDMemberType)
+	g.Expect(pdb.Spec.MinAvailable).To(Equal(intstrPtr(intstr.FromInt(3))))
+
+	pdb = getNewPodDisruptionBudget(tc, v1alpha1.TiKVMemberType)
+	g.Expect(pdb.Name).To(Equal("test-tikv"))
+	g.Expect(pdb.Spec.MinAvailable).To(BeNil())
+	g.Expect(pdb.Spec.MaxUnavailable).To(Equal(intstrPtr(intstr.FromInt(1))))
+
+	tc.Spec.PD.Config.Set("replication.max-replicas", 5)
+	pdb = getNewPodDisruptionBudget(tc, v1alpha1.TiKVMemberType)
+	g.Expect(pdb.Spec.MaxUnavailable).To(Equal(intstrPtr(intstr.FromInt(2))))
+
+	// fewer than 3 replicas, the stores are still allowed to be evicted one by one
+	for _, maxReplicas := range []int{1, 2} {
+		tc.Spec.PD.Config.Set("replication.max-replicas", maxReplicas)
+		pdb = getNewPodDisruptionBudget(tc, v1alpha1.TiKVMemberType)
+		g.Expect(pdb.Spec.MaxUnavailable).To(Equal(intstrPtr(intstr.FromInt(1))))
+	}
+
+	pdb = getNewPodDisruptionBudget(tc, v1alpha1.TiDBMemberType)
+	g.Expect(pdb.Name).To(Equal("test-tidb"))
+	g.Expect(pdb.Spec.MaxUnavailable).To(Equal(intstrPtr(intstr.FromInt(1))))
+
+	tc.Spec.TiDB = nil
+	g.Expect(getNewPodDisruptionBudget(tc, v1alpha1.TiDBMemberType)).To(BeNil())
+	g.Expect(getNewPodDisruptionBudget(tc, v1alpha1.TiFlashMemberType)).To(BeNil())
+}
+
+func TestSyncPodDisruptionBudget(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	features.DefaultFeatureGate.Set("PodDisruptionBudget=true")
+	defer features.DefaultFeatureGate.Set("PodDisruptionBudget=false")
+
+	deps := controller.NewFakeDependencies()
+	cli := deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+	tc := newTidbClusterForPodDisruptionBudget()
+	key := client.ObjectKey{Namespace: tc.Namespace, Name: "test-pd"}
+
+	g.Expect(syncPodDisruptionBudget(deps, tc, v1alpha1.PDMemberType)).To(Succeed())
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	g.Expect(cli.Get(context.TODO(), key, pdb)).To(Succeed())
+	g.Expect(pdb.Spec.MinAvailable).To(Equal(intstrPtr(intstr.FromInt(2))))
+	g.Expect(metav1.IsControlledBy(pdb, tc)).To(BeTrue())
+
+	// scale out
+	tc.Spec.PD.Replicas = 5
+	g.Expect(syncPodDisruptionBudget(deps, tc, v1alpha1.PDMemberType)).To(Succeed())
+	g.Expect(cli.Get(context.TODO(), key, pdb)).To(Succeed())
+	g.Expect(pdb.Spec.MinAvailable).To(Equal(intstrPtr(intstr.FromInt(3))))
+
+	// disable pd
+	tc.Spec.PD = nil
+	g.Expect(syncPodDisruptionBudget(deps, tc, v1alpha1.PDMemberType)).To(Succeed())
+	err := cli.Get(context.TODO(), key, pdb)
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	// nothing to delete
+	g.Expect(syncPodDisruptionBudget(deps, tc, v1alpha1.PDMemberType)).To(Succeed())
+}
+
+func TestSyncPodDisruptionBudgetFeatureDisabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	features.DefaultFeatureGate.Set("PodDisruptionBudget=true")
+	defer features.DefaultFeatureGate.Set("PodDisruptionBudget=false")
+
+	deps := controller.NewFakeDependencies()
+	cli := deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+	tc := newTidbClusterForPodDisruptionBudget()
+	key := client.ObjectKey{Namespace: tc.Namespace, Name: "test-tikv"}
+
+	g.Expect(syncPodDisruptionBudget(deps, tc, v1alpha1.TiKVMemberType)).To(Succeed())
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	g.Expect(cli.Get(context.TODO(), key, pdb)).To(Succeed())
+
+	// the pdb is kept while the cluster is paused
+	features.DefaultFeatureGate.Set("PodDisruptionBudget=false")
+	tc.Spec.Paused = true
+	g.Expect(syncPodDisruptionBudget(deps, tc, v1alpha1.TiKVMemberType)).To(Succeed())
+	g.Expect(cli.Get(context.TODO(), key, pdb)).To(Succeed())
+
+	// the pdb is deleted once the feature is disabled
+	tc.Spec.Paused = false
+	g.Expect(syncPodDisruptionBudget(deps, tc, v1alpha1.TiKVMemberType)).To(Succeed())
+	err := cli.Get(context.TODO(), key, pdb)
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	// the pdb not owned by the cluster is left alone
+	other := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+	}
+	g.Expect(cli.Create(context.TODO(), other)).To(Succeed())
+	g.Expect(syncPodDisruptionBudget(deps, tc, v1alpha1.TiKVMemberType)).To(Succeed())
+	g.Expect(cli.Get(context.TODO(), key, pdb)).To(Succeed())
+}
+
+func newTidbClusterForPodDisruptionBudget() *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "TidbCluster",
+			APIVersion: "pingcap.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: corev1.NamespaceDefault,
+			UID:       types.UID("test"),
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD: &v1alpha1.PDSpec{
+				Replicas: 3,
+				Config:   v1alpha1.NewPDConfig(),
+			},
+			TiKV: &v1alpha1.TiKVSpec{
+				Replicas: 3,
+			},
+			TiDB: &v1alpha1.TiDBSpec{
+				Replicas: 2,
+			},
+		},
+	}
+}
+
+func intstrPtr(v intstr.IntOrString) *intstr.IntOrString {
+	return &v
+}
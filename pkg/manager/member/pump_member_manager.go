@@ -172,6 +172,7 @@ func (m *pumpMemberManager) syncTiDBClusterStatus(tc *v1alpha1.TidbCluster, set
 		tc.Status.Pump.Phase = v1alpha1.UpgradePhase
 	} else {
 		tc.Status.Pump.Phase = v1alpha1.NormalPhase
+		endOperations(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.PumpMemberType, v1alpha1.OperationSucceeded)
 	}
 
 	client, err := m.buildBinlogClient(tc, m.deps.PDControl)
@@ -46,6 +46,10 @@ func (s *pumpScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
 	} else if scaling < 0 {
+		if deferToMaintenanceWindow(meta, v1alpha1.PumpMemberType, v1alpha1.MaintenanceOperationScaleIn) {
+			resetReplicas(newSet, oldSet)
+			return nil
+		}
 		return s.ScaleIn(meta, oldSet, newSet)
 	}
 
@@ -78,6 +82,7 @@ func (s *pumpScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, newS
 	} else if !errors.IsNotFound(err) {
 		return fmt.Errorf("pump.ScaleOut, cluster %s/%s failed to fetch pvc informaiton, err:%v", meta.GetNamespace(), meta.GetName(), err)
 	}
+	recordScaled(s.deps.Recorder, meta, v1alpha1.PumpMemberType, ordinal, true)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -206,6 +211,7 @@ func (s *pumpScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSe
 					return err
 				}
 			}
+			recordScaled(s.deps.Recorder, meta, v1alpha1.PumpMemberType, ordinal, false)
 			setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 			return nil
 		} else {
@@ -242,6 +248,7 @@ func (s *pumpScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSe
 			}
 		}
 
+		recordScaled(s.deps.Recorder, meta, v1alpha1.PumpMemberType, ordinal, false)
 		setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 		return nil
 	}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog"
@@ -111,7 +112,7 @@ func (p *pvcResizer) Resize(tc *v1alpha1.TidbCluster) error {
 				klog.Warningf("StorageVolume %q in %s/%s .Spec.PD is invalid", sv.Name, ns, tc.Name)
 			}
 		}
-		if err := p.patchPVCs(ns, selector.Add(*pdRequirement), pvcPrefix2Quantity); err != nil {
+		if err := p.patchPVCs(tc, ns, selector.Add(*pdRequirement), pvcPrefix2Quantity); err != nil {
 			return err
 		}
 	}
@@ -127,7 +128,7 @@ func (p *pvcResizer) Resize(tc *v1alpha1.TidbCluster) error {
 				klog.Warningf("StorageVolume %q in %s/%s .Spec.TiDB is invalid", sv.Name, ns, tc.Name)
 			}
 		}
-		if err := p.patchPVCs(ns, selector.Add(*tidbRequirement), pvcPrefix2Quantity); err != nil {
+		if err := p.patchPVCs(tc, ns, selector.Add(*tidbRequirement), pvcPrefix2Quantity); err != nil {
 			return err
 		}
 	}
@@ -147,7 +148,7 @@ func (p *pvcResizer) Resize(tc *v1alpha1.TidbCluster) error {
 				klog.Warningf("StorageVolume %q in %s/%s .Spec.TiKV is invalid", sv.Name, ns, tc.Name)
 			}
 		}
-		if err := p.patchPVCs(ns, selector.Add(*tikvRequirement), pvcPrefix2Quantity); err != nil {
+		if err := p.patchPVCs(tc, ns, selector.Add(*tikvRequirement), pvcPrefix2Quantity); err != nil {
 			return err
 		}
 	}
@@ -161,7 +162,7 @@ func (p *pvcResizer) Resize(tc *v1alpha1.TidbCluster) error {
 				pvcPrefix2Quantity[key] = quantity
 			}
 		}
-		if err := p.patchPVCs(ns, selector.Add(*tiflashRequirement), pvcPrefix2Quantity); err != nil {
+		if err := p.patchPVCs(tc, ns, selector.Add(*tiflashRequirement), pvcPrefix2Quantity); err != nil {
 			return err
 		}
 	}
@@ -177,7 +178,7 @@ func (p *pvcResizer) Resize(tc *v1alpha1.TidbCluster) error {
 				klog.Warningf("StorageVolume %q in %s/%s .Spec.TiCDC is invalid", sv.Name, ns, tc.Name)
 			}
 		}
-		if err := p.patchPVCs(ns, selector.Add(*ticdcRequirement), pvcPrefix2Quantity); err != nil {
+		if err := p.patchPVCs(tc, ns, selector.Add(*ticdcRequirement), pvcPrefix2Quantity); err != nil {
 			return err
 		}
 	}
@@ -189,7 +190,7 @@ func (p *pvcResizer) Resize(tc *v1alpha1.TidbCluster) error {
 			key := fmt.Sprintf("data-%s-%s", tc.Name, pumpMemberType)
 			pvcPrefix2Quantity[key] = quantity
 		}
-		if err := p.patchPVCs(ns, selector.Add(*pumpRequirement), pvcPrefix2Quantity); err != nil {
+		if err := p.patchPVCs(tc, ns, selector.Add(*pumpRequirement), pvcPrefix2Quantity); err != nil {
 			return err
 		}
 	}
@@ -212,7 +213,7 @@ func (p *pvcResizer) ResizeDM(dc *v1alpha1.DMCluster) error {
 			key := fmt.Sprintf("%s-%s-%s", dmMasterMemberType, dc.Name, dmMasterMemberType)
 			pvcPrefix2Quantity[key] = quantity
 		}
-		if err := p.patchPVCs(ns, selector.Add(*dmMasterRequirement), pvcPrefix2Quantity); err != nil {
+		if err := p.patchPVCs(dc, ns, selector.Add(*dmMasterRequirement), pvcPrefix2Quantity); err != nil {
 			return err
 		}
 	}
@@ -225,7 +226,7 @@ func (p *pvcResizer) ResizeDM(dc *v1alpha1.DMCluster) error {
 			key := fmt.Sprintf("%s-%s-%s", dmWorkerMemberType, dc.Name, dmWorkerMemberType)
 			pvcPrefix2Quantity[key] = quantity
 		}
-		if err := p.patchPVCs(ns, selector.Add(*dmWorkerRequirement), pvcPrefix2Quantity); err != nil {
+		if err := p.patchPVCs(dc, ns, selector.Add(*dmWorkerRequirement), pvcPrefix2Quantity); err != nil {
 			return err
 		}
 	}
@@ -244,7 +245,7 @@ func (p *pvcResizer) isVolumeExpansionSupported(storageClassName string) (bool,
 }
 
 // patchPVCs patches PVCs filtered by selector and prefix.
-func (p *pvcResizer) patchPVCs(ns string, selector labels.Selector, pvcQuantityInSpec map[string]resource.Quantity) error {
+func (p *pvcResizer) patchPVCs(obj runtime.Object, ns string, selector labels.Selector, pvcQuantityInSpec map[string]resource.Quantity) error {
 	if len(pvcQuantityInSpec) == 0 {
 		return nil
 	}
@@ -283,6 +284,7 @@ func (p *pvcResizer) patchPVCs(ns string, selector labels.Selector, pvcQuantityI
 				}
 				if !volumeExpansionSupported {
 					klog.Warningf("Storage Class %q used by PVC %s/%s does not support volume expansion, skipped", *pvc.Spec.StorageClassName, pvc.Namespace, pvc.Name)
+					p.deps.Recorder.Eventf(obj, corev1.EventTypeWarning, FailedResizePVC, "storage class %s used by PVC %s does not support volume expansion", *pvc.Spec.StorageClassName, pvc.Name)
 					continue
 				}
 			} else {
@@ -303,11 +305,14 @@ func (p *pvcResizer) patchPVCs(ns string, selector labels.Selector, pvcQuantityI
 			}
 			_, err = p.deps.KubeClientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Patch(context.TODO(), pvc.Name, types.MergePatchType, mergePatch, metav1.PatchOptions{})
 			if err != nil {
+				p.deps.Recorder.Eventf(obj, corev1.EventTypeWarning, FailedResizePVC, "failed to update storage request of PVC %s from %s to %s: %v", pvc.Name, currentRequest.String(), quantityInSpec.String(), err)
 				return err
 			}
 			klog.V(2).Infof("PVC %s/%s storage request is updated from %s to %s", pvc.Namespace, pvc.Name, currentRequest.String(), quantityInSpec.String())
+			p.deps.Recorder.Eventf(obj, corev1.EventTypeNormal, PVCResizing, "storage request of PVC %s is updated from %s to %s", pvc.Name, currentRequest.String(), quantityInSpec.String())
 		} else if quantityInSpec.Cmp(currentRequest) < 0 {
 			klog.Warningf("PVC %s/%s/ storage request cannot be shrunk (%s to %s), skipped", pvc.Namespace, pvc.Name, currentRequest.String(), quantityInSpec.String())
+			p.deps.Recorder.Eventf(obj, corev1.EventTypeWarning, FailedResizePVC, "storage request of PVC %s cannot be shrunk from %s to %s", pvc.Name, currentRequest.String(), quantityInSpec.String())
 		} else {
 			klog.V(4).Infof("PVC %s/%s storage request is already %s, skipped", pvc.Namespace, pvc.Name, quantityInSpec.String())
 		}
@@ -218,6 +218,7 @@ func (m *ticdcMemberManager) syncTiCDCStatus(tc *v1alpha1.TidbCluster, sts *apps
 		tc.Status.TiCDC.Phase = v1alpha1.UpgradePhase
 	} else {
 		tc.Status.TiCDC.Phase = v1alpha1.NormalPhase
+		endOperations(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.TiCDCMemberType, v1alpha1.OperationSucceeded)
 	}
 
 	ticdcCaptures := map[string]v1alpha1.TiCDCCapture{}
@@ -42,6 +42,10 @@ func (s *ticdcScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
 	} else if scaling < 0 {
+		if deferToMaintenanceWindow(meta, v1alpha1.TiCDCMemberType, v1alpha1.MaintenanceOperationScaleIn) {
+			resetReplicas(newSet, oldSet)
+			return nil
+		}
 		return s.ScaleIn(meta, oldSet, newSet)
 	}
 	return nil
@@ -64,6 +68,7 @@ func (s *ticdcScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, new
 		// wait for all PVCs to be deleted
 		return controller.RequeueErrorf("ticdc.ScaleOut, cluster %s/%s ready to scale out, skip reason %v, wait for next round", meta.GetNamespace(), meta.GetName(), skipReason)
 	}
+	recordScaled(s.deps.Recorder, meta, v1alpha1.TiCDCMemberType, ordinal, true)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -104,6 +109,7 @@ func (s *ticdcScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newS
 		}
 	}
 
+	recordScaled(s.deps.Recorder, meta, v1alpha1.TiCDCMemberType, ordinal, false)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
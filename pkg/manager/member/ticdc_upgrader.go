@@ -63,6 +63,11 @@ func (u *ticdcUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulS
 		return nil
 	}
 
+	if tc.Status.TiCDC.Phase != v1alpha1.UpgradePhase &&
+		deferToMaintenanceWindow(tc, v1alpha1.TiCDCMemberType, v1alpha1.MaintenanceOperationUpgrade) {
+		return keepLastAppliedPodTemplate(oldSet, newSet)
+	}
+
 	tc.Status.TiCDC.Phase = v1alpha1.UpgradePhase
 	if !templateEqual(newSet, oldSet) {
 		return nil
@@ -102,6 +107,7 @@ func (u *ticdcUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulS
 			}
 			continue
 		}
+		recordUpgrading(u.deps.Recorder, tc, v1alpha1.TiCDCMemberType, newSet, i)
 		setUpgradePartition(newSet, i)
 		return nil
 	}
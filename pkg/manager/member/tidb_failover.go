@@ -90,7 +90,9 @@ func (f *tidbFailover) Failover(tc *v1alpha1.TidbCluster) error {
 				CreatedAt: metav1.Now(),
 			}
 			msg := fmt.Sprintf("tidb[%s] is unhealthy", tidbMember.Name)
-			f.deps.Recorder.Event(tc, corev1.EventTypeWarning, unHealthEventReason, fmt.Sprintf(unHealthEventMsgPattern, "tidb", tidbMember.Name, msg))
+			f.deps.Recorder.Event(tc, corev1.EventTypeWarning, Unhealthy, fmt.Sprintf(unHealthEventMsgPattern, "tidb", tidbMember.Name, msg))
+			f.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, FailoverReplicaAdded, "add a tidb replica to replace failure member %s", tidbMember.Name)
+			startOperation(tc, v1alpha1.OperationTypeFailover, v1alpha1.TiDBMemberType, tidbMember.Name, msg)
 			break
 		}
 	}
@@ -99,7 +101,11 @@ func (f *tidbFailover) Failover(tc *v1alpha1.TidbCluster) error {
 }
 
 func (f *tidbFailover) Recover(tc *v1alpha1.TidbCluster) {
+	if len(tc.Status.TiDB.FailureMembers) > 0 {
+		f.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, FailoverRecovered, "tidb failure members recovered, remove the %d replicas added for failover", len(tc.Status.TiDB.FailureMembers))
+	}
 	tc.Status.TiDB.FailureMembers = nil
+	endOperations(tc, v1alpha1.OperationTypeFailover, v1alpha1.TiDBMemberType, v1alpha1.OperationSucceeded)
 }
 
 func (f *tidbFailover) RemoveUndesiredFailures(tc *v1alpha1.TidbCluster) {
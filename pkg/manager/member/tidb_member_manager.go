@@ -73,9 +73,9 @@ func NewTiDBMemberManager(deps *controller.Dependencies, scaler Scaler, tidbUpgr
 }
 
 func (m *tidbMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
-	// If tidb is not specified return
+	// If tidb is not specified, remove its pdb and return
 	if tc.Spec.TiDB == nil {
-		return nil
+		return syncPodDisruptionBudget(m.deps, tc, v1alpha1.TiDBMemberType)
 	}
 
 	ns := tc.GetNamespace()
@@ -108,7 +108,12 @@ func (m *tidbMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 	}
 
 	// Sync TiDB StatefulSet
-	return m.syncTiDBStatefulSetForTidbCluster(tc)
+	if err := m.syncTiDBStatefulSetForTidbCluster(tc); err != nil {
+		return err
+	}
+
+	// Sync TiDB PodDisruptionBudget
+	return syncPodDisruptionBudget(m.deps, tc, v1alpha1.TiDBMemberType)
 }
 
 func (m *tidbMemberManager) checkTLSClientCert(tc *v1alpha1.TidbCluster) error {
@@ -217,6 +222,10 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 		return nil
 	}
 
+	if err := keepPickedDeleteSlots(oldTiDBSet, newTiDBSet); err != nil {
+		return err
+	}
+
 	// Scaling takes precedence over upgrading because:
 	// - if a pod fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -227,8 +236,10 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	}
 
 	if m.deps.CLIConfig.AutoFailover {
-		if m.shouldRecover(tc) {
-			m.tidbFailover.Recover(tc)
+		if m.shouldRecover(tc, newTiDBSet) {
+			if !deferToMaintenanceWindow(tc, v1alpha1.TiDBMemberType, v1alpha1.MaintenanceOperationFailoverRecovery) {
+				m.tidbFailover.Recover(tc)
+			}
 		} else if tc.TiDBAllPodsStarted() && !tc.TiDBAllMembersReady() {
 			if err := m.tidbFailover.Failover(tc); err != nil {
 				return err
@@ -245,7 +256,7 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	return UpdateStatefulSet(m.deps.StatefulSetControl, tc, newTiDBSet, oldTiDBSet)
 }
 
-func (m *tidbMemberManager) shouldRecover(tc *v1alpha1.TidbCluster) bool {
+func (m *tidbMemberManager) shouldRecover(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) bool {
 	if tc.Status.TiDB.FailureMembers == nil {
 		return false
 	}
@@ -253,7 +264,9 @@ func (m *tidbMemberManager) shouldRecover(tc *v1alpha1.TidbCluster) bool {
 	// healthy, we can perform our failover recovery operation.
 	// Note that failover pods may fail (e.g. lack of resources) and we don't care
 	// about them because we're going to delete them.
-	for ordinal := range tc.TiDBStsDesiredOrdinals(true) {
+	// The ordinals are taken from the statefulset, as its delete slots may have
+	// the ones picked to scale in besides the delete slots of the tidb cluster.
+	for ordinal := range helper.GetPodOrdinals(tc.Spec.TiDB.Replicas, set) {
 		name := fmt.Sprintf("%s-%d", controller.TiDBMemberName(tc.GetName()), ordinal)
 		pod, err := m.deps.PodLister.Pods(tc.Namespace).Get(name)
 		if err != nil {
@@ -366,6 +379,23 @@ func (m *tidbMemberManager) syncTiDBConfigMap(tc *v1alpha1.TidbCluster, set *app
 
 	klog.V(3).Info("get tidb in use config map name: ", inUseName)
 
+	if tc.IsDynamicConfigurationEnabled() {
+		applied, err := updateConfigMapOnline(m.deps.ConfigMapLister, tc.BaseTiDBSpec().ConfigUpdateStrategy(), inUseName, newCm, isTiDBOnlineConfigItem, func(items map[string]interface{}) error {
+			return m.applyTiDBConfigOnline(tc, items)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(applied) > 0 {
+			tc.Status.TiDB.OnlineConfig = &v1alpha1.OnlineConfigStatus{
+				Items:           applied,
+				LastAppliedTime: metav1.Now(),
+			}
+			m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, ConfigAppliedOnline, "tidb config items %s are applied online", strings.Join(applied, ", "))
+		}
+		return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+	}
+
 	err = updateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BaseTiDBSpec().ConfigUpdateStrategy(), inUseName, newCm)
 	if err != nil {
 		return nil, err
@@ -373,6 +403,37 @@ func (m *tidbMemberManager) syncTiDBConfigMap(tc *v1alpha1.TidbCluster, set *app
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
+// applyTiDBConfigOnline updates the settings of the healthy members, a member
+// that is not healthy loads the config from the configmap when it restarts
+func (m *tidbMemberManager) applyTiDBConfigOnline(tc *v1alpha1.TidbCluster, items map[string]interface{}) error {
+	settings := map[string]string{}
+	for k, v := range items {
+		// the settings API takes 0 and 1 as the boolean values
+		switch b, ok := v.(bool); {
+		case ok && b:
+			settings[tidbOnlineConfigItems[k]] = "1"
+		case ok:
+			settings[tidbOnlineConfigItems[k]] = "0"
+		default:
+			settings[tidbOnlineConfigItems[k]] = fmt.Sprint(v)
+		}
+	}
+	for _, member := range tc.Status.TiDB.Members {
+		if !member.Health {
+			klog.Infof("skip applying config online to tidb %s/%s, it's not healthy", tc.Namespace, member.Name)
+			continue
+		}
+		ordinal, err := util.GetOrdinalFromPodName(member.Name)
+		if err != nil {
+			return err
+		}
+		if err := m.deps.TiDBControl.UpdateSettings(tc, ordinal, settings); err != nil {
+			return fmt.Errorf("failed to apply config online to tidb %s/%s: %v", tc.Namespace, member.Name, err)
+		}
+	}
+	return nil
+}
+
 func getTiDBConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	config := tc.Spec.TiDB.Config
 	if config == nil {
@@ -852,6 +913,7 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 		tc.Status.TiDB.Phase = v1alpha1.UpgradePhase
 	} else {
 		tc.Status.TiDB.Phase = v1alpha1.NormalPhase
+		endOperations(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.TiDBMemberType, v1alpha1.OperationSucceeded)
 	}
 
 	tidbStatus := map[string]v1alpha1.TiDBMember{}
@@ -867,6 +929,13 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 			Health: health,
 		}
 		oldTidbMember, exist := tc.Status.TiDB.Members[name]
+		if health {
+			m.syncTiDBMemberDetails(tc, int32(id), &newTidbMember)
+		} else if exist {
+			// keep the last known version, the connections and the ownership are meaningless for an unhealthy member
+			newTidbMember.Version = oldTidbMember.Version
+			newTidbMember.GitHash = oldTidbMember.GitHash
+		}
 
 		newTidbMember.LastTransitionTime = metav1.Now()
 		if exist {
@@ -895,6 +964,25 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 	return nil
 }
 
+// syncTiDBMemberDetails fills the version, the DDL ownership and the connections of a healthy TiDB member,
+// failures are only logged to not block the status sync
+func (m *tidbMemberManager) syncTiDBMemberDetails(tc *v1alpha1.TidbCluster, ordinal int32, member *v1alpha1.TiDBMember) {
+	status, err := m.deps.TiDBControl.GetStatus(tc, ordinal)
+	if err != nil {
+		klog.Warningf("failed to get status of tidb %s of cluster %s/%s, error: %v", member.Name, tc.Namespace, tc.Name, err)
+	} else if status != nil {
+		member.Version = status.Version
+		member.GitHash = status.GitHash
+		member.Connections = int32(status.Connections)
+	}
+	info, err := m.deps.TiDBControl.GetInfo(tc, ordinal)
+	if err != nil {
+		klog.Warningf("failed to get info of tidb %s of cluster %s/%s, error: %v", member.Name, tc.Namespace, tc.Name, err)
+	} else if info != nil {
+		member.IsDDLOwner = info.IsOwner
+	}
+}
+
 func tidbStatefulSetIsUpgrading(podLister corelisters.PodLister, set *apps.StatefulSet, tc *v1alpha1.TidbCluster) (bool, error) {
 	if statefulSetIsUpgrading(set) {
 		return true, nil
@@ -352,6 +352,8 @@ func TestTiDBMemberManagerSyncTidbClusterStatus(t *testing.T) {
 		updateSts   func(*apps.StatefulSet)
 		upgradingFn func(corelisters.PodLister, *apps.StatefulSet, *v1alpha1.TidbCluster) (bool, error)
 		healthInfo  map[string]bool
+		statuses    map[string]*controller.DBStatus
+		infos       map[string]*controller.DBInfo
 		errExpectFn func(*GomegaWithT, error)
 		tcExpectFn  func(*GomegaWithT, *v1alpha1.TidbCluster)
 	}
@@ -386,6 +388,8 @@ func TestTiDBMemberManagerSyncTidbClusterStatus(t *testing.T) {
 		if test.healthInfo != nil {
 			tidbControl.SetHealth(test.healthInfo)
 		}
+		tidbControl.SetStatus(test.statuses)
+		tidbControl.SetInfo(test.infos)
 
 		err := pmm.syncTidbClusterStatus(tc, set)
 		if test.errExpectFn != nil {
@@ -544,6 +548,40 @@ func TestTiDBMemberManagerSyncTidbClusterStatus(t *testing.T) {
 				g.Expect(tc.Status.TiDB.Members["test-tidb-2"].LastTransitionTime).NotTo(Equal(now))
 			},
 		},
+		{
+			name: "sync version, DDL owner and connections of healthy members",
+			updateTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{
+					"test-tidb-1": {Health: true, Version: "5.7.25-TiDB-v4.0.8", GitHash: "abc", IsDDLOwner: true, Connections: 3},
+				}
+			},
+			healthInfo: map[string]bool{
+				"test-tidb-0": true,
+			},
+			statuses: map[string]*controller.DBStatus{
+				"test-tidb-0": {Connections: 5, Version: "5.7.25-TiDB-v4.0.9", GitHash: "def"},
+			},
+			infos: map[string]*controller.DBInfo{
+				"test-tidb-0": {IsOwner: true},
+			},
+			upgradingFn: func(lister corelisters.PodLister, set *apps.StatefulSet, cluster *v1alpha1.TidbCluster) (bool, error) {
+				return false, nil
+			},
+			errExpectFn: errExpectNil,
+			tcExpectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				member := tc.Status.TiDB.Members["test-tidb-0"]
+				g.Expect(member.Version).To(Equal("5.7.25-TiDB-v4.0.9"))
+				g.Expect(member.GitHash).To(Equal("def"))
+				g.Expect(member.IsDDLOwner).To(BeTrue())
+				g.Expect(member.Connections).To(Equal(int32(5)))
+				// the version is kept for unhealthy members
+				member = tc.Status.TiDB.Members["test-tidb-1"]
+				g.Expect(member.Health).To(BeFalse())
+				g.Expect(member.Version).To(Equal("5.7.25-TiDB-v4.0.8"))
+				g.Expect(member.IsDDLOwner).To(BeFalse())
+				g.Expect(member.Connections).To(BeZero())
+			},
+		},
 	}
 
 	for i := range tests {
@@ -2262,7 +2300,7 @@ func TestTiDBShouldRecover(t *testing.T) {
 			kubeInformerFactory.Start(ctx.Done())
 			kubeInformerFactory.WaitForCacheSync(ctx.Done())
 			tidbMemberManager := &tidbMemberManager{deps: fakeDeps}
-			got := tidbMemberManager.shouldRecover(tt.tc)
+			got := tidbMemberManager.shouldRecover(tt.tc, &apps.StatefulSet{})
 			if got != tt.want {
 				t.Fatalf("wants %v, got %v", tt.want, got)
 			}
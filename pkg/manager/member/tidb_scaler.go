@@ -14,16 +14,21 @@
 package member
 
 import (
+	"encoding/json"
 	"fmt"
 
 	apps "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog"
 
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
 	"github.com/pingcap/tidb-operator/pkg/util"
 )
 
@@ -42,6 +47,15 @@ func (s *tidbScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
 	} else if scaling < 0 {
+		if deferToMaintenanceWindow(meta, v1alpha1.TiDBMemberType, v1alpha1.MaintenanceOperationScaleIn) {
+			resetReplicas(newSet, oldSet)
+			return nil
+		}
+		if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+			if err := s.pickIdlePodToScaleIn(tc, oldSet, newSet); err != nil {
+				return err
+			}
+		}
 		return s.ScaleIn(meta, oldSet, newSet)
 	}
 	return nil
@@ -64,6 +78,7 @@ func (s *tidbScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, newS
 		// wait for all PVCs to be deleted
 		return controller.RequeueErrorf("tidbScaler.ScaleOut, cluster %s/%s ready to scale out, skip reason %v, wait for next round", meta.GetNamespace(), meta.GetName(), skipReason)
 	}
+	recordScaled(s.deps.Recorder, meta, v1alpha1.TiDBMemberType, ordinal, true)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -96,12 +111,126 @@ func (s *tidbScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSe
 		return fmt.Errorf("tidbScaler.ScaleIn: failed to get pvcs for pod %s/%s in tc %s/%s, error: %s", ns, pod.Name, ns, tcName, err)
 	}
 	tc, _ := meta.(*v1alpha1.TidbCluster)
+	if err := resignDDLOwner(s.deps, tc, ordinal); err != nil {
+		return err
+	}
 	for _, pvc := range pvcs {
 		if err := addDeferDeletingAnnoToPVC(tc, pvc, s.deps.PVCControl); err != nil {
 			return err
 		}
 	}
 
+	recordScaled(s.deps.Recorder, meta, v1alpha1.TiDBMemberType, ordinal, false)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
+
+// pickIdlePodToScaleIn picks the healthy TiDB pod with the least connections, which is not the DDL owner, to scale
+// in instead of the pod with the max ordinal, by adding the pod to the delete slots of the new statefulset. The picked
+// slots are recorded in the statefulset to keep them in the following rounds, the delete slots of the TidbCluster
+// are left to the users. It's only possible with AdvancedStatefulSet, and is skipped if the pods to delete are
+// specified by the delete slots or the connections of some members are unknown.
+func (s *tidbScaler) pickIdlePodToScaleIn(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if !features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet) {
+		return nil
+	}
+	if !helper.GetDeleteSlots(newSet).Equal(helper.GetDeleteSlots(oldSet)) {
+		return nil
+	}
+	_, ordinal, _, _ := scaleOne(oldSet, newSet)
+	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
+	// the connections are queried again here as the ones in the status may be out of date
+	connections := map[int32]int{}
+	for _, i := range podOrdinals {
+		if member, exist := tc.Status.TiDB.Members[tidbPodName(tc.GetName(), i)]; !exist || !member.Health {
+			return nil
+		}
+		status, err := s.deps.TiDBControl.GetStatus(tc, i)
+		if err != nil || status == nil {
+			klog.Warningf("tidbScaler.Scale: failed to get status of tidb %s of cluster %s/%s, scale in %s, error: %v",
+				tidbPodName(tc.GetName(), i), tc.GetNamespace(), tc.GetName(), tidbPodName(tc.GetName(), ordinal), err)
+			return nil
+		}
+		connections[i] = status.Connections
+	}
+
+	picked := ordinal
+	pickedIsOwner := tc.Status.TiDB.Members[tidbPodName(tc.GetName(), picked)].IsDDLOwner
+	for _, i := range podOrdinals {
+		if tc.Status.TiDB.Members[tidbPodName(tc.GetName(), i)].IsDDLOwner {
+			continue
+		}
+		if pickedIsOwner || connections[i] < connections[picked] {
+			picked = i
+			pickedIsOwner = false
+		}
+	}
+	if picked == ordinal {
+		return nil
+	}
+
+	deleteSlots := helper.GetDeleteSlots(newSet)
+	deleteSlots.Insert(picked)
+	helper.SetDeleteSlots(newSet, deleteSlots)
+	pickedSlots := getPickedDeleteSlots(oldSet)
+	pickedSlots.Insert(picked)
+	if err := setPickedDeleteSlots(newSet, pickedSlots); err != nil {
+		return err
+	}
+	klog.Infof("tidbScaler.Scale: cluster %s/%s picks tidb pod %s with %d connections to scale in instead of %s",
+		tc.GetNamespace(), tc.GetName(), tidbPodName(tc.GetName(), picked), connections[picked], tidbPodName(tc.GetName(), ordinal))
+	return nil
+}
+
+// keepPickedDeleteSlots adds the delete slots picked by pickIdlePodToScaleIn in the old statefulset to the new one,
+// which only has the delete slots of the TidbCluster. After the scale-in finishes, the picked slots above the max
+// pod ordinal are pruned, they don't keep any pod in place and are free to be reused by the next scale-out.
+func keepPickedDeleteSlots(oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if !features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet) {
+		return nil
+	}
+	pickedSlots := getPickedDeleteSlots(oldSet)
+	if pickedSlots.Len() == 0 {
+		return nil
+	}
+	if oldSet.Status.Replicas == *oldSet.Spec.Replicas {
+		podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
+		for _, slot := range pickedSlots.List() {
+			if len(podOrdinals) == 0 || slot > podOrdinals[len(podOrdinals)-1] {
+				pickedSlots.Delete(slot)
+			}
+		}
+		if pickedSlots.Len() == 0 {
+			delete(newSet.Annotations, label.AnnTiDBPickedDeleteSlots)
+			return nil
+		}
+	}
+	helper.SetDeleteSlots(newSet, helper.GetDeleteSlots(newSet).Union(pickedSlots))
+	return setPickedDeleteSlots(newSet, pickedSlots)
+}
+
+func getPickedDeleteSlots(set *apps.StatefulSet) sets.Int32 {
+	slots := sets.NewInt32()
+	value, ok := set.Annotations[label.AnnTiDBPickedDeleteSlots]
+	if !ok {
+		return slots
+	}
+	var slice []int32
+	if err := json.Unmarshal([]byte(value), &slice); err != nil {
+		klog.Warningf("statefulset %s/%s has invalid %s annotation %q, error: %v", set.Namespace, set.Name, label.AnnTiDBPickedDeleteSlots, value, err)
+		return slots
+	}
+	return slots.Insert(slice...)
+}
+
+func setPickedDeleteSlots(set *apps.StatefulSet, slots sets.Int32) error {
+	value, err := json.Marshal(slots.List())
+	if err != nil {
+		return err
+	}
+	if set.Annotations == nil {
+		set.Annotations = map[string]string{}
+	}
+	set.Annotations[label.AnnTiDBPickedDeleteSlots] = string(value)
+	return nil
+}
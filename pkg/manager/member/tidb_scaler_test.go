@@ -19,15 +19,19 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
 )
 
 func TestTiDBScalerScaleOut(t *testing.T) {
@@ -311,6 +315,199 @@ func TestTiDBScalerScaleIn(t *testing.T) {
 	}
 }
 
+func TestTiDBScalerPickIdlePodToScaleIn(t *testing.T) {
+	g := NewGomegaWithT(t)
+	enabled := features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet)
+	defer features.DefaultFeatureGate.Set(fmt.Sprintf("AdvancedStatefulSet=%t", enabled))
+
+	healthy := v1alpha1.TiDBMember{Health: true}
+	owner := v1alpha1.TiDBMember{Health: true, IsDDLOwner: true}
+	tests := []struct {
+		name                string
+		advancedStatefulSet bool
+		members             map[string]v1alpha1.TiDBMember
+		connections         map[string]int
+		getStatusErr        bool
+		pickedDeleteSlots   string
+		desiredDeleteSlots  []int32
+		expectedDeleteSlots []int32
+		expectedPicked      string
+	}{
+		{
+			name:                "pick the pod with the least connections",
+			advancedStatefulSet: true,
+			members:             map[string]v1alpha1.TiDBMember{"test-tidb-0": healthy, "test-tidb-1": healthy, "test-tidb-2": healthy},
+			connections:         map[string]int{"test-tidb-0": 5, "test-tidb-1": 0, "test-tidb-2": 5},
+			expectedDeleteSlots: []int32{1},
+			expectedPicked:      "[1]",
+		},
+		{
+			name:                "never pick the DDL owner",
+			advancedStatefulSet: true,
+			members:             map[string]v1alpha1.TiDBMember{"test-tidb-0": healthy, "test-tidb-1": owner, "test-tidb-2": healthy},
+			connections:         map[string]int{"test-tidb-0": 2, "test-tidb-1": 0, "test-tidb-2": 5},
+			expectedDeleteSlots: []int32{0},
+			expectedPicked:      "[0]",
+		},
+		{
+			name:                "record the picked slots with the ones picked before",
+			advancedStatefulSet: true,
+			members:             map[string]v1alpha1.TiDBMember{"test-tidb-0": healthy, "test-tidb-1": healthy, "test-tidb-2": healthy},
+			connections:         map[string]int{"test-tidb-0": 5, "test-tidb-1": 0, "test-tidb-2": 5},
+			pickedDeleteSlots:   "[5]",
+			expectedDeleteSlots: []int32{1},
+			expectedPicked:      "[1,5]",
+		},
+		{
+			name:                "keep the pod with the max ordinal if it's the idlest",
+			advancedStatefulSet: true,
+			members:             map[string]v1alpha1.TiDBMember{"test-tidb-0": healthy, "test-tidb-1": healthy, "test-tidb-2": healthy},
+			connections:         map[string]int{"test-tidb-0": 5, "test-tidb-1": 1, "test-tidb-2": 1},
+		},
+		{
+			name:                "status of some members is unknown",
+			advancedStatefulSet: true,
+			members:             map[string]v1alpha1.TiDBMember{"test-tidb-0": healthy, "test-tidb-1": {Health: false}, "test-tidb-2": healthy},
+			connections:         map[string]int{"test-tidb-0": 5, "test-tidb-1": 0, "test-tidb-2": 5},
+		},
+		{
+			name:                "connections of some members are unknown",
+			advancedStatefulSet: true,
+			members:             map[string]v1alpha1.TiDBMember{"test-tidb-0": healthy, "test-tidb-1": healthy, "test-tidb-2": healthy},
+			connections:         map[string]int{"test-tidb-0": 5, "test-tidb-1": 0, "test-tidb-2": 5},
+			getStatusErr:        true,
+		},
+		{
+			name:                "pods to delete are specified by delete slots",
+			advancedStatefulSet: true,
+			members:             map[string]v1alpha1.TiDBMember{"test-tidb-0": healthy, "test-tidb-1": healthy, "test-tidb-2": healthy},
+			connections:         map[string]int{"test-tidb-0": 5, "test-tidb-1": 0, "test-tidb-2": 5},
+			desiredDeleteSlots:  []int32{0},
+			expectedDeleteSlots: []int32{0},
+		},
+		{
+			name:                "AdvancedStatefulSet is disabled",
+			advancedStatefulSet: false,
+			members:             map[string]v1alpha1.TiDBMember{"test-tidb-0": healthy, "test-tidb-1": healthy, "test-tidb-2": healthy},
+			connections:         map[string]int{"test-tidb-0": 5, "test-tidb-1": 0, "test-tidb-2": 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			features.DefaultFeatureGate.Set(fmt.Sprintf("AdvancedStatefulSet=%t", tt.advancedStatefulSet))
+			tc := newTidbClusterForPD()
+			tc.Status.TiDB.Members = tt.members
+			oldSet := &apps.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-tidb", Namespace: corev1.NamespaceDefault},
+				Spec:       apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(3)},
+			}
+			if tt.pickedDeleteSlots != "" {
+				oldSet.Annotations = map[string]string{label.AnnTiDBPickedDeleteSlots: tt.pickedDeleteSlots}
+			}
+			newSet := oldSet.DeepCopy()
+			newSet.Spec.Replicas = pointer.Int32Ptr(2)
+			if tt.desiredDeleteSlots != nil {
+				helper.SetDeleteSlots(newSet, sets.NewInt32(tt.desiredDeleteSlots...))
+			}
+
+			scaler, _, _, _ := newFakeTiDBScaler()
+			statuses := map[string]*controller.DBStatus{}
+			for name, connections := range tt.connections {
+				statuses[name] = &controller.DBStatus{Connections: connections}
+			}
+			tidbControl := scaler.deps.TiDBControl.(*controller.FakeTiDBControl)
+			tidbControl.SetStatus(statuses)
+			if tt.getStatusErr {
+				scaler.deps.TiDBControl = &failingStatusTiDBControl{tidbControl}
+			}
+
+			err := scaler.pickIdlePodToScaleIn(tc, oldSet, newSet)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(tc.Annotations).NotTo(HaveKey(label.AnnTiDBDeleteSlots))
+			g.Expect(*newSet.Spec.Replicas).To(Equal(int32(2)))
+			g.Expect(helper.GetDeleteSlots(newSet).List()).To(ConsistOf(tt.expectedDeleteSlots))
+			if tt.expectedPicked != "" {
+				g.Expect(newSet.Annotations[label.AnnTiDBPickedDeleteSlots]).To(Equal(tt.expectedPicked))
+			} else {
+				g.Expect(newSet.Annotations[label.AnnTiDBPickedDeleteSlots]).To(Equal(tt.pickedDeleteSlots))
+			}
+		})
+	}
+}
+
+type failingStatusTiDBControl struct {
+	*controller.FakeTiDBControl
+}
+
+func (c *failingStatusTiDBControl) GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*controller.DBStatus, error) {
+	return nil, fmt.Errorf("connection refused")
+}
+
+func TestKeepPickedDeleteSlots(t *testing.T) {
+	g := NewGomegaWithT(t)
+	enabled := features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet)
+	defer features.DefaultFeatureGate.Set(fmt.Sprintf("AdvancedStatefulSet=%t", enabled))
+	features.DefaultFeatureGate.Set("AdvancedStatefulSet=true")
+
+	oldSet := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-tidb",
+			Namespace:   corev1.NamespaceDefault,
+			Annotations: map[string]string{label.AnnTiDBPickedDeleteSlots: "[1]"},
+		},
+		Spec: apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(2)},
+	}
+	helper.SetDeleteSlots(oldSet, sets.NewInt32(1, 4))
+	// the new statefulset only has the delete slots of the TidbCluster
+	newSet := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tidb", Namespace: corev1.NamespaceDefault},
+		Spec:       apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(2)},
+	}
+	helper.SetDeleteSlots(newSet, sets.NewInt32(4))
+
+	g.Expect(keepPickedDeleteSlots(oldSet, newSet)).To(Succeed())
+	g.Expect(helper.GetDeleteSlots(newSet).List()).To(Equal([]int32{1, 4}))
+	g.Expect(newSet.Annotations[label.AnnTiDBPickedDeleteSlots]).To(Equal("[1]"))
+
+	// the picked slots above the pods are pruned after the scale-in finishes
+	oldSet.Annotations[label.AnnTiDBPickedDeleteSlots] = "[1,3]"
+	oldSet.Spec.Replicas = pointer.Int32Ptr(2)
+	oldSet.Status.Replicas = 3
+	helper.SetDeleteSlots(oldSet, sets.NewInt32(1, 3))
+	newSet = &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tidb", Namespace: corev1.NamespaceDefault},
+		Spec:       apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(2)},
+	}
+	g.Expect(keepPickedDeleteSlots(oldSet, newSet)).To(Succeed())
+	g.Expect(helper.GetDeleteSlots(newSet).List()).To(Equal([]int32{1, 3}))
+	oldSet.Status.Replicas = 2
+	newSet = &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tidb", Namespace: corev1.NamespaceDefault},
+		Spec:       apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(2)},
+	}
+	g.Expect(keepPickedDeleteSlots(oldSet, newSet)).To(Succeed())
+	g.Expect(helper.GetDeleteSlots(newSet).List()).To(Equal([]int32{1}))
+	g.Expect(newSet.Annotations[label.AnnTiDBPickedDeleteSlots]).To(Equal("[1]"))
+	oldSet.Spec.Replicas = pointer.Int32Ptr(1)
+	oldSet.Status.Replicas = 1
+	newSet = &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tidb", Namespace: corev1.NamespaceDefault},
+		Spec:       apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(1)},
+	}
+	g.Expect(keepPickedDeleteSlots(oldSet, newSet)).To(Succeed())
+	g.Expect(helper.GetDeleteSlots(newSet).List()).To(BeEmpty())
+	g.Expect(newSet.Annotations).NotTo(HaveKey(label.AnnTiDBPickedDeleteSlots))
+
+	// nothing is kept if no slot is picked
+	newSet = oldSet.DeepCopy()
+	delete(oldSet.Annotations, label.AnnTiDBPickedDeleteSlots)
+	helper.SetDeleteSlots(newSet, sets.NewInt32(4))
+	delete(newSet.Annotations, label.AnnTiDBPickedDeleteSlots)
+	g.Expect(keepPickedDeleteSlots(oldSet, newSet)).To(Succeed())
+	g.Expect(helper.GetDeleteSlots(newSet).List()).To(Equal([]int32{4}))
+}
+
 func newFakeTiDBScaler(resyncDuration ...time.Duration) (*tidbScaler, cache.Indexer, cache.Indexer, *controller.FakePVCControl) {
 	fakeDeps := controller.NewFakeDependencies()
 	if len(resyncDuration) > 0 {
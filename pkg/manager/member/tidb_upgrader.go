@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog"
 )
 
@@ -63,6 +64,11 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 		return nil
 	}
 
+	if tc.Status.TiDB.Phase != v1alpha1.UpgradePhase &&
+		deferToMaintenanceWindow(tc, v1alpha1.TiDBMemberType, v1alpha1.MaintenanceOperationUpgrade) {
+		return keepLastAppliedPodTemplate(oldSet, newSet)
+	}
+
 	tc.Status.TiDB.Phase = v1alpha1.UpgradePhase
 	if !templateEqual(newSet, oldSet) {
 		return nil
@@ -83,6 +89,10 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 	}
 
 	setUpgradePartition(newSet, *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition)
+	// The pods are upgraded from the max ordinal down through the partition, which is the only order the
+	// statefulset supports: the pods below the partition are always recreated at the current revision, so
+	// the DDL owner can't be put off until the other pods are upgraded. The owner is resigned right before
+	// its own pod is upgraded instead, see resignDDLOwner.
 	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
 	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
 		i := podOrdinals[_i]
@@ -109,10 +119,46 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 }
 
 func (u *tidbUpgrader) upgradeTiDBPod(tc *v1alpha1.TidbCluster, ordinal int32, newSet *apps.StatefulSet) error {
+	if err := resignDDLOwner(u.deps, tc, ordinal); err != nil {
+		return err
+	}
+	recordUpgrading(u.deps.Recorder, tc, v1alpha1.TiDBMemberType, newSet, ordinal)
 	setUpgradePartition(newSet, ordinal)
 	return nil
 }
 
+// resignDDLOwner moves the DDL owner away from the TiDB pod before the pod is restarted or deleted, so that the
+// running DDL jobs are taken over by a member which has been upgraded or is kept, it returns a requeue error after
+// the DDL owner is resigned. The pod may be elected as the DDL owner again, in which case it's resigned again in the
+// next round.
+func resignDDLOwner(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, ordinal int32) error {
+	podName := tidbPodName(tc.GetName(), ordinal)
+	member, exist := tc.Status.TiDB.Members[podName]
+	if !exist || !member.IsDDLOwner {
+		return nil
+	}
+	othersHealthy := false
+	for name, m := range tc.Status.TiDB.Members {
+		if name != podName && m.Health {
+			othersHealthy = true
+			break
+		}
+	}
+	if !othersHealthy {
+		// no one can take over the DDL owner
+		return nil
+	}
+	resigned, err := deps.TiDBControl.ResignDDLOwner(tc, ordinal)
+	if err != nil {
+		return fmt.Errorf("tidbcluster: [%s/%s] failed to resign the DDL owner of tidb pod %s, error: %v", tc.GetNamespace(), tc.GetName(), podName, err)
+	}
+	if !resigned {
+		return nil
+	}
+	deps.Recorder.Eventf(tc, corev1.EventTypeNormal, DDLOwnerResigned, "tidb pod %s resigned the DDL owner", podName)
+	return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod %s resigned the DDL owner, wait for the new DDL owner", tc.GetNamespace(), tc.GetName(), podName)
+}
+
 type fakeTiDBUpgrader struct{}
 
 // NewFakeTiDBUpgrader returns a fake tidb upgrader
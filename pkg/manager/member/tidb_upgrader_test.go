@@ -37,12 +37,15 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 		getLastAppliedConfigErr bool
 		errorExpect             bool
 		changeOldSet            func(set *apps.StatefulSet)
+		infos                   map[string]*controller.DBInfo
+		expectResigned          []string
 		expectFn                func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet)
 	}
 
 	testFn := func(test *testcase, t *testing.T) {
 		t.Log(test.name)
-		upgrader, _, podInformer := newTiDBUpgrader()
+		upgrader, tidbControl, podInformer := newTiDBUpgrader()
+		tidbControl.SetInfo(test.infos)
 		tc := newTidbClusterForTiDBUpgrader()
 		if test.changeFn != nil {
 			test.changeFn(tc)
@@ -70,6 +73,7 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 			g.Expect(err).NotTo(HaveOccurred())
 		}
 		test.expectFn(g, tc, newSet)
+		g.Expect(tidbControl.ResignedDDLOwners).To(Equal(test.expectResigned))
 	}
 
 	tests := []*testcase{
@@ -203,6 +207,38 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
 			},
 		},
+		{
+			name: "resign the DDL owner before upgrading it",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+				member := tc.Status.TiDB.Members["upgrader-tidb-0"]
+				member.IsDDLOwner = true
+				tc.Status.TiDB.Members["upgrader-tidb-0"] = member
+			},
+			infos: map[string]*controller.DBInfo{
+				"upgrader-tidb-0": {IsOwner: true},
+			},
+			errorExpect:    true,
+			expectResigned: []string{"upgrader-tidb-0"},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiDB.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+		},
+		{
+			name: "upgrade the pod which is not the DDL owner anymore",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+				member := tc.Status.TiDB.Members["upgrader-tidb-0"]
+				member.IsDDLOwner = true
+				tc.Status.TiDB.Members["upgrader-tidb-0"] = member
+			},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+			},
+		},
 	}
 
 	for _, test := range tests {
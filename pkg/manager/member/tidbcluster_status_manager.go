@@ -19,6 +19,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	perrors "github.com/pingcap/errors"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
@@ -119,8 +120,19 @@ func (m *TidbClusterStatusManager) syncTiDBInfoKey(tc *v1alpha1.TidbCluster) err
 		return err
 	}
 
+	// the delete slots of the statefulset may have the ones picked to scale in
+	// besides the delete slots of the tidb cluster, keep the keys of both
+	ordinals := tc.TiDBStsDesiredOrdinals(false)
+	set, err := m.deps.StatefulSetLister.StatefulSets(tc.Namespace).Get(controller.TiDBMemberName(tc.Name))
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if set != nil {
+		ordinals = ordinals.Union(helper.GetPodOrdinals(tc.TiDBStsDesiredReplicas(), set))
+	}
+
 	expectAddrs := make(map[string]struct{})
-	for ordinal := range tc.TiDBStsDesiredOrdinals(false) {
+	for ordinal := range ordinals {
 		addr := fmt.Sprintf("%s-%d.%s-tidb-peer.%s.svc",
 			controller.TiDBMemberName(tc.GetName()),
 			ordinal,
@@ -84,7 +84,9 @@ func (f *tiflashFailover) Failover(tc *v1alpha1.TidbCluster) error {
 					CreatedAt: metav1.Now(),
 				}
 				msg := fmt.Sprintf("store [%s] is Down", store.ID)
-				f.deps.Recorder.Event(tc, corev1.EventTypeWarning, unHealthEventReason, fmt.Sprintf(unHealthEventMsgPattern, "tiflash", podName, msg))
+				f.deps.Recorder.Event(tc, corev1.EventTypeWarning, Unhealthy, fmt.Sprintf(unHealthEventMsgPattern, "tiflash", podName, msg))
+				f.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, FailoverReplicaAdded, "add a tiflash replica to replace failure store %s of pod %s", store.ID, podName)
+				startOperation(tc, v1alpha1.OperationTypeFailover, v1alpha1.TiFlashMemberType, podName, msg)
 			}
 		}
 	}
@@ -98,12 +100,17 @@ func (f *tiflashFailover) RemoveUndesiredFailures(tc *v1alpha1.TidbCluster) {
 			// slots feature. We should remove the record of undesired pods,
 			// otherwise an extra replacement pod will be created.
 			delete(tc.Status.TiFlash.FailureStores, key)
+			endOperation(tc, v1alpha1.OperationTypeFailover, v1alpha1.TiFlashMemberType, failureStore.PodName, v1alpha1.OperationFailed, "the failure pod is no longer desired")
 		}
 	}
 }
 
 func (f *tiflashFailover) Recover(tc *v1alpha1.TidbCluster) {
+	if len(tc.Status.TiFlash.FailureStores) > 0 {
+		f.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, FailoverRecovered, "tiflash failure members recovered, remove the %d replicas added for failover", len(tc.Status.TiFlash.FailureStores))
+	}
 	tc.Status.TiFlash.FailureStores = nil
+	endOperations(tc, v1alpha1.OperationTypeFailover, v1alpha1.TiFlashMemberType, v1alpha1.OperationSucceeded)
 	klog.Infof("TiFlash recover: clear FailureStores, %s/%s", tc.GetNamespace(), tc.GetName())
 }
 
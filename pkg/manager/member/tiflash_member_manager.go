@@ -175,7 +175,8 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	}
 	if len(tc.Status.TiFlash.FailureStores) > 0 &&
 		tc.Spec.TiFlash.RecoverFailover &&
-		shouldRecover(tc, label.TiFlashLabelVal, m.deps.PodLister) {
+		shouldRecover(tc, label.TiFlashLabelVal, m.deps.PodLister) &&
+		!deferToMaintenanceWindow(tc, v1alpha1.TiFlashMemberType, v1alpha1.MaintenanceOperationFailoverRecovery) {
 		m.failover.Recover(tc)
 	}
 
@@ -655,6 +656,7 @@ func (m *tiflashMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, s
 		tc.Status.TiFlash.Phase = v1alpha1.UpgradePhase
 	} else {
 		tc.Status.TiFlash.Phase = v1alpha1.NormalPhase
+		endOperations(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.TiFlashMemberType, v1alpha1.OperationSucceeded)
 	}
 
 	previousStores := tc.Status.TiFlash.Stores
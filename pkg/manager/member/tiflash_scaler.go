@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
@@ -41,6 +42,10 @@ func (s *tiflashScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newS
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
 	} else if scaling < 0 {
+		if deferToMaintenanceWindow(meta, v1alpha1.TiFlashMemberType, v1alpha1.MaintenanceOperationScaleIn) {
+			resetReplicas(newSet, oldSet)
+			return nil
+		}
 		return s.ScaleIn(meta, oldSet, newSet)
 	}
 	// we only sync auto scaler annotations when we are finishing syncing scaling
@@ -62,6 +67,7 @@ func (s *tiflashScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, n
 		return err
 	}
 
+	recordScaled(s.deps.Recorder, meta, v1alpha1.TiFlashMemberType, ordinal, true)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -105,6 +111,8 @@ func (s *tiflashScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, ne
 					return err
 				}
 				klog.Infof("tiflash scale in: delete store %d for tiflash %s/%s successfully", id, ns, podName)
+				s.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, StoreOffline, "store %d of tiflash pod %s is set offline, waiting for its regions to be moved", id, podName)
+				startOperation(tc, v1alpha1.OperationTypeScaleIn, v1alpha1.TiFlashMemberType, podName, fmt.Sprintf("store %d is set offline", id))
 			}
 			return controller.RequeueErrorf("TiFlash %s/%s store %d is still in cluster, state: %s", ns, podName, id, state)
 		}
@@ -118,11 +126,13 @@ func (s *tiflashScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, ne
 
 			// TODO: double check if store is really not in Up/Offline/Down state
 			klog.Infof("TiFlash %s/%s store %d becomes tombstone", ns, podName, id)
+			s.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, StoreTombstone, "store %d of tiflash pod %s becomes tombstone", id, podName)
 
 			err = s.updateDeferDeletingPVC(tc, v1alpha1.TiFlashMemberType, ordinal)
 			if err != nil {
 				return err
 			}
+			recordScaled(s.deps.Recorder, meta, v1alpha1.TiFlashMemberType, ordinal, false)
 			setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 			return nil
 		}
@@ -153,6 +163,7 @@ func (s *tiflashScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, ne
 		if err != nil {
 			return err
 		}
+		recordScaled(s.deps.Recorder, meta, v1alpha1.TiFlashMemberType, ordinal, false)
 		setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 		return nil
 	}
@@ -66,6 +66,11 @@ func (u *tiflashUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Statefu
 		return fmt.Errorf("cluster: [%s/%s]'s TiFlash status is not synced, can not upgrade", ns, tcName)
 	}
 
+	if tc.Status.TiFlash.Phase != v1alpha1.UpgradePhase &&
+		deferToMaintenanceWindow(tc, v1alpha1.TiFlashMemberType, v1alpha1.MaintenanceOperationUpgrade) {
+		return keepLastAppliedPodTemplate(oldSet, newSet)
+	}
+
 	tc.Status.TiFlash.Phase = v1alpha1.UpgradePhase
 	if !templateEqual(newSet, oldSet) {
 		return nil
@@ -133,6 +138,7 @@ func (u *tiflashUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Statefu
 			continue
 		}
 
+		recordUpgrading(u.deps.Recorder, tc, v1alpha1.TiFlashMemberType, newSet, i)
 		setUpgradePartition(newSet, i)
 		return nil
 	}
@@ -83,7 +83,9 @@ func (f *tikvFailover) Failover(tc *v1alpha1.TidbCluster) error {
 					CreatedAt: metav1.Now(),
 				}
 				msg := fmt.Sprintf("store[%s] is Down", store.ID)
-				f.deps.Recorder.Event(tc, corev1.EventTypeWarning, unHealthEventReason, fmt.Sprintf(unHealthEventMsgPattern, "tikv", podName, msg))
+				f.deps.Recorder.Event(tc, corev1.EventTypeWarning, Unhealthy, fmt.Sprintf(unHealthEventMsgPattern, "tikv", podName, msg))
+				f.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, FailoverReplicaAdded, "add a tikv replica to replace failure store %s of pod %s", store.ID, podName)
+				startOperation(tc, v1alpha1.OperationTypeFailover, v1alpha1.TiKVMemberType, podName, msg)
 			}
 		}
 	}
@@ -98,12 +100,17 @@ func (f *tikvFailover) RemoveUndesiredFailures(tc *v1alpha1.TidbCluster) {
 			// slots feature. We should remove the record of undesired pods,
 			// otherwise an extra replacement pod will be created.
 			delete(tc.Status.TiKV.FailureStores, key)
+			endOperation(tc, v1alpha1.OperationTypeFailover, v1alpha1.TiKVMemberType, failureStore.PodName, v1alpha1.OperationFailed, "the failure pod is no longer desired")
 		}
 	}
 }
 
 func (f *tikvFailover) Recover(tc *v1alpha1.TidbCluster) {
+	if len(tc.Status.TiKV.FailureStores) > 0 {
+		f.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, FailoverRecovered, "tikv failure members recovered, remove the %d replicas added for failover", len(tc.Status.TiKV.FailureStores))
+	}
 	tc.Status.TiKV.FailureStores = nil
+	endOperations(tc, v1alpha1.OperationTypeFailover, v1alpha1.TiKVMemberType, v1alpha1.OperationSucceeded)
 	klog.Infof("TiKV recover: clear FailureStores, %s/%s", tc.GetNamespace(), tc.GetName())
 }
 
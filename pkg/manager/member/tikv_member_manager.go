@@ -21,6 +21,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	"github.com/pingcap/kvproto/pkg/metapb"
@@ -33,6 +34,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -84,9 +86,9 @@ type SvcConfig struct {
 
 // Sync fulfills the manager.Manager interface
 func (m *tikvMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
-	// If tikv is not specified return
+	// If tikv is not specified, remove its pdb and return
 	if tc.Spec.TiKV == nil {
-		return nil
+		return syncPodDisruptionBudget(m.deps, tc, v1alpha1.TiKVMemberType)
 	}
 
 	ns := tc.GetNamespace()
@@ -110,7 +112,10 @@ func (m *tikvMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 			return err
 		}
 	}
-	return m.syncStatefulSetForTidbCluster(tc)
+	if err := m.syncStatefulSetForTidbCluster(tc); err != nil {
+		return err
+	}
+	return syncPodDisruptionBudget(m.deps, tc, v1alpha1.TiKVMemberType)
 }
 
 func (m *tikvMemberManager) syncServiceForTidbCluster(tc *v1alpha1.TidbCluster, svcConfig SvcConfig) error {
@@ -189,7 +194,8 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	}
 	if len(tc.Status.TiKV.FailureStores) > 0 &&
 		tc.Spec.TiKV.RecoverFailover &&
-		shouldRecover(tc, label.TiKVLabelVal, m.deps.PodLister) {
+		shouldRecover(tc, label.TiKVLabelVal, m.deps.PodLister) &&
+		!deferToMaintenanceWindow(tc, v1alpha1.TiKVMemberType, v1alpha1.MaintenanceOperationFailoverRecovery) {
 		m.failover.Recover(tc)
 	}
 
@@ -260,6 +266,23 @@ func (m *tikvMemberManager) syncTiKVConfigMap(tc *v1alpha1.TidbCluster, set *app
 		})
 	}
 
+	if tc.IsDynamicConfigurationEnabled() {
+		applied, err := updateConfigMapOnline(m.deps.ConfigMapLister, tc.BaseTiKVSpec().ConfigUpdateStrategy(), inUseName, newCm, isTiKVOnlineConfigItem, func(items map[string]interface{}) error {
+			return m.applyTiKVConfigOnline(tc, items)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(applied) > 0 {
+			tc.Status.TiKV.OnlineConfig = &v1alpha1.OnlineConfigStatus{
+				Items:           applied,
+				LastAppliedTime: metav1.Now(),
+			}
+			m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, ConfigAppliedOnline, "tikv config items %s are applied online", strings.Join(applied, ", "))
+		}
+		return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+	}
+
 	err = updateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BaseTiKVSpec().ConfigUpdateStrategy(), inUseName, newCm)
 	if err != nil {
 		return nil, err
@@ -267,6 +290,22 @@ func (m *tikvMemberManager) syncTiKVConfigMap(tc *v1alpha1.TidbCluster, set *app
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
+// applyTiKVConfigOnline updates the config of the stores that are up, a store
+// that is not up loads the config from the configmap when it restarts
+func (m *tikvMemberManager) applyTiKVConfigOnline(tc *v1alpha1.TidbCluster, items map[string]interface{}) error {
+	for _, store := range tc.Status.TiKV.Stores {
+		if store.State != v1alpha1.TiKVStateUp {
+			klog.Infof("skip applying config online to tikv %s/%s, its state is %s", tc.Namespace, store.PodName, store.State)
+			continue
+		}
+		tikvClient := m.deps.TiKVControl.GetTiKVPodClient(tc.Namespace, tc.Name, store.PodName, tc.IsTLSClusterEnabled())
+		if err := tikvClient.UpdateConfig(items); err != nil {
+			return fmt.Errorf("failed to apply config online to tikv %s/%s: %v", tc.Namespace, store.PodName, err)
+		}
+	}
+	return nil
+}
+
 func getNewServiceForTidbCluster(tc *v1alpha1.TidbCluster, svcConfig SvcConfig) *corev1.Service {
 	ns := tc.Namespace
 	tcName := tc.Name
@@ -701,6 +740,7 @@ func (m *tikvMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 		tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
 	} else {
 		tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+		endOperations(tc, v1alpha1.OperationTypeUpgrade, v1alpha1.TiKVMemberType, v1alpha1.OperationSucceeded)
 	}
 
 	previousStores := tc.Status.TiKV.Stores
@@ -739,14 +779,25 @@ func (m *tikvMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 		}
 
 		status.LastTransitionTime = metav1.Now()
-		if exist && status.State == oldStore.State {
-			status.LastTransitionTime = oldStore.LastTransitionTime
+		if exist {
+			status.NodeName = oldStore.NodeName
+			if status.State == oldStore.State {
+				status.LastTransitionTime = oldStore.LastTransitionTime
+			}
 		}
 
 		// In theory, the external tikv can join the cluster, and the operator would only manage the internal tikv.
 		// So we check the store owner to make sure it.
 		if store.Store != nil {
 			if pattern.Match([]byte(store.Store.Address)) {
+				pod, err := m.deps.PodLister.Pods(tc.GetNamespace()).Get(status.PodName)
+				if err != nil && !errors.IsNotFound(err) {
+					return fmt.Errorf("syncTidbClusterStatus: failed to get pods %s for cluster %s/%s, error: %s", status.PodName, tc.GetNamespace(), tc.GetName(), err)
+				}
+				if pod != nil && pod.Spec.NodeName != "" {
+					// Update assigned node if pod exists and is scheduled
+					status.NodeName = pod.Spec.NodeName
+				}
 				stores[status.ID] = *status
 			} else if util.MatchLabelFromStoreLabels(store.Store.Labels, label.TiKVLabelVal) {
 				peerStores[status.ID] = *status
@@ -775,6 +826,7 @@ func (m *tikvMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 	tc.Status.TiKV.Stores = stores
 	tc.Status.TiKV.PeerStores = peerStores
 	tc.Status.TiKV.TombstoneStores = tombstoneStores
+	tc.Status.TiKV.StoreSummary = getTiKVStoreSummary(stores)
 	tc.Status.TiKV.BootStrapped = true
 	tc.Status.TiKV.Image = ""
 	c := findContainerByName(set, "tikv")
@@ -792,13 +844,80 @@ func getTiKVStore(store *pdapi.StoreInfo) *v1alpha1.TiKVStore {
 	ip := strings.Split(store.Store.GetAddress(), ":")[0]
 	podName := strings.Split(ip, ".")[0]
 
-	return &v1alpha1.TiKVStore{
-		ID:          storeID,
-		PodName:     podName,
-		IP:          ip,
-		LeaderCount: int32(store.Status.LeaderCount),
-		State:       store.Store.StateName,
+	status := &v1alpha1.TiKVStore{
+		ID:           storeID,
+		PodName:      podName,
+		IP:           ip,
+		LeaderCount:  int32(store.Status.LeaderCount),
+		State:        store.Store.StateName,
+		RegionCount:  int32(store.Status.RegionCount),
+		Capacity:     resource.NewQuantity(int64(store.Status.Capacity), resource.BinarySI),
+		Available:    resource.NewQuantity(int64(store.Status.Available), resource.BinarySI),
+		UsedSize:     resource.NewQuantity(int64(store.Status.UsedSize), resource.BinarySI),
+		LeaderWeight: strconv.FormatFloat(store.Status.LeaderWeight, 'f', -1, 64),
+		RegionWeight: strconv.FormatFloat(store.Status.RegionWeight, 'f', -1, 64),
+		Uptime:       store.Status.Uptime.Duration.Truncate(time.Minute).String(),
+	}
+	if len(store.Store.Labels) > 0 {
+		status.Labels = map[string]string{}
+		for _, l := range store.Store.Labels {
+			status.Labels[l.GetKey()] = l.GetValue()
+		}
+	}
+	// the uptime and the heartbeat are coarsened to minutes so the status is not updated for them in every sync
+	if !store.Status.StartTS.IsZero() {
+		status.StartTime = &metav1.Time{Time: store.Status.StartTS}
+	}
+	if !store.Status.LastHeartbeatTS.IsZero() {
+		status.LastHeartbeatTime = &metav1.Time{Time: store.Status.LastHeartbeatTS.Truncate(time.Minute)}
+	}
+	return status
+}
+
+// getTiKVStoreSummary summarizes the given stores, it returns nil if there is no store
+func getTiKVStoreSummary(stores map[string]v1alpha1.TiKVStore) *v1alpha1.TiKVStoreSummary {
+	if len(stores) == 0 {
+		return nil
+	}
+	summary := &v1alpha1.TiKVStoreSummary{
+		Capacity:  resource.NewQuantity(0, resource.BinarySI),
+		Available: resource.NewQuantity(0, resource.BinarySI),
+		UsedSize:  resource.NewQuantity(0, resource.BinarySI),
+	}
+	var minLeader, maxLeader, minRegion, maxRegion int32
+	for _, store := range stores {
+		summary.StoreCount++
+		summary.RegionCount += store.RegionCount
+		summary.LeaderCount += store.LeaderCount
+		if store.Capacity != nil {
+			summary.Capacity.Add(*store.Capacity)
+		}
+		if store.Available != nil {
+			summary.Available.Add(*store.Available)
+		}
+		if store.UsedSize != nil {
+			summary.UsedSize.Add(*store.UsedSize)
+		}
+		if store.State != v1alpha1.TiKVStateUp {
+			continue
+		}
+		if summary.UpStoreCount == 0 || store.LeaderCount < minLeader {
+			minLeader = store.LeaderCount
+		}
+		if summary.UpStoreCount == 0 || store.LeaderCount > maxLeader {
+			maxLeader = store.LeaderCount
+		}
+		if summary.UpStoreCount == 0 || store.RegionCount < minRegion {
+			minRegion = store.RegionCount
+		}
+		if summary.UpStoreCount == 0 || store.RegionCount > maxRegion {
+			maxRegion = store.RegionCount
+		}
+		summary.UpStoreCount++
 	}
+	summary.LeaderImbalance = maxLeader - minLeader
+	summary.RegionImbalance = maxRegion - minRegion
+	return summary
 }
 
 func (m *tikvMemberManager) setStoreLabelsForTiKV(tc *v1alpha1.TidbCluster) (int, error) {
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/util/toml"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/tikv/pd/pkg/typeutil"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
@@ -825,6 +826,7 @@ func TestTiKVMemberManagerSyncTidbClusterStatus(t *testing.T) {
 		storeInfo                 *pdapi.StoresInfo
 		errWhenGetTombstoneStores bool
 		tombstoneStoreInfo        *pdapi.StoresInfo
+		pods                      []*corev1.Pod
 		errExpectFn               func(*GomegaWithT, error)
 		tcExpectFn                func(*GomegaWithT, *v1alpha1.TidbCluster)
 	}
@@ -846,7 +848,10 @@ func TestTiKVMemberManagerSyncTidbClusterStatus(t *testing.T) {
 		if test.updateTC != nil {
 			test.updateTC(tc)
 		}
-		pmm, _, _, pdClient, _, _ := newFakeTiKVMemberManager(tc)
+		pmm, _, _, pdClient, podIndexer, _ := newFakeTiKVMemberManager(tc)
+		for _, pod := range test.pods {
+			podIndexer.Add(pod)
+		}
 
 		if test.upgradingFn != nil {
 			pmm.statefulSetIsUpgradingFn = test.upgradingFn
@@ -1003,6 +1008,54 @@ func TestTiKVMemberManagerSyncTidbClusterStatus(t *testing.T) {
 				g.Expect(tc.Status.TiKV.Synced).To(BeFalse())
 			},
 		},
+		{
+			name: "record the node of the stores",
+			updateTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+					"333": {ID: "333", PodName: "test-tikv-1", NodeName: "node-0"},
+					"334": {ID: "334", PodName: "test-tikv-2", NodeName: "node-2"},
+				}
+			},
+			upgradingFn: func(lister corelisters.PodLister, controlInterface pdapi.PDControlInterface, set *apps.StatefulSet, cluster *v1alpha1.TidbCluster) (bool, error) {
+				return false, nil
+			},
+			storeInfo: &pdapi.StoresInfo{
+				Stores: []*pdapi.StoreInfo{
+					{
+						Store: &pdapi.MetaStore{
+							Store: &metapb.Store{
+								Id:      333,
+								Address: fmt.Sprintf("%s-tikv-1.%s-tikv-peer.%s.svc:20160", "test", "test", "default"),
+							},
+						},
+						Status: &pdapi.StoreStatus{},
+					},
+					{
+						Store: &pdapi.MetaStore{
+							Store: &metapb.Store{
+								Id:      334,
+								Address: fmt.Sprintf("%s-tikv-2.%s-tikv-peer.%s.svc:20160", "test", "test", "default"),
+							},
+						},
+						Status: &pdapi.StoreStatus{},
+					},
+				},
+			},
+			tombstoneStoreInfo: &pdapi.StoresInfo{
+				Stores: []*pdapi.StoreInfo{},
+			},
+			pods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-tikv-1", Namespace: corev1.NamespaceDefault},
+					Spec:       corev1.PodSpec{NodeName: "node-1"},
+				},
+			},
+			errExpectFn: errExpectNil,
+			tcExpectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.TiKV.Stores["333"].NodeName).To(Equal("node-1"))
+				g.Expect(tc.Status.TiKV.Stores["334"].NodeName).To(Equal("node-2"))
+			},
+		},
 		{
 			name:     "stores is empty",
 			updateTC: nil,
@@ -1588,6 +1641,85 @@ func newFakeTiKVMemberManager(tc *v1alpha1.TidbCluster) (
 	return tmm, setControl, svcControl, pdClient, podIndexer, nodeIndexer
 }
 
+func TestGetTiKVStore(t *testing.T) {
+	g := NewGomegaWithT(t)
+	heartbeat := time.Date(2021, 1, 1, 0, 0, 42, 0, time.UTC)
+	start := heartbeat.Add(-90*time.Minute - 30*time.Second)
+	store := &pdapi.StoreInfo{
+		Store: &pdapi.MetaStore{
+			Store: &metapb.Store{
+				Id:      1,
+				Address: "test-tikv-0.test-tikv-peer.default.svc:20160",
+				Labels:  []*metapb.StoreLabel{{Key: "zone", Value: "z1"}},
+			},
+			StateName: v1alpha1.TiKVStateUp,
+		},
+		Status: &pdapi.StoreStatus{
+			Capacity:        typeutil.ByteSize(100 << 30),
+			Available:       typeutil.ByteSize(60 << 30),
+			UsedSize:        typeutil.ByteSize(30 << 30),
+			LeaderCount:     10,
+			RegionCount:     30,
+			LeaderWeight:    1,
+			RegionWeight:    0.5,
+			StartTS:         start,
+			Uptime:          typeutil.NewDuration(90*time.Minute + 30*time.Second),
+			LastHeartbeatTS: heartbeat,
+		},
+	}
+
+	// the uptime and the heartbeat are coarsened to minutes
+	g.Expect(getTiKVStore(store)).To(Equal(&v1alpha1.TiKVStore{
+		ID:                "1",
+		PodName:           "test-tikv-0",
+		IP:                "test-tikv-0.test-tikv-peer.default.svc",
+		LeaderCount:       10,
+		State:             v1alpha1.TiKVStateUp,
+		RegionCount:       30,
+		Capacity:          resource.NewQuantity(100<<30, resource.BinarySI),
+		Available:         resource.NewQuantity(60<<30, resource.BinarySI),
+		UsedSize:          resource.NewQuantity(30<<30, resource.BinarySI),
+		LeaderWeight:      "1",
+		RegionWeight:      "0.5",
+		Labels:            map[string]string{"zone": "z1"},
+		StartTime:         &metav1.Time{Time: start},
+		Uptime:            "1h30m0s",
+		LastHeartbeatTime: &metav1.Time{Time: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}))
+	g.Expect(getTiKVStore(&pdapi.StoreInfo{Store: store.Store})).To(BeNil())
+}
+
+func TestGetTiKVStoreSummary(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(getTiKVStoreSummary(nil)).To(BeNil())
+
+	newStore := func(state string, leaders, regions int32, capacity int64) v1alpha1.TiKVStore {
+		return v1alpha1.TiKVStore{
+			State:       state,
+			LeaderCount: leaders,
+			RegionCount: regions,
+			Capacity:    resource.NewQuantity(capacity, resource.BinarySI),
+			Available:   resource.NewQuantity(capacity/2, resource.BinarySI),
+		}
+	}
+	summary := getTiKVStoreSummary(map[string]v1alpha1.TiKVStore{
+		"1": newStore(v1alpha1.TiKVStateUp, 10, 30, 100<<30),
+		"2": newStore(v1alpha1.TiKVStateUp, 4, 28, 100<<30),
+		"3": newStore(v1alpha1.TiKVStateUp, 7, 35, 100<<30),
+		// stores not in Up state are not counted for imbalance
+		"4": newStore(v1alpha1.TiKVStateDown, 0, 0, 50<<30),
+	})
+	g.Expect(summary.StoreCount).To(Equal(int32(4)))
+	g.Expect(summary.UpStoreCount).To(Equal(int32(3)))
+	g.Expect(summary.LeaderCount).To(Equal(int32(21)))
+	g.Expect(summary.RegionCount).To(Equal(int32(93)))
+	g.Expect(summary.Capacity.String()).To(Equal("350Gi"))
+	g.Expect(summary.Available.String()).To(Equal("175Gi"))
+	g.Expect(summary.UsedSize.IsZero()).To(BeTrue())
+	g.Expect(summary.LeaderImbalance).To(Equal(int32(6)))
+	g.Expect(summary.RegionImbalance).To(Equal(int32(7)))
+}
+
 func TestGetNewTiKVServiceForTidbCluster(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -70,6 +70,10 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 			newSet.Spec.Template.Spec = *podSpec
 			return nil
 		}
+		if meta.Status.TiKV.Phase != v1alpha1.UpgradePhase &&
+			deferToMaintenanceWindow(meta, v1alpha1.TiKVMemberType, v1alpha1.MaintenanceOperationUpgrade) {
+			return keepLastAppliedPodTemplate(oldSet, newSet)
+		}
 		status = &meta.Status.TiKV
 	default:
 		return fmt.Errorf("cluster[%s/%s] failed to upgrading tikv due to converting", meta.GetNamespace(), meta.GetName())
@@ -150,6 +154,7 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 		}
 
 		if u.deps.CLIConfig.PodWebhookEnabled {
+			recordUpgrading(u.deps.Recorder, tc, v1alpha1.TiKVMemberType, newSet, i)
 			setUpgradePartition(newSet, i)
 			return nil
 		}
@@ -181,6 +186,7 @@ func (u *tikvUpgrader) upgradeTiKVPod(tc *v1alpha1.TidbCluster, ordinal int32, n
 			}
 
 			if u.readyToUpgrade(upgradePod, tc) {
+				recordUpgrading(u.deps.Recorder, tc, v1alpha1.TiKVMemberType, newSet, ordinal)
 				setUpgradePartition(newSet, ordinal)
 				return nil
 			}
@@ -234,6 +240,7 @@ func (u *tikvUpgrader) beginEvictLeader(tc *v1alpha1.TidbCluster, storeID uint64
 		return err
 	}
 	klog.Infof("tikv upgrader: begin evict leader: %d, %s/%s successfully", storeID, ns, podName)
+	u.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, EvictLeaderBegan, "tikv pod %s is being upgraded, begin evicting leaders of store %d", podName, storeID)
 	if pod.Annotations == nil {
 		pod.Annotations = map[string]string{}
 	}
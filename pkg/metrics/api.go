@@ -0,0 +1,54 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	PDAPIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "pd_api",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the PD API requests of each cluster",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+		}, []string{LabelNamespace, LabelName, LabelAPI})
+
+	PDAPIRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "pd_api",
+			Name:      "request_errors_total",
+			Help:      "Total number of the failed PD API requests of each cluster",
+		}, []string{LabelNamespace, LabelName, LabelAPI})
+
+	TiKVAPIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "tikv_api",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the TiKV API requests of each cluster",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+		}, []string{LabelNamespace, LabelName, LabelAPI})
+
+	TiKVAPIRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "tikv_api",
+			Name:      "request_errors_total",
+			Help:      "Total number of the failed TiKV API requests of each cluster",
+		}, []string{LabelNamespace, LabelName, LabelAPI})
+)
@@ -0,0 +1,50 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	BackupDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup",
+			Name:      "duration_seconds",
+			Help:      "Duration of the finished backups of each cluster, the result is complete or failed",
+			Buckets:   prometheus.ExponentialBuckets(60, 2, 12),
+		}, []string{LabelNamespace, LabelCluster, LabelType, LabelResult})
+
+	RestoreDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "restore",
+			Name:      "duration_seconds",
+			Help:      "Duration of the finished restores of each cluster, the result is complete or failed",
+			Buckets:   prometheus.ExponentialBuckets(60, 2, 12),
+		}, []string{LabelNamespace, LabelCluster, LabelType, LabelResult})
+)
+
+// Backup results
+const (
+	BackupComplete = "complete"
+	BackupFailed   = "failed"
+)
+
+// Restore results
+const (
+	RestoreComplete = "complete"
+	RestoreFailed   = "failed"
+)
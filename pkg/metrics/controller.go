@@ -0,0 +1,45 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "controller",
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of the reconciliations of each controller",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+		}, []string{LabelController, LabelResult})
+
+	ReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "controller",
+			Name:      "reconcile_errors_total",
+			Help:      "Total number of the reconciliations of each object failed with an error other than requeue",
+		}, []string{LabelController, LabelNamespace, LabelName})
+)
+
+// Reconcile results
+const (
+	ReconcileSuccess = "success"
+	ReconcileRequeue = "requeue"
+	ReconcileIgnore  = "ignore"
+	ReconcileError   = "error"
+)
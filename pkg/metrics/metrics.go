@@ -20,6 +20,18 @@ import (
 // RegisterMetrics registers all metrics of tidb-operator.
 func RegisterMetrics() {
 	prometheus.MustRegister(ClusterSpecReplicas)
+	prometheus.MustRegister(ClusterStatusReplicas)
+	prometheus.MustRegister(ClusterUpdatedReplicas)
+	prometheus.MustRegister(ClusterFailureMembers)
+	prometheus.MustRegister(ClusterScaleTotal)
+	prometheus.MustRegister(ReconcileDuration)
+	prometheus.MustRegister(ReconcileErrors)
+	prometheus.MustRegister(PDAPIRequestDuration)
+	prometheus.MustRegister(PDAPIRequestErrors)
+	prometheus.MustRegister(TiKVAPIRequestDuration)
+	prometheus.MustRegister(TiKVAPIRequestErrors)
+	prometheus.MustRegister(BackupDuration)
+	prometheus.MustRegister(RestoreDuration)
 }
 
 // Label constants.
@@ -27,4 +39,15 @@ const (
 	LabelNamespace = "namespace"
 	LabelName      = "name"
 	LabelComponent = "component"
+	LabelCluster   = "cluster"
+
+	LabelController = "controller"
+	LabelResult     = "result"
+	LabelType       = "type"
+	LabelAPI        = "api"
+
+	LabelWebhook   = "webhook"
+	LabelResource  = "resource"
+	LabelOperation = "operation"
+	LabelAllowed   = "allowed"
 )
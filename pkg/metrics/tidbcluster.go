@@ -15,6 +15,7 @@ package metrics
 
 import (
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 var (
@@ -26,3 +27,104 @@ var (
 			Help:      "Desired replicas of each component in TidbCluster",
 		}, []string{LabelNamespace, LabelName, LabelComponent})
 )
+
+var (
+	ClusterStatusReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "status_replicas",
+			Help:      "Current replicas of each component in TidbCluster",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	ClusterUpdatedReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "updated_replicas",
+			Help:      "Replicas of each component in TidbCluster which are upgraded to the latest revision",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	ClusterFailureMembers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "failure_members",
+			Help:      "Failure members of each component in TidbCluster, which are replaced by new replicas",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	ClusterScaleTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "scale_total",
+			Help:      "Total number of pods scaled out or scaled in of each component in TidbCluster and DMCluster",
+		}, []string{LabelNamespace, LabelName, LabelComponent, LabelType})
+)
+
+// Scale types
+const (
+	ScaleOut = "out"
+	ScaleIn  = "in"
+)
+
+// DeleteClusterMetrics deletes the metrics of a deleted TidbCluster, which are
+// otherwise exported with the last values until the operator restarts.
+func DeleteClusterMetrics(ns, name string) {
+	cluster := prometheus.Labels{LabelNamespace: ns, LabelName: name}
+	for _, vec := range []deletableVec{
+		ClusterSpecReplicas,
+		ClusterStatusReplicas,
+		ClusterUpdatedReplicas,
+		ClusterFailureMembers,
+		ClusterScaleTotal,
+		PDAPIRequestDuration,
+		PDAPIRequestErrors,
+		TiKVAPIRequestDuration,
+		TiKVAPIRequestErrors,
+	} {
+		deleteSeries(vec, cluster)
+	}
+	ReconcileErrors.Delete(prometheus.Labels{LabelController: "tidbcluster", LabelNamespace: ns, LabelName: name})
+}
+
+// deletableVec is a metric vector whose series can be deleted by the labels
+type deletableVec interface {
+	prometheus.Collector
+	Delete(labels prometheus.Labels) bool
+}
+
+// deleteSeries deletes the series of the vector whose labels contain all the given labels,
+// the values of the other labels, e.g. the API of the requests, are unknown to the caller.
+func deleteSeries(vec deletableVec, labels prometheus.Labels) {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		vec.Collect(ch)
+		close(ch)
+	}()
+	var matched []prometheus.Labels
+	for m := range ch {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			continue
+		}
+		series := prometheus.Labels{}
+		for _, pair := range pb.GetLabel() {
+			series[pair.GetName()] = pair.GetValue()
+		}
+		match := true
+		for k, v := range labels {
+			if series[k] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			matched = append(matched, series)
+		}
+	}
+	// the series are deleted after the collection, which holds the lock of the vector
+	for _, series := range matched {
+		vec.Delete(series)
+	}
+}
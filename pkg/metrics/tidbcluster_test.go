@@ -0,0 +1,56 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestDeleteClusterMetrics(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	for _, name := range []string{"deleted", "other"} {
+		ClusterSpecReplicas.WithLabelValues("ns", name, "pd").Set(3)
+		ClusterStatusReplicas.WithLabelValues("ns", name, "tikv").Set(3)
+		ClusterUpdatedReplicas.WithLabelValues("ns", name, "tidb").Set(2)
+		ClusterFailureMembers.WithLabelValues("ns", name, "tiflash").Set(1)
+		ClusterScaleTotal.WithLabelValues("ns", name, "tikv", ScaleOut).Inc()
+		PDAPIRequestDuration.WithLabelValues("ns", name, "GetHealth").Observe(0.1)
+		PDAPIRequestErrors.WithLabelValues("ns", name, "DeleteStore").Inc()
+		TiKVAPIRequestDuration.WithLabelValues("ns", name, "GetLeaderCount").Observe(0.1)
+		TiKVAPIRequestErrors.WithLabelValues("ns", name, "GetLeaderCount").Inc()
+		ReconcileErrors.WithLabelValues("tidbcluster", "ns", name).Inc()
+	}
+	defer DeleteClusterMetrics("ns", "other")
+
+	DeleteClusterMetrics("ns", "deleted")
+	for _, vec := range []deletableVec{
+		ClusterSpecReplicas,
+		ClusterStatusReplicas,
+		ClusterUpdatedReplicas,
+		ClusterFailureMembers,
+		ClusterScaleTotal,
+		PDAPIRequestDuration,
+		PDAPIRequestErrors,
+		TiKVAPIRequestDuration,
+		TiKVAPIRequestErrors,
+		ReconcileErrors,
+	} {
+		// only the series of the other cluster remain
+		g.Expect(testutil.CollectAndCount(vec)).To(Equal(1))
+	}
+}
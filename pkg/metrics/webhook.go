@@ -0,0 +1,39 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	admission "k8s.io/api/admission/v1beta1"
+)
+
+// WebhookAdmissionTotal is registered by the admission webhook server instead of the controller manager, and exposed
+// on the metrics endpoint of the admission webhook server.
+var WebhookAdmissionTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "tidb_operator",
+		Subsystem: "webhook",
+		Name:      "admission_total",
+		Help:      "Total number of the admission decisions of each webhook",
+	}, []string{LabelWebhook, LabelResource, LabelOperation, LabelAllowed})
+
+// ObserveAdmission records the admission decision of the webhook on the request
+func ObserveAdmission(webhook string, ar *admission.AdmissionRequest, resp *admission.AdmissionResponse) {
+	if ar == nil || resp == nil {
+		return
+	}
+	WebhookAdmissionTotal.WithLabelValues(webhook, ar.Resource.Resource, string(ar.Operation), strconv.FormatBool(resp.Allowed)).Inc()
+}
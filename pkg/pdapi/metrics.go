@@ -0,0 +1,141 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdapi
+
+import (
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+// instrumentedPDClient records the duration and the errors of the requests of PDClient in the metrics of the
+// cluster, the api label of the metrics is the name of the method
+type instrumentedPDClient struct {
+	PDClient
+	namespace string
+	tcName    string
+}
+
+func newInstrumentedPDClient(client PDClient, namespace Namespace, tcName string) PDClient {
+	return &instrumentedPDClient{PDClient: client, namespace: string(namespace), tcName: tcName}
+}
+
+func (c *instrumentedPDClient) observe(api string, startTime time.Time, err *error) {
+	metrics.PDAPIRequestDuration.WithLabelValues(c.namespace, c.tcName, api).Observe(time.Since(startTime).Seconds())
+	if *err != nil {
+		metrics.PDAPIRequestErrors.WithLabelValues(c.namespace, c.tcName, api).Inc()
+	}
+}
+
+func (c *instrumentedPDClient) GetHealth() (ret *HealthInfo, err error) {
+	defer c.observe("GetHealth", time.Now(), &err)
+	return c.PDClient.GetHealth()
+}
+
+func (c *instrumentedPDClient) GetConfig() (ret *PDConfigFromAPI, err error) {
+	defer c.observe("GetConfig", time.Now(), &err)
+	return c.PDClient.GetConfig()
+}
+
+func (c *instrumentedPDClient) GetCluster() (ret *metapb.Cluster, err error) {
+	defer c.observe("GetCluster", time.Now(), &err)
+	return c.PDClient.GetCluster()
+}
+
+func (c *instrumentedPDClient) GetMembers() (ret *MembersInfo, err error) {
+	defer c.observe("GetMembers", time.Now(), &err)
+	return c.PDClient.GetMembers()
+}
+
+func (c *instrumentedPDClient) GetStores() (ret *StoresInfo, err error) {
+	defer c.observe("GetStores", time.Now(), &err)
+	return c.PDClient.GetStores()
+}
+
+func (c *instrumentedPDClient) GetTombStoneStores() (ret *StoresInfo, err error) {
+	defer c.observe("GetTombStoneStores", time.Now(), &err)
+	return c.PDClient.GetTombStoneStores()
+}
+
+func (c *instrumentedPDClient) GetStore(storeID uint64) (ret *StoreInfo, err error) {
+	defer c.observe("GetStore", time.Now(), &err)
+	return c.PDClient.GetStore(storeID)
+}
+
+func (c *instrumentedPDClient) SetStoreLabels(storeID uint64, labels map[string]string) (ret bool, err error) {
+	defer c.observe("SetStoreLabels", time.Now(), &err)
+	return c.PDClient.SetStoreLabels(storeID, labels)
+}
+
+func (c *instrumentedPDClient) UpdateReplicationConfig(config PDReplicationConfig) (err error) {
+	defer c.observe("UpdateReplicationConfig", time.Now(), &err)
+	return c.PDClient.UpdateReplicationConfig(config)
+}
+
+func (c *instrumentedPDClient) UpdateConfig(config map[string]interface{}) (err error) {
+	defer c.observe("UpdateConfig", time.Now(), &err)
+	return c.PDClient.UpdateConfig(config)
+}
+
+func (c *instrumentedPDClient) DeleteStore(storeID uint64) (err error) {
+	defer c.observe("DeleteStore", time.Now(), &err)
+	return c.PDClient.DeleteStore(storeID)
+}
+
+func (c *instrumentedPDClient) SetStoreState(storeID uint64, state string) (err error) {
+	defer c.observe("SetStoreState", time.Now(), &err)
+	return c.PDClient.SetStoreState(storeID, state)
+}
+
+func (c *instrumentedPDClient) DeleteMember(name string) (err error) {
+	defer c.observe("DeleteMember", time.Now(), &err)
+	return c.PDClient.DeleteMember(name)
+}
+
+func (c *instrumentedPDClient) DeleteMemberByID(memberID uint64) (err error) {
+	defer c.observe("DeleteMemberByID", time.Now(), &err)
+	return c.PDClient.DeleteMemberByID(memberID)
+}
+
+func (c *instrumentedPDClient) BeginEvictLeader(storeID uint64) (err error) {
+	defer c.observe("BeginEvictLeader", time.Now(), &err)
+	return c.PDClient.BeginEvictLeader(storeID)
+}
+
+func (c *instrumentedPDClient) EndEvictLeader(storeID uint64) (err error) {
+	defer c.observe("EndEvictLeader", time.Now(), &err)
+	return c.PDClient.EndEvictLeader(storeID)
+}
+
+func (c *instrumentedPDClient) GetEvictLeaderSchedulers() (ret []string, err error) {
+	defer c.observe("GetEvictLeaderSchedulers", time.Now(), &err)
+	return c.PDClient.GetEvictLeaderSchedulers()
+}
+
+func (c *instrumentedPDClient) GetPDLeader() (ret *pdpb.Member, err error) {
+	defer c.observe("GetPDLeader", time.Now(), &err)
+	return c.PDClient.GetPDLeader()
+}
+
+func (c *instrumentedPDClient) TransferPDLeader(name string) (err error) {
+	defer c.observe("TransferPDLeader", time.Now(), &err)
+	return c.PDClient.TransferPDLeader(name)
+}
+
+func (c *instrumentedPDClient) GetAutoscalingPlans(strategy Strategy) (ret []Plan, err error) {
+	defer c.observe("GetAutoscalingPlans", time.Now(), &err)
+	return c.PDClient.GetAutoscalingPlans(strategy)
+}
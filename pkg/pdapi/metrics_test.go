@@ -0,0 +1,47 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdapi
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestInstrumentedPDClient(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeClient := NewFakePDClient()
+	fakeClient.AddReaction(GetHealthActionType, func(action *Action) (interface{}, error) {
+		return &HealthInfo{}, nil
+	})
+	fakeClient.AddReaction(DeleteStoreActionType, func(action *Action) (interface{}, error) {
+		return nil, fmt.Errorf("failed to delete store")
+	})
+	client := newInstrumentedPDClient(fakeClient, "ns", "tc")
+
+	_, err := client.GetHealth()
+	g.Expect(err).NotTo(HaveOccurred())
+	err = client.DeleteStore(1)
+	g.Expect(err).To(HaveOccurred())
+	err = client.DeleteStore(1)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(testutil.CollectAndCount(metrics.PDAPIRequestDuration)).To(Equal(2))
+	g.Expect(testutil.ToFloat64(metrics.PDAPIRequestErrors.WithLabelValues("ns", "tc", "GetHealth"))).To(Equal(float64(0)))
+	g.Expect(testutil.ToFloat64(metrics.PDAPIRequestErrors.WithLabelValues("ns", "tc", "DeleteStore"))).To(Equal(float64(2)))
+}
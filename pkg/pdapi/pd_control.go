@@ -143,13 +143,13 @@ func (pdc *defaultPDControl) GetPeerPDClient(namespace Namespace, tcName string,
 		tlsConfig, err = GetTLSConfig(pdc.kubeCli, namespace, tcName, util.ClusterClientTLSSecretName(tcName))
 		if err != nil {
 			klog.Errorf("Unable to get tls config for tidb cluster %q in %s, pd client may not work: %v", tcName, namespace, err)
-			return &pdClient{url: clientURL, httpClient: &http.Client{Timeout: DefaultTimeout}}
+			return newInstrumentedPDClient(&pdClient{url: clientURL, httpClient: &http.Client{Timeout: DefaultTimeout}}, namespace, tcName)
 		}
 
-		return NewPDClient(clientURL, DefaultTimeout, tlsConfig)
+		return newInstrumentedPDClient(NewPDClient(clientURL, DefaultTimeout, tlsConfig), namespace, tcName)
 	}
 	if _, ok := pdc.pdClients[clientName]; !ok {
-		pdc.pdClients[clientName] = NewPDClient(clientURL, DefaultTimeout, nil)
+		pdc.pdClients[clientName] = newInstrumentedPDClient(NewPDClient(clientURL, DefaultTimeout, nil), namespace, tcName)
 	}
 	return pdc.pdClients[clientName]
 }
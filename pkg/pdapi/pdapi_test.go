@@ -354,6 +354,59 @@ func TestSetStoreLabels(t *testing.T) {
 	}
 }
 
+func TestUpdateConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+	config := map[string]interface{}{
+		"schedule.max-snapshot-count": float64(8),
+		"replication.location-labels": []interface{}{"zone", "host"},
+	}
+	tcs := []struct {
+		caseName string
+		path     string
+		method   string
+		want     bool
+	}{{
+		caseName: "success_UpdateConfig",
+		path:     fmt.Sprintf("/%s", configPrefix),
+		method:   "POST",
+		want:     true,
+	}, {
+		caseName: "failed_UpdateConfig",
+		path:     fmt.Sprintf("/%s", configPrefix),
+		method:   "POST",
+		want:     false,
+	},
+	}
+
+	for _, tc := range tcs {
+		svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.Method).To(Equal(tc.method), "check method")
+			g.Expect(request.URL.Path).To(Equal(tc.path), "check url")
+
+			got := map[string]interface{}{}
+			err := readJSON(request.Body, &got)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(config), "check config")
+
+			w.Header().Set("Content-Type", ContentTypeJSON)
+			if tc.want {
+				w.WriteHeader(http.StatusOK)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		})
+		defer svc.Close()
+
+		pdClient := NewPDClient(svc.URL, DefaultTimeout, &tls.Config{})
+		err := pdClient.UpdateConfig(config)
+		if tc.want {
+			g.Expect(err).NotTo(HaveOccurred(), tc.caseName)
+		} else {
+			g.Expect(err).To(HaveOccurred(), tc.caseName)
+		}
+	}
+}
+
 func TestDeleteMember(t *testing.T) {
 	g := NewGomegaWithT(t)
 	name := "testMember"
@@ -16,5 +16,8 @@ package registry
 var (
 	Strategies = []CreateUpdateStrategy{
 		TidbClusterStrategy{},
+		BackupStrategy{},
+		RestoreStrategy{},
+		BackupScheduleStrategy{},
 	}
 )
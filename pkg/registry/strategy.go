@@ -74,3 +74,12 @@ type CreateUpdateStrategy interface {
 	// ValidateUpdate validates an update request for existing resource
 	ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList
 }
+
+// WarningStrategy is optionally implemented by a CreateUpdateStrategy to return warnings to the client for a request
+// that is allowed but may not do what the user expects.
+type WarningStrategy interface {
+	// WarningsOnCreate returns warnings for the creation of the given object
+	WarningsOnCreate(ctx context.Context, obj runtime.Object) []string
+	// WarningsOnUpdate returns warnings for the update of the given object
+	WarningsOnUpdate(ctx context.Context, obj, old runtime.Object) []string
+}
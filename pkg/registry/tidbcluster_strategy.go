@@ -57,6 +57,24 @@ func (TidbClusterStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.
 	return field.ErrorList{}
 }
 
+func (TidbClusterStrategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string {
+	if tc, ok := castTidbCluster(obj); ok {
+		_, warnings := validation.ValidateTidbClusterConfig(tc)
+		return warnings
+	}
+	return nil
+}
+
+func (TidbClusterStrategy) WarningsOnUpdate(ctx context.Context, obj, old runtime.Object) []string {
+	oldTc, oldOk := castTidbCluster(old)
+	tc, ok := castTidbCluster(obj)
+	if ok && oldOk {
+		_, warnings := validation.ValidateUpdateTidbClusterConfig(oldTc, tc)
+		return warnings
+	}
+	return nil
+}
+
 func castTidbCluster(obj runtime.Object) (*v1alpha1.TidbCluster, bool) {
 	tc, ok := obj.(*v1alpha1.TidbCluster)
 	if !ok {
@@ -15,6 +15,7 @@ package predicates
 
 import (
 	v1 "k8s.io/api/core/v1"
+	schedulerapi "k8s.io/kube-scheduler/extender/v1"
 )
 
 type FakePredicate struct {
@@ -42,3 +43,29 @@ func (f *FakePredicate) Filter(_ string, _ *v1.Pod, nodes []v1.Node) ([]v1.Node,
 	}
 	return nodes, nil
 }
+
+type FakePrioritizer struct {
+	FakeName string
+	Scores   map[string]int64
+	Err      error
+}
+
+var _ Prioritizer = &FakePrioritizer{}
+
+func (f *FakePrioritizer) Name() string {
+	return f.FakeName
+}
+
+func (f *FakePrioritizer) Prioritize(_ string, _ *v1.Pod, nodes []v1.Node) (schedulerapi.HostPriorityList, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	result := schedulerapi.HostPriorityList{}
+	for _, node := range nodes {
+		result = append(result, schedulerapi.HostPriority{
+			Host:  node.Name,
+			Score: f.Scores[node.Name],
+		})
+	}
+	return result, nil
+}
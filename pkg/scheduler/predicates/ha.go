@@ -125,12 +125,7 @@ func (h *ha) Filter(instanceName string, pod *apiv1.Pod, nodes []apiv1.Node) ([]
 	replicas := getReplicasFrom(tc, component)
 	klog.Infof("ha: tidbcluster %s/%s component %s replicas %d", ns, tcName, component, replicas)
 
-	var topologyKey string
-	if tc.Annotations[label.AnnHATopologyKey] != "" {
-		topologyKey = tc.Annotations[label.AnnHATopologyKey]
-	} else {
-		topologyKey = "kubernetes.io/hostname"
-	}
+	topologyKey := getTopologyKey(tc)
 	klog.Infof("current topology key: %s", topologyKey)
 
 	allTopologies := make(sets.String)
@@ -467,6 +462,8 @@ func isPodDesired(tc *v1alpha1.TidbCluster, component, podName string) bool {
 	ordinals := tc.TiKVStsDesiredOrdinals(false)
 	if component == v1alpha1.PDMemberType.String() {
 		ordinals = tc.PDStsDesiredOrdinals(false)
+	} else if component == v1alpha1.TiFlashMemberType.String() {
+		ordinals = tc.TiFlashStsDesiredOrdinals(false)
 	}
 	ordinal, err := util.GetOrdinalFromPodName(podName)
 	if err != nil {
@@ -486,6 +483,15 @@ func isFailureMember(tc *v1alpha1.TidbCluster, component, podName string) bool {
 		return false
 	}
 
+	if component == v1alpha1.TiFlashMemberType.String() {
+		for _, fs := range tc.Status.TiFlash.FailureStores {
+			if fs.PodName == podName {
+				return true
+			}
+		}
+		return false
+	}
+
 	for _, fs := range tc.Status.TiKV.FailureStores {
 		if fs.PodName == podName {
 			return true
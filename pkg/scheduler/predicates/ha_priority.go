@@ -0,0 +1,268 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package predicates
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+	schedulerapi "k8s.io/kube-scheduler/extender/v1"
+)
+
+const (
+	// spreadWeight and utilizationWeight are the weights of the spreading score and the
+	// node utilization score in the final score, spreading across topologies is preferred.
+	spreadWeight      = 2
+	utilizationWeight = 1
+
+	// NodeNameIndex is the name of the pod index by the node the pod is scheduled to
+	NodeNameIndex = "spec.nodeName"
+)
+
+// PodNodeNameIndexFunc indexes the pods by the node they are scheduled to
+func PodNodeNameIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*apiv1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return []string{}, nil
+	}
+	return []string{pod.Spec.NodeName}, nil
+}
+
+type haPriority struct {
+	kubeCli            kubernetes.Interface
+	cli                versioned.Interface
+	podIndexer         cache.Indexer
+	podListFn          func(ns, instanceName, component string) (*apiv1.PodList, error)
+	tcGetFn            func(ns, tcName string) (*v1alpha1.TidbCluster, error)
+	scheduledNodeGetFn func(nodeName string) (*apiv1.Node, error)
+	nodePodListFn      func(nodeName string) (*apiv1.PodList, error)
+}
+
+// NewHAPriority returns a Prioritizer which prefers the nodes spreading the pods of
+// PD/TiKV/TiFlash evenly across the topologies and the nodes with lower utilization,
+// podIndexer must be indexed by NodeNameIndex, the pods of a node are listed from the apiserver if it is nil.
+func NewHAPriority(kubeCli kubernetes.Interface, cli versioned.Interface, podIndexer cache.Indexer) Prioritizer {
+	p := &haPriority{
+		kubeCli:    kubeCli,
+		cli:        cli,
+		podIndexer: podIndexer,
+	}
+	p.podListFn = p.realPodListFn
+	p.tcGetFn = p.realTCGetFn
+	p.scheduledNodeGetFn = p.realScheduledNodeGetFn
+	p.nodePodListFn = p.realNodePodListFn
+	return p
+}
+
+func (p *haPriority) Name() string {
+	return "HAPriority"
+}
+
+// Prioritize scores each node by how evenly the pods of the component are spread across the topologies
+// if the pod is scheduled to the node, the nodes in the topologies with the fewest pods get the highest score.
+// The ratio of the unrequested cpu and memory of the node is also taken into account, the final score is
+// the weighted average of the spreading score and the utilization score.
+func (p *haPriority) Prioritize(instanceName string, pod *apiv1.Pod, nodes []apiv1.Node) (schedulerapi.HostPriorityList, error) {
+	ns := pod.GetNamespace()
+	component := pod.Labels[label.ComponentLabelKey]
+	result := make(schedulerapi.HostPriorityList, 0, len(nodes))
+
+	if component != label.PDLabelVal && component != label.TiKVLabelVal && component != label.TiFlashLabelVal {
+		klog.V(4).Infof("component %s is ignored in HA priority", component)
+		for _, node := range nodes {
+			result = append(result, schedulerapi.HostPriority{Host: node.Name, Score: 0})
+		}
+		return result, nil
+	}
+	if len(nodes) == 0 {
+		return result, nil
+	}
+
+	tc, err := p.tcGetFn(ns, getTCNameFromPod(pod, component))
+	if err != nil {
+		return nil, err
+	}
+	spreadScores, err := p.spreadScores(tc, component, instanceName, pod, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		utilization := p.utilizationScore(pod, &node)
+		score := (spreadWeight*spreadScores[node.Name] + utilizationWeight*utilization) / (spreadWeight + utilizationWeight)
+		klog.V(4).Infof("ha priority: pod %s/%s node %s spread score %d utilization score %d",
+			ns, pod.GetName(), node.Name, spreadScores[node.Name], utilization)
+		result = append(result, schedulerapi.HostPriority{Host: node.Name, Score: score})
+	}
+	return result, nil
+}
+
+// spreadScores returns the spreading scores of the nodes, the nodes without the topology label get 0
+func (p *haPriority) spreadScores(tc *v1alpha1.TidbCluster, component, instanceName string, pod *apiv1.Pod, nodes []apiv1.Node) (map[string]int64, error) {
+	topologyKey := getTopologyKey(tc)
+
+	nodeTopology := map[string]string{}
+	topologyCount := map[string]int{}
+	for _, node := range nodes {
+		topology, ok := node.Labels[topologyKey]
+		if !ok {
+			continue
+		}
+		nodeTopology[node.Name] = topology
+		topologyCount[topology] = 0
+	}
+
+	podList, err := p.podListFn(pod.GetNamespace(), instanceName, component)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range podList.Items {
+		nodeName := item.Spec.NodeName
+		if item.Name == pod.Name || nodeName == "" {
+			continue
+		}
+		if !isPodDesired(tc, component, item.Name) || isFailureMember(tc, component, item.Name) {
+			continue
+		}
+		topology, ok := nodeTopology[nodeName]
+		if !ok {
+			scheduledNode, err := p.scheduledNodeGetFn(nodeName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get node %s, error: %v", nodeName, err)
+			}
+			topology = scheduledNode.Labels[topologyKey]
+			nodeTopology[nodeName] = topology
+		}
+		if _, ok := topologyCount[topology]; ok {
+			topologyCount[topology]++
+		}
+	}
+
+	min, max := -1, -1
+	for _, count := range topologyCount {
+		if min == -1 || count < min {
+			min = count
+		}
+		if max == -1 || count > max {
+			max = count
+		}
+	}
+
+	scores := map[string]int64{}
+	for _, node := range nodes {
+		topology, ok := node.Labels[topologyKey]
+		if !ok {
+			scores[node.Name] = 0
+			continue
+		}
+		if max == min {
+			scores[node.Name] = schedulerapi.MaxExtenderPriority
+			continue
+		}
+		scores[node.Name] = schedulerapi.MaxExtenderPriority * int64(max-topologyCount[topology]) / int64(max-min)
+	}
+	return scores, nil
+}
+
+// utilizationScore returns the score of the node by the ratio of the unrequested cpu and memory
+// after the pod is scheduled to it, MaxExtenderPriority is returned if it can't be calculated.
+func (p *haPriority) utilizationScore(pod *apiv1.Pod, node *apiv1.Node) int64 {
+	podList, err := p.nodePodListFn(node.Name)
+	if err != nil {
+		klog.Warningf("ha priority: failed to list pods on node %s, ignore its utilization, error: %v", node.Name, err)
+		return schedulerapi.MaxExtenderPriority
+	}
+
+	pods := append([]apiv1.Pod{*pod}, podList.Items...)
+	var total float64
+	var count int
+	for _, resourceName := range []apiv1.ResourceName{apiv1.ResourceCPU, apiv1.ResourceMemory} {
+		allocatable, ok := node.Status.Allocatable[resourceName]
+		if !ok || allocatable.IsZero() {
+			continue
+		}
+		var requested int64
+		for _, item := range pods {
+			if item.Status.Phase == apiv1.PodSucceeded || item.Status.Phase == apiv1.PodFailed {
+				continue
+			}
+			for _, c := range item.Spec.Containers {
+				if r, ok := c.Resources.Requests[resourceName]; ok {
+					requested += r.MilliValue()
+				}
+			}
+		}
+		ratio := float64(requested) / float64(allocatable.MilliValue())
+		if ratio > 1 {
+			ratio = 1
+		}
+		total += ratio
+		count++
+	}
+	if count == 0 {
+		return schedulerapi.MaxExtenderPriority
+	}
+	return int64(float64(schedulerapi.MaxExtenderPriority) * (1 - total/float64(count)))
+}
+
+func (p *haPriority) realPodListFn(ns, instanceName, component string) (*apiv1.PodList, error) {
+	selector := label.New().Instance(instanceName).Component(component).Labels()
+	return p.kubeCli.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+}
+
+func (p *haPriority) realTCGetFn(ns, tcName string) (*v1alpha1.TidbCluster, error) {
+	return p.cli.PingcapV1alpha1().TidbClusters(ns).Get(context.TODO(), tcName, metav1.GetOptions{})
+}
+
+func (p *haPriority) realScheduledNodeGetFn(nodeName string) (*apiv1.Node, error) {
+	return p.kubeCli.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+}
+
+func (p *haPriority) realNodePodListFn(nodeName string) (*apiv1.PodList, error) {
+	if p.podIndexer == nil {
+		return p.kubeCli.CoreV1().Pods(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(NodeNameIndex, nodeName).String(),
+		})
+	}
+	objs, err := p.podIndexer.ByIndex(NodeNameIndex, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	podList := &apiv1.PodList{}
+	for _, obj := range objs {
+		if pod, ok := obj.(*apiv1.Pod); ok {
+			podList.Items = append(podList.Items, *pod)
+		}
+	}
+	return podList, nil
+}
+
+func getTopologyKey(tc *v1alpha1.TidbCluster) string {
+	if tc.Annotations[label.AnnHATopologyKey] != "" {
+		return tc.Annotations[label.AnnHATopologyKey]
+	}
+	return "kubernetes.io/hostname"
+}
@@ -0,0 +1,229 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package predicates
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulerapi "k8s.io/kube-scheduler/extender/v1"
+)
+
+func TestHAPriority(t *testing.T) {
+	g := NewGomegaWithT(t)
+	type testcase struct {
+		name          string
+		podFn         func(string, string, int32) *apiv1.Pod
+		nodesFn       func() []apiv1.Node
+		podListFn     func(string, string, string) (*apiv1.PodList, error)
+		tcGetFn       func(string, string) (*v1alpha1.TidbCluster, error)
+		nodePodListFn func(string) (*apiv1.PodList, error)
+		expectFn      func(map[string]int64, error)
+	}
+
+	instanceName := "demo"
+	clusterName := "cluster-1"
+	testFn := func(test *testcase, t *testing.T) {
+		t.Log(test.name)
+
+		pod := test.podFn(instanceName, clusterName, 0)
+		nodes := test.nodesFn()
+
+		p := haPriority{
+			podListFn:          test.podListFn,
+			tcGetFn:            test.tcGetFn,
+			scheduledNodeGetFn: fakeZeroScheduledNode,
+			nodePodListFn:      test.nodePodListFn,
+		}
+		if p.nodePodListFn == nil {
+			p.nodePodListFn = nodePodListFn(nil)
+		}
+		result, err := p.Prioritize(instanceName, pod, nodes)
+		scores := map[string]int64{}
+		for _, hp := range result {
+			scores[hp.Host] = hp.Score
+		}
+		test.expectFn(scores, err)
+	}
+
+	tests := []testcase{
+		{
+			name:      "no pods scheduled, all nodes get the max score",
+			podFn:     newHAPDPod,
+			nodesFn:   fakeThreeNodes,
+			podListFn: podListFn(map[string][]int32{}),
+			tcGetFn:   tcGetFn,
+			expectFn: func(scores map[string]int64, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(scores).To(Equal(map[string]int64{
+					"kube-node-1": schedulerapi.MaxExtenderPriority,
+					"kube-node-2": schedulerapi.MaxExtenderPriority,
+					"kube-node-3": schedulerapi.MaxExtenderPriority,
+				}))
+			},
+		},
+		{
+			name:      "two pods scheduled, prefer the empty topology",
+			podFn:     newHAPDPod,
+			nodesFn:   fakeThreeNodes,
+			podListFn: podListFn(map[string][]int32{"kube-node-1": {1}, "kube-node-2": {2}}),
+			tcGetFn:   tcGetFn,
+			expectFn: func(scores map[string]int64, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(scores).To(Equal(map[string]int64{
+					"kube-node-1": 3,
+					"kube-node-2": 3,
+					"kube-node-3": 10,
+				}))
+			},
+		},
+		{
+			name:      "pods not in desired ordinals are not counted",
+			podFn:     newHAPDPod,
+			nodesFn:   fakeThreeNodes,
+			podListFn: podListFn(map[string][]int32{"kube-node-1": {1}, "kube-node-2": {5}}),
+			tcGetFn:   tcGetFn,
+			expectFn: func(scores map[string]int64, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(scores).To(Equal(map[string]int64{
+					"kube-node-1": 3,
+					"kube-node-2": 10,
+					"kube-node-3": 10,
+				}))
+			},
+		},
+		{
+			name:    "the node in the same topology of a scheduled pod gets the lower score",
+			podFn:   newHAPDPod,
+			nodesFn: fakeFourNodesWithThreeTopologies,
+			podListFn: podListFn(map[string][]int32{
+				"kube-node-3": {1},
+			}),
+			tcGetFn: tcGetFn,
+			expectFn: func(scores map[string]int64, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(scores).To(Equal(map[string]int64{
+					"kube-node-1": 10,
+					"kube-node-2": 10,
+					"kube-node-3": 3,
+					"kube-node-4": 3,
+				}))
+			},
+		},
+		{
+			name:      "prefer the node with lower utilization",
+			podFn:     newHAPDPod,
+			nodesFn:   fakeThreeNodesWithAllocatable("4", "8Gi"),
+			podListFn: podListFn(map[string][]int32{}),
+			tcGetFn:   tcGetFn,
+			nodePodListFn: nodePodListFn(map[string][]apiv1.Pod{
+				"kube-node-1": {newPodWithRequests("2", "4Gi")},
+				"kube-node-2": {newPodWithRequests("4", "8Gi")},
+			}),
+			expectFn: func(scores map[string]int64, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(scores).To(Equal(map[string]int64{
+					"kube-node-1": 8,
+					"kube-node-2": 6,
+					"kube-node-3": 10,
+				}))
+			},
+		},
+		{
+			name:          "failed to list pods on nodes, ignore the utilization",
+			podFn:         newHAPDPod,
+			nodesFn:       fakeThreeNodesWithAllocatable("4", "8Gi"),
+			podListFn:     podListFn(map[string][]int32{"kube-node-1": {1}}),
+			tcGetFn:       tcGetFn,
+			nodePodListFn: func(string) (*apiv1.PodList, error) { return nil, errors.New("list pods failed") },
+			expectFn: func(scores map[string]int64, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(scores).To(Equal(map[string]int64{
+					"kube-node-1": 3,
+					"kube-node-2": 10,
+					"kube-node-3": 10,
+				}))
+			},
+		},
+		{
+			name:      "list pods of the component failed",
+			podFn:     newHATiKVPod,
+			nodesFn:   fakeThreeNodes,
+			podListFn: podListErr(),
+			tcGetFn:   tcGetFn,
+			expectFn: func(scores map[string]int64, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("list pods failed"))
+			},
+		},
+		{
+			name: "component without HA priority",
+			podFn: func(instanceName, clusterName string, ordinal int32) *apiv1.Pod {
+				pod := newHAPDPod(instanceName, clusterName, ordinal)
+				pod.Labels = label.New().Instance(instanceName).TiDB().Labels()
+				return pod
+			},
+			nodesFn: fakeThreeNodes,
+			expectFn: func(scores map[string]int64, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(scores).To(Equal(map[string]int64{
+					"kube-node-1": 0,
+					"kube-node-2": 0,
+					"kube-node-3": 0,
+				}))
+			},
+		},
+	}
+
+	for i := range tests {
+		testFn(&tests[i], t)
+	}
+}
+
+func newPodWithRequests(cpu, memory string) apiv1.Pod {
+	return apiv1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: apiv1.NamespaceDefault,
+		},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{
+					Resources: apiv1.ResourceRequirements{
+						Requests: apiv1.ResourceList{
+							apiv1.ResourceCPU:    resource.MustParse(cpu),
+							apiv1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func nodePodListFn(nodePods map[string][]apiv1.Pod) func(string) (*apiv1.PodList, error) {
+	return func(nodeName string) (*apiv1.PodList, error) {
+		return &apiv1.PodList{
+			TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+			Items:    nodePods[nodeName],
+		}, nil
+	}
+}
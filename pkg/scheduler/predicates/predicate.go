@@ -15,6 +15,7 @@ package predicates
 
 import (
 	apiv1 "k8s.io/api/core/v1"
+	schedulerapi "k8s.io/kube-scheduler/extender/v1"
 )
 
 // Predicate is an interface as extender-implemented predicate functions
@@ -26,6 +27,16 @@ type Predicate interface {
 	Filter(string, *apiv1.Pod, []apiv1.Node) ([]apiv1.Node, error)
 }
 
+// Prioritizer is an interface as extender-implemented priority functions
+type Prioritizer interface {
+	// Name return the priority function name
+	Name() string
+
+	// Prioritize function receives a set of nodes and returns the scores of them,
+	// each score ranges from 0 to schedulerapi.MaxExtenderPriority.
+	Prioritize(string, *apiv1.Pod, []apiv1.Node) (schedulerapi.HostPriorityList, error)
+}
+
 func getNodeFromTopologies(nodes []apiv1.Node, topologyKey string, topologies []string) []apiv1.Node {
 	var retNodes []apiv1.Node
 	for _, node := range nodes {
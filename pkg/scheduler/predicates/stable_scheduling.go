@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/util"
 	apiv1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,7 +38,7 @@ const (
 
 var (
 	// supportedComponents holds the supported components
-	supportedComponents = sets.NewString(label.TiDBLabelVal)
+	supportedComponents = sets.NewString(label.TiDBLabelVal, label.PDLabelVal, label.TiKVLabelVal)
 )
 
 type stableScheduling struct {
@@ -59,15 +60,25 @@ func (p *stableScheduling) Name() string {
 }
 
 func (p *stableScheduling) findPreviousNodeInTC(tc *v1alpha1.TidbCluster, pod *apiv1.Pod) string {
-	members := tc.Status.TiDB.Members
-	if members == nil {
-		return ""
-	}
-	tidbMember, ok := tc.Status.TiDB.Members[pod.Name]
-	if !ok {
-		return ""
+	switch pod.Labels[label.ComponentLabelKey] {
+	case label.PDLabelVal:
+		for name, member := range tc.Status.PD.Members {
+			if util.PDMemberPodName(name) == pod.Name {
+				return member.NodeName
+			}
+		}
+	case label.TiKVLabelVal:
+		for _, store := range tc.Status.TiKV.Stores {
+			if store.PodName == pod.Name {
+				return store.NodeName
+			}
+		}
+	default:
+		if tidbMember, ok := tc.Status.TiDB.Members[pod.Name]; ok {
+			return tidbMember.NodeName
+		}
 	}
-	return tidbMember.NodeName
+	return ""
 }
 
 func (p *stableScheduling) Filter(instanceName string, pod *apiv1.Pod, nodes []apiv1.Node) ([]apiv1.Node, error) {
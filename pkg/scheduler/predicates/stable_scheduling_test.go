@@ -83,9 +83,9 @@ func TestStableSchedulingFilter(t *testing.T) {
 
 	tests := []testcase{
 		{
-			name:         "cannot schedule to previous node because it's not a TiDB pod",
+			name:         "cannot schedule to previous node because the component is not supported",
 			instanceName: "demo",
-			pod:          makePod("demo-tikv-0", label.TiKVLabelVal),
+			pod:          makePod("demo-tiflash-0", label.TiFlashLabelVal),
 			tidbCluster:  nil,
 			candicateNodes: []v1.Node{
 				makeNode("node-1"),
@@ -160,6 +160,115 @@ func TestStableSchedulingFilter(t *testing.T) {
 				g.Expect(getSortedNodeNames(nodes)).To(Equal([]string{"node-2"}))
 			},
 		},
+		{
+			name:         "schedule the pd pod to previous node",
+			instanceName: "demo",
+			pod:          makePod("demo-pd-1", label.PDLabelVal),
+			tidbCluster: func() *v1alpha1.TidbCluster {
+				tc := makeTidbCluster("", "")
+				tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+					"demo-pd-0":                          {Name: "demo-pd-0", NodeName: "node-1"},
+					"demo-pd-1.demo-pd-peer.default.svc": {Name: "demo-pd-1.demo-pd-peer.default.svc", NodeName: "node-3"},
+				}
+				return tc
+			}(),
+			candicateNodes: []v1.Node{
+				makeNode("node-1"),
+				makeNode("node-2"),
+				makeNode("node-3"),
+			},
+			expectFn: func(nodes []v1.Node, err error, recorder *record.FakeRecorder) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(getSortedNodeNames(nodes)).To(Equal([]string{"node-3"}))
+			},
+		},
+		{
+			name:         "schedule the pd pod named by the pod name to previous node",
+			instanceName: "demo",
+			pod:          makePod("demo-pd-0", label.PDLabelVal),
+			tidbCluster: func() *v1alpha1.TidbCluster {
+				tc := makeTidbCluster("", "")
+				tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+					"demo-pd-0":  {Name: "demo-pd-0", NodeName: "node-1"},
+					"demo-pd-10": {Name: "demo-pd-10", NodeName: "node-2"},
+				}
+				return tc
+			}(),
+			candicateNodes: []v1.Node{
+				makeNode("node-1"),
+				makeNode("node-2"),
+				makeNode("node-3"),
+			},
+			expectFn: func(nodes []v1.Node, err error, recorder *record.FakeRecorder) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(getSortedNodeNames(nodes)).To(Equal([]string{"node-1"}))
+			},
+		},
+		{
+			name:         "fall back to all candicates because the pd pod has no previous node",
+			instanceName: "demo",
+			pod:          makePod("demo-pd-2", label.PDLabelVal),
+			tidbCluster: func() *v1alpha1.TidbCluster {
+				tc := makeTidbCluster("", "")
+				tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+					"demo-pd-0.demo-pd-peer.default.svc": {Name: "demo-pd-0.demo-pd-peer.default.svc", NodeName: "node-1"},
+				}
+				return tc
+			}(),
+			candicateNodes: []v1.Node{
+				makeNode("node-1"),
+				makeNode("node-2"),
+			},
+			expectFn: func(nodes []v1.Node, err error, recorder *record.FakeRecorder) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("no previous node exists for pod \"demo-pd-2\""))
+				g.Expect(len(nodes)).To(Equal(2))
+			},
+		},
+		{
+			name:         "schedule the tikv pod to previous node",
+			instanceName: "demo",
+			pod:          makePod("demo-tikv-0", label.TiKVLabelVal),
+			tidbCluster: func() *v1alpha1.TidbCluster {
+				tc := makeTidbCluster("", "")
+				tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+					"1": {ID: "1", PodName: "demo-tikv-0", NodeName: "node-2"},
+					"2": {ID: "2", PodName: "demo-tikv-1", NodeName: "node-1"},
+				}
+				return tc
+			}(),
+			candicateNodes: []v1.Node{
+				makeNode("node-1"),
+				makeNode("node-2"),
+				makeNode("node-3"),
+			},
+			expectFn: func(nodes []v1.Node, err error, recorder *record.FakeRecorder) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(getSortedNodeNames(nodes)).To(Equal([]string{"node-2"}))
+			},
+		},
+		{
+			name:         "fall back to all candicates because previous node of the tikv pod is unavailable",
+			instanceName: "demo",
+			pod:          makePod("demo-tikv-0", label.TiKVLabelVal),
+			tidbCluster: func() *v1alpha1.TidbCluster {
+				tc := makeTidbCluster("", "")
+				tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+					"1": {ID: "1", PodName: "demo-tikv-0", NodeName: "node-4"},
+				}
+				return tc
+			}(),
+			candicateNodes: []v1.Node{
+				makeNode("node-1"),
+				makeNode("node-2"),
+				makeNode("node-3"),
+			},
+			expectFn: func(nodes []v1.Node, err error, recorder *record.FakeRecorder) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("cannot run default/demo-tikv-0 on its previous node \"node-4\""))
+				g.Expect(len(nodes)).To(Equal(3))
+			},
+		},
 	}
 
 	for _, tc := range tests {
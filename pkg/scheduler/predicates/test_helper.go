@@ -15,6 +15,7 @@ package predicates
 
 import (
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -53,6 +54,19 @@ func fakeThreeNodes() []apiv1.Node {
 	}
 }
 
+func fakeThreeNodesWithAllocatable(cpu, memory string) func() []apiv1.Node {
+	return func() []apiv1.Node {
+		nodes := fakeThreeNodes()
+		for i := range nodes {
+			nodes[i].Status.Allocatable = apiv1.ResourceList{
+				apiv1.ResourceCPU:    resource.MustParse(cpu),
+				apiv1.ResourceMemory: resource.MustParse(memory),
+			}
+		}
+		return nodes
+	}
+}
+
 func fakeFourNodes() []apiv1.Node {
 	return []apiv1.Node{
 		{
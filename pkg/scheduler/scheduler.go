@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
@@ -24,9 +25,11 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/scheduler/predicates"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	kubescheme "k8s.io/client-go/kubernetes/scheme"
 	eventv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog"
 	schedulerapi "k8s.io/kube-scheduler/extender/v1"
@@ -49,14 +52,42 @@ type Scheduler interface {
 	Priority(*schedulerapi.ExtenderArgs) (schedulerapi.HostPriorityList, error)
 }
 
+// podCacheSyncTimeout is how long the scheduler waits for the pod cache to sync on startup
+const podCacheSyncTimeout = time.Minute
+
 type scheduler struct {
 	// component => predicates
 	predicates map[string][]predicates.Predicate
+	// component => priorities
+	priorities map[string][]predicates.Prioritizer
 
 	kubeCli  kubernetes.Interface
 	recorder record.EventRecorder
 }
 
+// newPodIndexer caches the pods of all the nodes indexed by the node name for the HA priority
+// to calculate the node utilization. It returns nil if the cache fails to sync in
+// podCacheSyncTimeout, e.g. the service account is not allowed to list and watch the pods
+// of all the namespaces, the HA priority lists the pods of a node from the apiserver then.
+func newPodIndexer(kubeCli kubernetes.Interface) cache.Indexer {
+	informerFactory := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+	podInformer := informerFactory.Core().V1().Pods().Informer()
+	if err := podInformer.AddIndexers(cache.Indexers{predicates.NodeNameIndex: predicates.PodNodeNameIndexFunc}); err != nil {
+		klog.Warningf("failed to add the node name index of pods, list the pods from the apiserver instead: %v", err)
+		return nil
+	}
+	stopCh := make(chan struct{})
+	informerFactory.Start(stopCh)
+	ctx, cancel := context.WithTimeout(context.Background(), podCacheSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), podInformer.HasSynced) {
+		close(stopCh)
+		klog.Warningf("failed to sync the pod cache in %v, list the pods from the apiserver instead", podCacheSyncTimeout)
+		return nil
+	}
+	return podInformer.GetIndexer()
+}
+
 // NewScheduler returns a Scheduler
 func NewScheduler(kubeCli kubernetes.Interface, cli versioned.Interface) Scheduler {
 	eventBroadcaster := record.NewBroadcaster()
@@ -64,6 +95,9 @@ func NewScheduler(kubeCli kubernetes.Interface, cli versioned.Interface) Schedul
 	eventBroadcaster.StartRecordingToSink(&eventv1.EventSinkImpl{
 		Interface: eventv1.New(kubeCli.CoreV1().RESTClient()).Events("")})
 	recorder := eventBroadcaster.NewRecorder(kubescheme.Scheme, apiv1.EventSource{Component: "tidb-scheduler"})
+
+	podIndexer := newPodIndexer(kubeCli)
+
 	predicatesByComponent := map[string][]predicates.Predicate{
 		label.PDLabelVal: {
 			predicates.NewHA(kubeCli, cli),
@@ -72,13 +106,31 @@ func NewScheduler(kubeCli kubernetes.Interface, cli versioned.Interface) Schedul
 			predicates.NewHA(kubeCli, cli),
 		},
 	}
+	prioritiesByComponent := map[string][]predicates.Prioritizer{
+		label.PDLabelVal: {
+			predicates.NewHAPriority(kubeCli, cli, podIndexer),
+		},
+		label.TiKVLabelVal: {
+			predicates.NewHAPriority(kubeCli, cli, podIndexer),
+		},
+		label.TiFlashLabelVal: {
+			predicates.NewHAPriority(kubeCli, cli, podIndexer),
+		},
+	}
 	if features.DefaultFeatureGate.Enabled(features.StableScheduling) {
 		predicatesByComponent[label.TiDBLabelVal] = []predicates.Predicate{
 			predicates.NewStableScheduling(kubeCli, cli),
 		}
+		// stable scheduling of PD and TiKV works after HA scheduling, so that the previous
+		// node is preferred only if it does not break HA
+		predicatesByComponent[label.PDLabelVal] = append(predicatesByComponent[label.PDLabelVal],
+			predicates.NewStableScheduling(kubeCli, cli))
+		predicatesByComponent[label.TiKVLabelVal] = append(predicatesByComponent[label.TiKVLabelVal],
+			predicates.NewStableScheduling(kubeCli, cli))
 	}
 	return &scheduler{
 		predicates: predicatesByComponent,
+		priorities: prioritiesByComponent,
 		kubeCli:    kubeCli,
 		recorder:   recorder,
 	}
@@ -250,17 +302,48 @@ func (ferr FailureError) Error() string {
 	return fmt.Sprintf("pod %s had an intentional failure injected", ferr.PodName)
 }
 
-// We don't pass `prioritizeVerb` to kubernetes scheduler extender's config file, this method will not be called.
+// Priority scores the nodes from *schedulerapi.ExtenderArgs.Nodes by the priority functions of the component,
+// the score of a node is the average of the scores of all the priority functions, and 0 if no priority function
+// is registered for the component.
 func (s *scheduler) Priority(args *schedulerapi.ExtenderArgs) (schedulerapi.HostPriorityList, error) {
 	result := schedulerapi.HostPriorityList{}
+	if args.Nodes == nil {
+		return result, nil
+	}
 
-	if args.Nodes != nil {
-		for _, node := range args.Nodes.Items {
-			result = append(result, schedulerapi.HostPriority{
-				Host:  node.Name,
-				Score: 0,
-			})
+	kubeNodes := args.Nodes.Items
+	scores := make(map[string]int64, len(kubeNodes))
+	pod := args.Pod
+	var prioritizers []predicates.Prioritizer
+	var instanceName string
+	if pod != nil {
+		instanceName = pod.Labels[label.InstanceLabelKey]
+		if instanceName != "" {
+			prioritizers = s.priorities[pod.Labels[label.ComponentLabelKey]]
+		}
+	}
+
+	for _, prioritizer := range prioritizers {
+		klog.V(4).Infof("entering priority: %s, nodes: %v", prioritizer.Name(), predicates.GetNodeNames(kubeNodes))
+		hostPriorities, err := prioritizer.Prioritize(instanceName, pod, kubeNodes)
+		if err != nil {
+			klog.Errorf("priority %s failed for pod %s/%s, error: %v", prioritizer.Name(), pod.GetNamespace(), pod.GetName(), err)
+			return nil, err
+		}
+		for _, hp := range hostPriorities {
+			scores[hp.Host] += hp.Score
+		}
+	}
+
+	for _, node := range kubeNodes {
+		score := int64(0)
+		if len(prioritizers) > 0 {
+			score = scores[node.Name] / int64(len(prioritizers))
 		}
+		result = append(result, schedulerapi.HostPriority{
+			Host:  node.Name,
+			Score: score,
+		})
 	}
 
 	return result, nil
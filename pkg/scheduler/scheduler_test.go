@@ -272,6 +272,52 @@ func TestSchedulerPriority(t *testing.T) {
 	}
 }
 
+func TestSchedulerPriorityWithPrioritizers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := &scheduler{
+		priorities: map[string][]predicates.Prioritizer{
+			label.PDLabelVal: {
+				&predicates.FakePrioritizer{Scores: map[string]int64{"node-1": 10, "node-2": 4}},
+				&predicates.FakePrioritizer{Scores: map[string]int64{"node-1": 6, "node-2": 0}},
+			},
+		},
+	}
+	nodes := &apiv1.NodeList{
+		Items: []apiv1.Node{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+		},
+	}
+	newPod := func(component string) *apiv1.Pod {
+		return &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-1",
+				Namespace: corev1.NamespaceDefault,
+				Labels:    label.New().Instance("demo").Component(component).Labels(),
+			},
+		}
+	}
+
+	result, err := s.Priority(&schedulerapi.ExtenderArgs{Pod: newPod(label.PDLabelVal), Nodes: nodes})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(schedulerapi.HostPriorityList{
+		{Host: "node-1", Score: 8},
+		{Host: "node-2", Score: 2},
+	}))
+
+	result, err = s.Priority(&schedulerapi.ExtenderArgs{Pod: newPod(label.TiDBLabelVal), Nodes: nodes})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(schedulerapi.HostPriorityList{
+		{Host: "node-1", Score: 0},
+		{Host: "node-2", Score: 0},
+	}))
+
+	s.priorities[label.PDLabelVal] = []predicates.Prioritizer{&predicates.FakePrioritizer{Err: fmt.Errorf("priority failed")}}
+	_, err = s.Priority(&schedulerapi.ExtenderArgs{Pod: newPod(label.PDLabelVal), Nodes: nodes})
+	g.Expect(err).To(HaveOccurred())
+}
+
 func TestSchedulerPreempt(t *testing.T) {
 	victims := &schedulerapi.Victims{
 		Pods: []*apiv1.Pod{},
@@ -0,0 +1,49 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tikvapi
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+// instrumentedTiKVClient records the duration and the errors of the requests of TiKVClient in the metrics of the
+// cluster, the api label of the metrics is the name of the method
+type instrumentedTiKVClient struct {
+	TiKVClient
+	namespace string
+	tcName    string
+}
+
+func newInstrumentedTiKVClient(client TiKVClient, namespace, tcName string) TiKVClient {
+	return &instrumentedTiKVClient{TiKVClient: client, namespace: namespace, tcName: tcName}
+}
+
+func (c *instrumentedTiKVClient) observe(api string, startTime time.Time, err *error) {
+	metrics.TiKVAPIRequestDuration.WithLabelValues(c.namespace, c.tcName, api).Observe(time.Since(startTime).Seconds())
+	if *err != nil {
+		metrics.TiKVAPIRequestErrors.WithLabelValues(c.namespace, c.tcName, api).Inc()
+	}
+}
+
+func (c *instrumentedTiKVClient) GetLeaderCount() (ret int, err error) {
+	defer c.observe("GetLeaderCount", time.Now(), &err)
+	return c.TiKVClient.GetLeaderCount()
+}
+
+func (c *instrumentedTiKVClient) UpdateConfig(config map[string]interface{}) (err error) {
+	defer c.observe("UpdateConfig", time.Now(), &err)
+	return c.TiKVClient.UpdateConfig(config)
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tikvapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestUpdateConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+	config := map[string]interface{}{
+		"raftstore.sync-log":           false,
+		"split.qps-threshold":          int64(3000),
+		"gc.ratio-threshold":           1.5,
+		"storage.block-cache.capacity": "10GB",
+	}
+	tcs := []struct {
+		caseName string
+		want     bool
+	}{{
+		caseName: "success_UpdateConfig",
+		want:     true,
+	}, {
+		caseName: "failed_UpdateConfig",
+		want:     false,
+	},
+	}
+
+	for _, tc := range tcs {
+		svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.Method).To(Equal("POST"), "check method")
+			g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s", configPrefix)), "check url")
+
+			got := map[string]string{}
+			err := json.NewDecoder(request.Body).Decode(&got)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(map[string]string{
+				"raftstore.sync-log":           "false",
+				"split.qps-threshold":          "3000",
+				"gc.ratio-threshold":           "1.5",
+				"storage.block-cache.capacity": "10GB",
+			}), "check config")
+
+			if tc.want {
+				w.WriteHeader(http.StatusOK)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}))
+		defer svc.Close()
+
+		tikvClient := NewTiKVClient(svc.URL, DefaultTimeout, nil, true)
+		err := tikvClient.UpdateConfig(config)
+		if tc.want {
+			g.Expect(err).NotTo(HaveOccurred(), tc.caseName)
+		} else {
+			g.Expect(err).To(HaveOccurred(), tc.caseName)
+		}
+	}
+}
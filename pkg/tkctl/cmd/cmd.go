@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/completion"
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/ctop"
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/debug"
+	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/diff"
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/get"
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/info"
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/list"
@@ -81,6 +82,7 @@ func NewTkcCommand(streams genericclioptions.IOStreams) *cobra.Command {
 				version.NewCmdVersion(tkcContext, streams.Out),
 				upinfo.NewCmdUpInfo(tkcContext, streams),
 				diagnose.NewCmdDiagnoseInfo(tkcContext, streams),
+				diff.NewCmdDiff(tkcContext, streams),
 			},
 		},
 		{
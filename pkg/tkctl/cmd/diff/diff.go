@@ -0,0 +1,284 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/manager/member"
+	"github.com/pingcap/tidb-operator/pkg/tkctl/config"
+	"github.com/pingcap/tidb-operator/pkg/tkctl/readable"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+const (
+	diffLongDesc = `
+		Preview the ConfigMap and StatefulSet changes the operator would make
+		if the tidb cluster in the file was applied, and whether the pods of
+		each component would be restarted one by one.
+
+		The changes are computed against the live ConfigMaps and StatefulSets
+		of the tidb cluster, the missing ones are to be created.
+`
+	diffExample = `
+		# preview the changes of applying tc.yaml
+		tkctl diff -f tc.yaml
+
+		# print the changes as structured yaml
+		tkctl diff -f tc.yaml -o yaml
+`
+	diffUsage = "expected 'diff -f FILENAME' for the diff command"
+)
+
+// DiffOptions contains the input to the diff command.
+type DiffOptions struct {
+	Filename     string
+	OutputFormat string
+	Namespace    string
+
+	TcCli   versioned.Interface
+	KubeCli kubernetes.Interface
+
+	genericclioptions.IOStreams
+}
+
+// NewDiffOptions returns a DiffOptions
+func NewDiffOptions(streams genericclioptions.IOStreams) *DiffOptions {
+	return &DiffOptions{
+		IOStreams: streams,
+	}
+}
+
+// NewCmdDiff creates the diff command which previews the changes of applying a tidb cluster
+func NewCmdDiff(tkcContext *config.TkcContext, streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewDiffOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:     "diff -f FILENAME",
+		Short:   "Preview the changes of applying a tidb cluster.",
+		Long:    diffLongDesc,
+		Example: diffExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(tkcContext, cmd, args))
+			cmdutil.CheckErr(o.Run())
+		},
+		SuggestFor: []string{"preview", "plan"},
+	}
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", o.Filename,
+		"File that contains the proposed tidb cluster")
+	cmd.Flags().StringVarP(&o.OutputFormat, "output", "o", o.OutputFormat,
+		"Output format. json|yaml")
+
+	return cmd
+}
+
+func (o *DiffOptions) Complete(tkcContext *config.TkcContext, cmd *cobra.Command, args []string) error {
+	if o.Filename == "" {
+		return cmdutil.UsageErrorf(cmd, diffUsage)
+	}
+	switch o.OutputFormat {
+	case "", "json", "yaml":
+	default:
+		return cmdutil.UsageErrorf(cmd, "unsupported output format %q", o.OutputFormat)
+	}
+
+	clientConfig, err := tkcContext.ToTkcClientConfig()
+	if err != nil {
+		return err
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	o.Namespace = namespace
+
+	restConfig, err := clientConfig.RestConfig()
+	if err != nil {
+		return err
+	}
+	tcCli, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	o.TcCli = tcCli
+	kubeCli, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	o.KubeCli = kubeCli
+
+	return nil
+}
+
+func (o *DiffOptions) Run() error {
+	data, err := ioutil.ReadFile(o.Filename)
+	if err != nil {
+		return err
+	}
+	proposed := &v1alpha1.TidbCluster{}
+	if err := yaml.Unmarshal(data, proposed); err != nil {
+		return fmt.Errorf("decode tidb cluster from %s failed: %v", o.Filename, err)
+	}
+	if proposed.Name == "" {
+		return fmt.Errorf("tidb cluster in %s has no name", o.Filename)
+	}
+	if proposed.Namespace == "" {
+		proposed.Namespace = o.Namespace
+	}
+
+	current, err := o.TcCli.PingcapV1alpha1().
+		TidbClusters(proposed.Namespace).
+		Get(context.TODO(), proposed.Name, metav1.GetOptions{})
+	if err == nil {
+		// the builders take owner references and some member state, e.g. the
+		// failure members, from these, a file never has them
+		proposed.UID = current.UID
+		proposed.Status = current.Status
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	setLister, cmLister, err := o.liveObjectListers(proposed)
+	if err != nil {
+		return err
+	}
+	diffs, err := member.DiffTidbCluster(proposed, setLister, cmLister)
+	if err != nil {
+		return err
+	}
+
+	switch o.OutputFormat {
+	case "json":
+		out, err := json.MarshalIndent(diffs, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, string(out))
+		return nil
+	case "yaml":
+		out, err := yaml.Marshal(diffs)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.Out, string(out))
+		return nil
+	}
+
+	msg, err := renderDiffs(diffs)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(o.Out, msg)
+	return nil
+}
+
+// liveObjectListers lists the StatefulSets and ConfigMaps of the tidb cluster
+func (o *DiffOptions) liveObjectListers(tc *v1alpha1.TidbCluster) (appslisters.StatefulSetLister, corelisters.ConfigMapLister, error) {
+	listOpts := metav1.ListOptions{LabelSelector: label.New().Instance(tc.Name).String()}
+	sets, err := o.KubeCli.AppsV1().StatefulSets(tc.Namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	setIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for i := range sets.Items {
+		if err := setIndexer.Add(&sets.Items[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	cms, err := o.KubeCli.CoreV1().ConfigMaps(tc.Namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	cmIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for i := range cms.Items {
+		if err := cmIndexer.Add(&cms.Items[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return appslisters.NewStatefulSetLister(setIndexer), corelisters.NewConfigMapLister(cmIndexer), nil
+}
+
+func renderDiffs(diffs []member.ComponentDiff) (string, error) {
+	return readable.TabbedString(func(out io.Writer) error {
+		w := readable.NewPrefixWriter(out)
+		if len(diffs) == 0 {
+			w.WriteLine(readable.LEVEL_0, "No changes.")
+			return nil
+		}
+		for _, diff := range diffs {
+			w.WriteLine(readable.LEVEL_0, "%s:", diff.Component)
+			w.WriteLine(readable.LEVEL_1, "Rolling Restart:\t%t", diff.RollingRestart)
+			if len(diff.OnlineItems) > 0 {
+				w.WriteLine(readable.LEVEL_1, "Online Items:\t%s", strings.Join(diff.OnlineItems, ", "))
+			}
+			renderObjectDiff(w, "ConfigMap", diff.ConfigMap)
+			renderObjectDiff(w, "StatefulSet", diff.StatefulSet)
+		}
+		return nil
+	})
+}
+
+func renderObjectDiff(w readable.PrefixWriter, kind string, diff *member.ObjectDiff) {
+	if diff == nil {
+		return
+	}
+	w.WriteLine(readable.LEVEL_1, "%s %s (%s)", kind, diff.Name, diff.Action)
+	for _, change := range diff.Changes {
+		oldStr, oldMultiline := formatValue(change.Old)
+		newStr, newMultiline := formatValue(change.New)
+		if oldMultiline || newMultiline {
+			w.WriteLine(readable.LEVEL_2, "%s:", change.Path)
+			for _, line := range strings.Split(strings.TrimRight(cmp.Diff(oldStr, newStr), "\n"), "\n") {
+				w.WriteLine(readable.LEVEL_3, "%s", line)
+			}
+			continue
+		}
+		w.WriteLine(readable.LEVEL_2, "%s: %s -> %s", change.Path, oldStr, newStr)
+	}
+}
+
+// formatValue returns the json representation of a value and whether it is
+// a multi-line string, which is better shown as a line diff
+func formatValue(v interface{}) (string, bool) {
+	if v == nil {
+		return "<none>", false
+	}
+	if s, ok := v.(string); ok && strings.Contains(s, "\n") {
+		return s, true
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v), false
+	}
+	return string(b), false
+}
@@ -0,0 +1,166 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	"github.com/pingcap/tidb-operator/pkg/manager/member"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+const tcYaml = `
+apiVersion: pingcap.com/v1alpha1
+kind: TidbCluster
+metadata:
+  name: demo
+spec:
+  version: v4.0.8
+  pd:
+    replicas: 3
+    requests:
+      storage: 1Gi
+    config: {}
+  tikv:
+    replicas: 3
+    requests:
+      storage: 10Gi
+    config:
+      log-level: info
+`
+
+func TestDiffRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := ioutil.TempDir("", "tkctl-diff")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "tc.yaml")
+	g.Expect(ioutil.WriteFile(filename, []byte(tcYaml), 0644)).To(Succeed())
+
+	type testcase struct {
+		name     string
+		live     []runtime.Object
+		expectFn func(diffs []member.ComponentDiff)
+	}
+	tests := []testcase{
+		{
+			name: "new cluster",
+			expectFn: func(diffs []member.ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(2))
+				for _, diff := range diffs {
+					g.Expect(diff.ConfigMap.Action).To(Equal(member.ObjectActionCreate))
+					g.Expect(diff.StatefulSet.Action).To(Equal(member.ObjectActionCreate))
+				}
+			},
+		},
+		{
+			name: "live configmap",
+			live: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "demo-tikv",
+						Namespace: "ns",
+						Labels:    label.New().Instance("demo").TiKV().Labels(),
+					},
+					Data: map[string]string{"config-file": "log-level = \"debug\"\n"},
+				},
+				// not labeled as the objects of the cluster
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "demo-pd", Namespace: "ns"},
+				},
+			},
+			expectFn: func(diffs []member.ComponentDiff) {
+				g.Expect(diffs).To(HaveLen(2))
+				g.Expect(diffs[0].Component).To(Equal(v1alpha1.PDMemberType))
+				g.Expect(diffs[0].ConfigMap.Action).To(Equal(member.ObjectActionCreate))
+				g.Expect(diffs[1].Component).To(Equal(v1alpha1.TiKVMemberType))
+				g.Expect(diffs[1].ConfigMap.Action).To(Equal(member.ObjectActionUpdate))
+				g.Expect(diffs[1].ConfigMap.Name).To(Equal("demo-tikv"))
+				g.Expect(diffs[1].ConfigMap.Changes).To(ContainElement(member.FieldChange{
+					Path: "data.config-file",
+					Old:  "log-level = \"debug\"\n",
+					New:  "log-level = \"info\"\n",
+				}))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		out := &bytes.Buffer{}
+		o := NewDiffOptions(genericclioptions.IOStreams{Out: out, ErrOut: out})
+		o.Filename = filename
+		o.Namespace = "ns"
+		o.OutputFormat = "yaml"
+		o.TcCli = fake.NewSimpleClientset()
+		o.KubeCli = kubefake.NewSimpleClientset(test.live...)
+		g.Expect(o.Run()).To(Succeed())
+
+		var diffs []member.ComponentDiff
+		g.Expect(yaml.Unmarshal(out.Bytes(), &diffs)).To(Succeed())
+		test.expectFn(diffs)
+	}
+}
+
+func TestRenderDiffs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	msg, err := renderDiffs(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(msg).To(Equal("No changes.\n"))
+
+	msg, err = renderDiffs([]member.ComponentDiff{
+		{
+			Component:      v1alpha1.TiKVMemberType,
+			RollingRestart: true,
+			ConfigMap: &member.ObjectDiff{
+				Name:   "demo-tikv",
+				Action: member.ObjectActionUpdate,
+				Changes: []member.FieldChange{
+					{Path: "data.config-file", Old: "a = 1\nb = 2\n", New: "a = 1\nb = 3\n"},
+				},
+			},
+			StatefulSet: &member.ObjectDiff{
+				Name:   "demo-tikv",
+				Action: member.ObjectActionUpdate,
+				Changes: []member.FieldChange{
+					{Path: "spec.replicas", Old: int64(3), New: int64(4)},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(msg).To(ContainSubstring("tikv:"))
+	g.Expect(msg).To(MatchRegexp(`Rolling Restart:\s+true`))
+	g.Expect(msg).To(ContainSubstring("ConfigMap demo-tikv (Update)"))
+	g.Expect(msg).To(ContainSubstring("data.config-file:\n"))
+	g.Expect(msg).To(MatchRegexp(`-.*b = 2`))
+	g.Expect(msg).To(MatchRegexp(`\+.*b = 3`))
+	g.Expect(msg).To(ContainSubstring("StatefulSet demo-tikv (Update)"))
+	g.Expect(msg).To(ContainSubstring("spec.replicas: 3 -> 4"))
+}
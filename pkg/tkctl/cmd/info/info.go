@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -237,6 +238,32 @@ func renderTidbCluster(tc *v1alpha1.TidbCluster, svc *v1.Service, podList *v1.Po
 				w.Write(readable.LEVEL_0, "%s\t\n", tc.TiDBImage())
 			}
 		}
+		if summary := tc.Status.TiKV.StoreSummary; summary != nil {
+			w.WriteLine(readable.LEVEL_0, "TiKV Stores:")
+			w.WriteLine(readable.LEVEL_1, "Stores(Up/Total):\t%d/%d", summary.UpStoreCount, summary.StoreCount)
+			w.WriteLine(readable.LEVEL_1, "Capacity:\t%s", formatQuantity(summary.Capacity))
+			w.WriteLine(readable.LEVEL_1, "Available:\t%s", formatQuantity(summary.Available))
+			w.WriteLine(readable.LEVEL_1, "Used:\t%s", formatQuantity(summary.UsedSize))
+			w.WriteLine(readable.LEVEL_1, "Regions:\t%d (imbalance %d)", summary.RegionCount, summary.RegionImbalance)
+			w.WriteLine(readable.LEVEL_1, "Leaders:\t%d (imbalance %d)", summary.LeaderCount, summary.LeaderImbalance)
+			w.WriteLine(readable.LEVEL_1, "\tID\tPod\tState\tLeaders\tRegions\tCapacity\tAvailable\tLastHeartbeat")
+			w.WriteLine(readable.LEVEL_1, "\t--\t---\t-----\t-------\t-------\t--------\t---------\t-------------")
+			ids := make([]string, 0, len(tc.Status.TiKV.Stores))
+			for id := range tc.Status.TiKV.Stores {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			for _, id := range ids {
+				store := tc.Status.TiKV.Stores[id]
+				lastHeartbeat := "<unknown>"
+				if store.LastHeartbeatTime != nil {
+					lastHeartbeat = store.LastHeartbeatTime.String()
+				}
+				w.WriteLine(readable.LEVEL_1, "\t%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s",
+					store.ID, store.PodName, store.State, store.LeaderCount, store.RegionCount,
+					formatQuantity(store.Capacity), formatQuantity(store.Available), lastHeartbeat)
+			}
+		}
 		w.WriteLine(readable.LEVEL_0, "Endpoints(%s):", svc.Spec.Type)
 		if svc.Spec.Type == v1.ServiceTypeNodePort {
 			var nodePort int32
@@ -262,3 +289,10 @@ func renderTidbCluster(tc *v1alpha1.TidbCluster, svc *v1.Service, podList *v1.Po
 		return nil
 	})
 }
+
+func formatQuantity(q *resource.Quantity) string {
+	if q == nil {
+		return "<unknown>"
+	}
+	return q.String()
+}
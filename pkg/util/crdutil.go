@@ -18,10 +18,16 @@ import (
 	"strings"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1beta1"
 	crdutils "github.com/yisaer/crd-validation/pkg"
 	extensionsobj "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 )
 
+const (
+	// ConversionWebhookPath is the path of the conversion webhook
+	ConversionWebhookPath = "/convert"
+)
+
 var (
 	tidbClusteradditionalPrinterColumns []extensionsobj.CustomResourceColumnDefinition
 	tidbClusterReadyColumn              = extensionsobj.CustomResourceColumnDefinition{
@@ -202,6 +208,13 @@ var (
 		Priority:    1,
 		JSONPath:    ".status.timeCompleted",
 	}
+	backupVerifyColumn = extensionsobj.CustomResourceColumnDefinition{
+		Name:        "Verify",
+		Type:        "string",
+		Description: "The result of the latest verification of the backup",
+		Priority:    1,
+		JSONPath:    ".status.verify.phase",
+	}
 	restoreAdditionalPrinterColumns []extensionsobj.CustomResourceColumnDefinition
 	restoreStatusColumn             = extensionsobj.CustomResourceColumnDefinition{
 		Name:        "Status",
@@ -327,7 +340,7 @@ func init() {
 		dmClusterMasterColumn, dmClusterMasterStorageColumn, dmClusterMasterReadyColumn, dmClusterMasterDesireColumn,
 		dmClusterWorkerColumn, dmClusterWorkerStorageColumn, dmClusterWorkerReadyColumn, dmClusterWorkerDesireColumn,
 		dmClusterStatusMessageColumn, ageColumn)
-	backupAdditionalPrinterColumns = append(backupAdditionalPrinterColumns, backupStatusColumn, backupPathColumn, backupBackupSizeColumn, backupCommitTSColumn, backupStartedColumn, backupCompletedColumn, ageColumn)
+	backupAdditionalPrinterColumns = append(backupAdditionalPrinterColumns, backupStatusColumn, backupPathColumn, backupBackupSizeColumn, backupCommitTSColumn, backupStartedColumn, backupCompletedColumn, backupVerifyColumn, ageColumn)
 	restoreAdditionalPrinterColumns = append(restoreAdditionalPrinterColumns, restoreStatusColumn, restoreStartedColumn, restoreCompletedColumn, restoreCommitTSColumn, ageColumn)
 	bksAdditionalPrinterColumns = append(bksAdditionalPrinterColumns, bksScheduleColumn, bksMaxBackups, bksLastBackup, bksLastBackupTime, ageColumn)
 	tidbInitializerPrinterColumns = append(tidbInitializerPrinterColumns, tidbInitializerPhase, ageColumn)
@@ -353,6 +366,70 @@ func NewCustomResourceDefinition(crdKind v1alpha1.CrdKind, group string, labels
 	return crd
 }
 
+// IsServedInV1beta1 returns whether the kind is served in v1beta1 besides v1alpha1
+func IsServedInV1beta1(crdKind v1alpha1.CrdKind) bool {
+	switch crdKind.Kind {
+	case v1alpha1.DefaultCrdKinds.TiDBCluster.Kind, v1alpha1.DefaultCrdKinds.TidbClusterAutoScaler.Kind:
+		return true
+	}
+	return false
+}
+
+// AddConversionForCRD serves v1beta1 besides v1alpha1, the objects are stored in v1alpha1 and converted
+// by the conversion webhook at clientConfig. The webhook conversion requires a structural schema without
+// `preserveUnknownFields`, unknown fields are still preserved everywhere so that nothing stored is pruned.
+// It is opt-in, the conversion webhook adds it to the CustomResourceDefinitions on startup if it's enabled.
+func AddConversionForCRD(crd *extensionsobj.CustomResourceDefinition, clientConfig *extensionsobj.WebhookClientConfig) {
+	crd.Spec.Versions = []extensionsobj.CustomResourceDefinitionVersion{
+		{Name: v1alpha1.SchemeGroupVersion.Version, Served: true, Storage: true},
+		{Name: v1beta1.SchemeGroupVersion.Version, Served: true, Storage: false},
+	}
+	preserveUnknownFields := false
+	crd.Spec.PreserveUnknownFields = &preserveUnknownFields
+	if crd.Spec.Validation != nil && crd.Spec.Validation.OpenAPIV3Schema != nil {
+		preserveUnknownFieldsInSchema(crd.Spec.Validation.OpenAPIV3Schema)
+	}
+	crd.Spec.Conversion = &extensionsobj.CustomResourceConversion{
+		Strategy:                 extensionsobj.WebhookConverter,
+		WebhookClientConfig:      clientConfig,
+		ConversionReviewVersions: []string{"v1", "v1beta1"},
+	}
+}
+
+// preserveUnknownFieldsInSchema makes the schema structural by setting `x-kubernetes-preserve-unknown-fields`
+// on all the objects and the nodes without a type, and `x-kubernetes-int-or-string` on the IntOrString nodes.
+func preserveUnknownFieldsInSchema(schema *extensionsobj.JSONSchemaProps) {
+	switch {
+	case schema.XIntOrString:
+		// the IntOrString node is made structural already
+	case schema.Type == "" && isIntOrStringSchema(schema):
+		schema.XIntOrString = true
+		schema.AnyOf = nil
+	case schema.Type == "" || schema.Type == "object":
+		preserve := true
+		schema.XPreserveUnknownFields = &preserve
+	}
+	for name, prop := range schema.Properties {
+		preserveUnknownFieldsInSchema(&prop)
+		schema.Properties[name] = prop
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		preserveUnknownFieldsInSchema(schema.Items.Schema)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		preserveUnknownFieldsInSchema(schema.AdditionalProperties.Schema)
+	}
+}
+
+// isIntOrStringSchema returns whether the schema is generated for an IntOrString
+func isIntOrStringSchema(schema *extensionsobj.JSONSchemaProps) bool {
+	if len(schema.AnyOf) != 2 {
+		return false
+	}
+	types := map[string]bool{schema.AnyOf[0].Type: true, schema.AnyOf[1].Type: true}
+	return types["string"] && types["integer"]
+}
+
 func GetCrdKindFromKindName(kindName string) (v1alpha1.CrdKind, error) {
 	switch strings.ToLower(kindName) {
 	case v1alpha1.TiDBClusterKindKey:
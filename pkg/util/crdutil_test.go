@@ -18,6 +18,11 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/install"
+	extensionsobj "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/validation"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestNewCustomResourceDefinition(t *testing.T) {
@@ -37,6 +42,42 @@ func TestNewCustomResourceDefinition(t *testing.T) {
 	g.Expect(crd.Spec.AdditionalPrinterColumns).Should(Equal(tidbClusteradditionalPrinterColumns))
 }
 
+func TestAddConversionForCRD(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	port := int32(6444)
+	clientConfig := &extensionsobj.WebhookClientConfig{
+		Service: &extensionsobj.ServiceReference{Namespace: "tidb-admin", Name: "tidb-admission-webhook", Port: &port},
+	}
+	for _, kind := range []v1alpha1.CrdKind{v1alpha1.DefaultCrdKinds.TiDBCluster, v1alpha1.DefaultCrdKinds.TidbClusterAutoScaler} {
+		g.Expect(IsServedInV1beta1(kind)).To(BeTrue())
+		// v1beta1 is not served unless the conversion is enabled
+		crd := NewCustomResourceDefinition(kind, "pingcap.com", nil, true)
+		g.Expect(crd.Spec.Versions).To(BeEmpty())
+		g.Expect(crd.Spec.PreserveUnknownFields).To(BeNil())
+		g.Expect(crd.Spec.Conversion).To(BeNil())
+
+		AddConversionForCRD(crd, clientConfig)
+		g.Expect(crd.Spec.Versions).To(HaveLen(2))
+		g.Expect(crd.Spec.Conversion.Strategy).To(Equal(extensionsobj.WebhookConverter))
+		g.Expect(crd.Spec.Conversion.WebhookClientConfig).To(Equal(clientConfig))
+
+		// adding the conversion again changes nothing
+		again := crd.DeepCopy()
+		AddConversionForCRD(again, clientConfig)
+		g.Expect(again).To(Equal(crd))
+
+		// the CRD with webhook conversion must be accepted by the apiserver
+		scheme.Default(crd)
+		internal := &apiextensions.CustomResourceDefinition{}
+		g.Expect(scheme.Convert(crd, internal, nil)).To(Succeed())
+		g.Expect(validation.ValidateCustomResourceDefinition(internal, extensionsobj.SchemeGroupVersion)).To(BeEmpty())
+	}
+	g.Expect(IsServedInV1beta1(v1alpha1.DefaultCrdKinds.Backup)).To(BeFalse())
+}
+
 func TestGetCrdKindFromKindName(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -47,6 +47,8 @@ var (
 	BRBinPath              = "/var/lib/br-bin"
 	DumplingBinPath        = "/var/lib/dumpling-bin"
 	LightningBinPath       = "/var/lib/lightning-bin"
+	ReparoBinPath          = "/var/lib/reparo-bin"
+	PitrLogPath            = "/var/lib/pitr-log"
 	ClusterClientVolName   = "cluster-client-tls"
 	DMClusterClientVolName = "dm-cluster-client-tls"
 )
@@ -154,6 +156,12 @@ func IsSubMapOf(first map[string]string, second map[string]string) bool {
 	return true
 }
 
+// PDMemberPodName returns the Pod name of the PD member, the member name
+// may be the Pod name or the FQDN of the Pod
+func PDMemberPodName(memberName string) string {
+	return strings.Split(memberName, ".")[0]
+}
+
 func GetPodName(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, ordinal int32) string {
 	return fmt.Sprintf("%s-%s-%d", tc.Name, memberType.String(), ordinal)
 }
@@ -47,6 +47,13 @@ func TestGetOrdinalFromPodName(t *testing.T) {
 	g.Expect(i).To(Equal(int32(0)))
 }
 
+func TestPDMemberPodName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(PDMemberPodName("demo-pd-0")).To(Equal("demo-pd-0"))
+	g.Expect(PDMemberPodName("demo-pd-0.demo-pd-peer.default.svc.cluster.local")).To(Equal("demo-pd-0"))
+}
+
 func TestGetDeleteSlotsNumber(t *testing.T) {
 	g := NewGomegaWithT(t)
 	annotations := map[string]string{
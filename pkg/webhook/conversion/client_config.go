@@ -0,0 +1,79 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+)
+
+// EnableConversion serves pingcap.com/v1beta1 in the CustomResourceDefinitions of the kinds converted by
+// this webhook and points their conversion to the Service serving this webhook with the CA to verify it
+// with. manifests/crd.yaml serves v1alpha1 only, so that v1beta1 is opt-in with the conversion webhook.
+func EnableConversion(cli apiextensionsclientset.Interface, namespace, service string, port int32, caBundle []byte) error {
+	crds, err := cli.ApiextensionsV1beta1().CustomResourceDefinitions().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list CustomResourceDefinitions failed, err: %v", err)
+	}
+	path := Path
+	clientConfig := &apiextensionsv1beta1.WebhookClientConfig{
+		Service: &apiextensionsv1beta1.ServiceReference{
+			Namespace: namespace,
+			Name:      service,
+			Path:      &path,
+			Port:      &port,
+		},
+		CABundle: caBundle,
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		if crd.Spec.Group != v1alpha1.SchemeGroupVersion.Group ||
+			!util.IsServedInV1beta1(v1alpha1.CrdKind{Kind: crd.Spec.Names.Kind}) {
+			continue
+		}
+		if err := enableCRDConversion(cli, crd.Name, clientConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func enableCRDConversion(cli apiextensionsclientset.Interface, name string, clientConfig *apiextensionsv1beta1.WebhookClientConfig) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := cli.ApiextensionsV1beta1().CustomResourceDefinitions().Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		newCRD := crd.DeepCopy()
+		util.AddConversionForCRD(newCRD, clientConfig.DeepCopy())
+		if apiequality.Semantic.DeepEqual(crd.Spec, newCRD.Spec) {
+			return nil
+		}
+		if _, err := cli.ApiextensionsV1beta1().CustomResourceDefinitions().Update(context.TODO(), newCRD, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		svc := clientConfig.Service
+		klog.Infof("CustomResourceDefinition %s serves v1beta1 converted by the webhook at Service %s/%s:%d", name, svc.Namespace, svc.Name, *svc.Port)
+		return nil
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEnableConversion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newCRD := func(kind v1alpha1.CrdKind, group string) *apiextensionsv1beta1.CustomResourceDefinition {
+		crd := util.NewCustomResourceDefinition(kind, group, nil, true)
+		crd.Name = kind.Plural + "." + group
+		return crd
+	}
+	other := newCRD(v1alpha1.DefaultCrdKinds.TiDBCluster, "example.com")
+	cli := apiextensionsfake.NewSimpleClientset(
+		newCRD(v1alpha1.DefaultCrdKinds.TiDBCluster, "pingcap.com"),
+		newCRD(v1alpha1.DefaultCrdKinds.TidbClusterAutoScaler, "pingcap.com"),
+		newCRD(v1alpha1.DefaultCrdKinds.Backup, "pingcap.com"),
+		other,
+	)
+
+	g.Expect(EnableConversion(cli, "tidb", "tidb-admission-webhook", 6444, []byte("ca"))).To(Succeed())
+
+	for _, name := range []string{"tidbclusters.pingcap.com", "tidbclusterautoscalers.pingcap.com"} {
+		crd, err := cli.ApiextensionsV1beta1().CustomResourceDefinitions().Get(context.TODO(), name, metav1.GetOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(crd.Spec.Versions).To(HaveLen(2))
+		g.Expect(*crd.Spec.PreserveUnknownFields).To(BeFalse())
+		g.Expect(crd.Spec.Conversion.Strategy).To(Equal(apiextensionsv1beta1.WebhookConverter))
+		clientConfig := crd.Spec.Conversion.WebhookClientConfig
+		g.Expect(clientConfig.CABundle).To(Equal([]byte("ca")))
+		g.Expect(clientConfig.Service.Namespace).To(Equal("tidb"))
+		g.Expect(clientConfig.Service.Name).To(Equal("tidb-admission-webhook"))
+		g.Expect(*clientConfig.Service.Port).To(BeEquivalentTo(6444))
+		g.Expect(*clientConfig.Service.Path).To(Equal(Path))
+	}
+
+	crd, err := cli.ApiextensionsV1beta1().CustomResourceDefinitions().Get(context.TODO(), "backups.pingcap.com", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(crd.Spec.Versions).To(BeEmpty())
+	g.Expect(crd.Spec.Conversion).To(BeNil())
+	crd, err = cli.ApiextensionsV1beta1().CustomResourceDefinitions().Get(context.TODO(), other.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(crd.Spec).To(Equal(other.Spec))
+
+	// nothing is updated if the conversion is enabled already
+	cli.ClearActions()
+	g.Expect(EnableConversion(cli, "tidb", "tidb-admission-webhook", 6444, []byte("ca"))).To(Succeed())
+	for _, action := range cli.Actions() {
+		g.Expect(action.GetVerb()).NotTo(Equal("update"))
+	}
+
+	// the client config is updated if the CA changes
+	g.Expect(EnableConversion(cli, "tidb", "tidb-admission-webhook", 6444, []byte("new-ca"))).To(Succeed())
+	crd, err = cli.ApiextensionsV1beta1().CustomResourceDefinitions().Get(context.TODO(), "tidbclusters.pingcap.com", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(crd.Spec.Conversion.WebhookClientConfig.CABundle).To(Equal([]byte("new-ca")))
+}
@@ -0,0 +1,154 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1beta1"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog"
+)
+
+const (
+	// Path is the path of the conversion webhook
+	Path = util.ConversionWebhookPath
+)
+
+// scheme holds the versions of pingcap.com and the conversions between them
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+}
+
+// ConversionHandler is the CustomResourceDefinition conversion webhook converting the resources
+// between the versions of pingcap.com. It accepts ConversionReview of both apiextensions.k8s.io/v1
+// and apiextensions.k8s.io/v1beta1, which are identical except the apiVersion.
+type ConversionHandler struct{}
+
+var _ http.Handler = &ConversionHandler{}
+
+func NewConversionHandler() *ConversionHandler {
+	return &ConversionHandler{}
+}
+
+// ListenAndServeTLS serves the conversion webhook on the given port with the serving certificate
+func ListenAndServeTLS(port int, certFile, keyFile string) error {
+	mux := http.NewServeMux()
+	mux.Handle(Path, NewConversionHandler())
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: mux,
+	}
+	klog.Infof("serving the conversion webhook on port %d", port)
+	return server.ListenAndServeTLS(certFile, keyFile)
+}
+
+func (h *ConversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("read request body failed, err: %v", err), http.StatusBadRequest)
+		return
+	}
+	review := &apiextensionsv1.ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		klog.Errorf("conversion webhook: cannot unmarshal %s to ConversionReview, err: %v", string(body), err)
+		http.Error(w, "invalid ConversionReview", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.Convert(review.Request)
+	review.Request = nil
+	data, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("marshal ConversionReview failed, err: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		klog.Errorf("conversion webhook: write response failed, err: %v", err)
+	}
+}
+
+// Convert converts all the objects in the request to the desired version, the conversion fails
+// if any of the objects can't be converted.
+func (h *ConversionHandler) Convert(req *apiextensionsv1.ConversionRequest) *apiextensionsv1.ConversionResponse {
+	resp := &apiextensionsv1.ConversionResponse{
+		UID: req.UID,
+	}
+	for _, obj := range req.Objects {
+		converted, err := convertObject(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			klog.Errorf("conversion webhook: convert object to %s failed, err: %v", req.DesiredAPIVersion, err)
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			}
+			return resp
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	resp.Result = metav1.Status{
+		Status: metav1.StatusSuccess,
+	}
+	return resp
+}
+
+func convertObject(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, typeMeta); err != nil {
+		return nil, err
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+	gvk := typeMeta.GroupVersionKind()
+	desiredGV, err := schema.ParseGroupVersion(desiredAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	desiredGVK := desiredGV.WithKind(gvk.Kind)
+	if !scheme.Recognizes(gvk) || !scheme.Recognizes(desiredGVK) {
+		return nil, fmt.Errorf("conversion of %s from %s to %s is not supported", gvk.Kind, typeMeta.APIVersion, desiredAPIVersion)
+	}
+
+	in, err := scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, in); err != nil {
+		return nil, err
+	}
+	out, err := scheme.New(desiredGVK)
+	if err != nil {
+		return nil, err
+	}
+	if err := scheme.Convert(in, out, nil); err != nil {
+		return nil, err
+	}
+	out.GetObjectKind().SetGroupVersionKind(desiredGVK)
+	return json.Marshal(out)
+}
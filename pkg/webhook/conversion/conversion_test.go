@@ -0,0 +1,127 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestConversionHandler(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "TidbCluster",
+			APIVersion: "pingcap.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "demo",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			Version:  "v5.0.1",
+			Services: []v1alpha1.Service{{Name: "tidb", Type: "NodePort"}},
+		},
+	}
+	raw, err := json.Marshal(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	review := func(desiredAPIVersion string, objects ...[]byte) *apiextensionsv1.ConversionReview {
+		req := &apiextensionsv1.ConversionReview{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConversionReview",
+				APIVersion: "apiextensions.k8s.io/v1beta1",
+			},
+			Request: &apiextensionsv1.ConversionRequest{
+				UID:               "uid",
+				DesiredAPIVersion: desiredAPIVersion,
+			},
+		}
+		for _, obj := range objects {
+			req.Request.Objects = append(req.Request.Objects, runtime.RawExtension{Raw: obj})
+		}
+		body, err := json.Marshal(req)
+		g.Expect(err).NotTo(HaveOccurred())
+		w := httptest.NewRecorder()
+		NewConversionHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body)))
+		g.Expect(w.Code).To(Equal(http.StatusOK))
+		resp := &apiextensionsv1.ConversionReview{}
+		g.Expect(json.Unmarshal(w.Body.Bytes(), resp)).To(Succeed())
+		g.Expect(resp.APIVersion).To(Equal("apiextensions.k8s.io/v1beta1"))
+		g.Expect(resp.Response.UID).To(BeEquivalentTo("uid"))
+		return resp
+	}
+
+	// v1alpha1 -> v1beta1
+	resp := review("pingcap.com/v1beta1", raw)
+	g.Expect(resp.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+	g.Expect(resp.Response.ConvertedObjects).To(HaveLen(1))
+	beta := &v1beta1.TidbCluster{}
+	g.Expect(json.Unmarshal(resp.Response.ConvertedObjects[0].Raw, beta)).To(Succeed())
+	g.Expect(beta.APIVersion).To(Equal("pingcap.com/v1beta1"))
+	g.Expect(beta.Spec.Version).To(Equal("v5.0.1"))
+	g.Expect(beta.Annotations).To(HaveKey(v1beta1.AnnV1alpha1Spec))
+
+	// v1beta1 -> v1alpha1
+	resp = review("pingcap.com/v1alpha1", resp.Response.ConvertedObjects[0].Raw)
+	g.Expect(resp.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+	alpha := &v1alpha1.TidbCluster{}
+	g.Expect(json.Unmarshal(resp.Response.ConvertedObjects[0].Raw, alpha)).To(Succeed())
+	g.Expect(alpha).To(Equal(tc))
+
+	// same version
+	resp = review("pingcap.com/v1alpha1", raw)
+	g.Expect(resp.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+	g.Expect(resp.Response.ConvertedObjects[0].Raw).To(MatchJSON(raw))
+
+	// TidbClusterAutoScaler
+	tac, err := json.Marshal(&v1alpha1.TidbClusterAutoScaler{
+		TypeMeta:   metav1.TypeMeta{Kind: "TidbClusterAutoScaler", APIVersion: "pingcap.com/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "auto", Namespace: "default"},
+		Spec:       v1alpha1.TidbClusterAutoScalerSpec{Cluster: v1alpha1.TidbClusterRef{Name: "demo"}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	resp = review("pingcap.com/v1beta1", tac)
+	g.Expect(resp.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+	betaTac := &v1beta1.TidbClusterAutoScaler{}
+	g.Expect(json.Unmarshal(resp.Response.ConvertedObjects[0].Raw, betaTac)).To(Succeed())
+	g.Expect(betaTac.APIVersion).To(Equal("pingcap.com/v1beta1"))
+	g.Expect(betaTac.Spec.Cluster.Name).To(Equal("demo"))
+
+	// unsupported kind
+	backup, err := json.Marshal(&v1alpha1.Backup{TypeMeta: metav1.TypeMeta{Kind: "Backup", APIVersion: "pingcap.com/v1alpha1"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	resp = review("pingcap.com/v1beta1", raw, backup)
+	g.Expect(resp.Response.Result.Status).To(Equal(metav1.StatusFailure))
+	g.Expect(resp.Response.ConvertedObjects).To(BeEmpty())
+}
+
+func TestConversionHandlerInvalidRequest(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	w := httptest.NewRecorder()
+	NewConversionHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader([]byte("{}"))))
+	g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+}
@@ -0,0 +1,230 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/openshift/generic-admission-server/pkg/apiserver"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/webhook/util"
+	admission "k8s.io/api/admission/v1beta1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	eventv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog"
+)
+
+const (
+	deletionDeniedReason = "DeletionDenied"
+)
+
+// DeletionProtectionAdmissionControl denies the deletion of the TidbClusters protected by the
+// annotation `tidb.pingcap.com/deletion-protection: "true"`, and the deletion of the statefulsets
+// and pvcs belonging to them.
+type DeletionProtectionAdmissionControl struct {
+	lock        sync.RWMutex
+	initialized bool
+	// operator client interface
+	operatorCli versioned.Interface
+	// dynamic client to get the object to be deleted if it is not sent with the request
+	dynamicCli dynamic.Interface
+	// the service accounts which are allowed to delete the statefulsets and pvcs of the protected TidbClusters,
+	// e.g. the tidb-controller-manager deletes the pvcs when scaling in
+	serviceAccounts sets.String
+	// recorder to send event
+	recorder record.EventRecorder
+}
+
+var _ apiserver.ValidatingAdmissionHook = &DeletionProtectionAdmissionControl{}
+
+func NewDeletionProtectionAdmissionControl(serviceAccounts []string) *DeletionProtectionAdmissionControl {
+	return &DeletionProtectionAdmissionControl{
+		serviceAccounts: sets.NewString(serviceAccounts...),
+	}
+}
+
+func (dc *DeletionProtectionAdmissionControl) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	return schema.GroupVersionResource{
+			Group:    "admission.tidb.pingcap.com",
+			Version:  "v1alpha1",
+			Resource: "deletionvalidations",
+		},
+		"deletionvalidation"
+}
+
+func (dc *DeletionProtectionAdmissionControl) Validate(ar *admission.AdmissionRequest) (resp *admission.AdmissionResponse) {
+	defer func() { metrics.ObserveAdmission("deletion-protection", ar, resp) }()
+	dc.lock.RLock()
+	defer dc.lock.RUnlock()
+	if !dc.initialized {
+		return &admission.AdmissionResponse{
+			Allowed: false,
+		}
+	}
+
+	if ar.Operation != admission.Delete {
+		return util.ARSuccess()
+	}
+
+	name := ar.Name
+	namespace := ar.Namespace
+	kind := ar.Kind.Kind
+
+	meta, err := dc.getObjectMeta(ar)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return util.ARSuccess()
+		}
+		err = fmt.Errorf("%s %s/%s, get object failed, err: %v", kind, namespace, name, err)
+		klog.Error(err)
+		return util.ARFail(err)
+	}
+
+	if kind == controller.ControllerKind.Kind {
+		tc := &v1alpha1.TidbCluster{ObjectMeta: *meta}
+		if !tc.IsDeletionProtected() {
+			return util.ARSuccess()
+		}
+		return dc.deny(tc, kind, name, ar.UserInfo.Username)
+	}
+
+	tcName := getOwnerTidbClusterName(kind, meta)
+	if tcName == "" {
+		return util.ARSuccess()
+	}
+	if dc.serviceAccounts.Has(ar.UserInfo.Username) {
+		klog.Infof("%s %s/%s of tidbcluster %s is deleted by %s", kind, namespace, name, tcName, ar.UserInfo.Username)
+		return util.ARSuccess()
+	}
+
+	tc, err := dc.operatorCli.PingcapV1alpha1().TidbClusters(namespace).Get(context.TODO(), tcName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return util.ARSuccess()
+		}
+		err = fmt.Errorf("get tidbcluster %s/%s failed, %s %s, err: %v", namespace, tcName, kind, name, err)
+		klog.Error(err)
+		return util.ARFail(err)
+	}
+	// the dependents are garbage collected after the deletion of the TidbCluster is allowed
+	if !tc.IsDeletionProtected() || tc.DeletionTimestamp != nil {
+		return util.ARSuccess()
+	}
+	return dc.deny(tc, kind, name, ar.UserInfo.Username)
+}
+
+func (dc *DeletionProtectionAdmissionControl) deny(tc *v1alpha1.TidbCluster, kind, name, username string) *admission.AdmissionResponse {
+	msg := fmt.Sprintf("deletion of %s %s/%s by %q is denied, tidbcluster %s/%s is protected by annotation %s, remove it before the deletion",
+		kind, tc.Namespace, name, username, tc.Namespace, tc.Name, label.AnnDeletionProtection)
+	klog.Info(msg)
+	dc.recorder.Event(tc, core.EventTypeWarning, deletionDeniedReason, msg)
+	return util.ARFail(fmt.Errorf("%s", msg))
+}
+
+// getObjectMeta returns the metadata of the object to be deleted, it is sent as the old object since
+// Kubernetes v1.15, and it is got from the api server for the earlier versions.
+func (dc *DeletionProtectionAdmissionControl) getObjectMeta(ar *admission.AdmissionRequest) (*metav1.ObjectMeta, error) {
+	if len(ar.OldObject.Raw) > 0 {
+		obj := struct {
+			metav1.ObjectMeta `json:"metadata"`
+		}{}
+		if err := json.Unmarshal(ar.OldObject.Raw, &obj); err != nil {
+			return nil, err
+		}
+		return &obj.ObjectMeta, nil
+	}
+	gvr := schema.GroupVersionResource{Group: ar.Resource.Group, Version: ar.Resource.Version, Resource: ar.Resource.Resource}
+	obj, err := dc.dynamicCli.Resource(gvr).Namespace(ar.Namespace).Get(context.TODO(), ar.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.ObjectMeta{
+		Name:            obj.GetName(),
+		Namespace:       obj.GetNamespace(),
+		Labels:          obj.GetLabels(),
+		Annotations:     obj.GetAnnotations(),
+		OwnerReferences: obj.GetOwnerReferences(),
+	}, nil
+}
+
+// getOwnerTidbClusterName returns the name of the TidbCluster which the statefulset or pvc belongs to,
+// empty string is returned if it is not managed by a TidbCluster.
+func getOwnerTidbClusterName(kind string, meta *metav1.ObjectMeta) string {
+	switch kind {
+	case "StatefulSet":
+		ref := metav1.GetControllerOf(meta)
+		if ref == nil || ref.Kind != controller.ControllerKind.Kind {
+			return ""
+		}
+		return ref.Name
+	case "PersistentVolumeClaim":
+		l := label.Label(meta.Labels)
+		if !l.IsManagedByTiDBOperator() || !l.IsTidbClusterPod() || l.IsMonitor() {
+			return ""
+		}
+		return l[label.InstanceLabelKey]
+	}
+	return ""
+}
+
+func (dc *DeletionProtectionAdmissionControl) initialize(cli versioned.Interface, dynamicCli dynamic.Interface, recorder record.EventRecorder) error {
+	dc.operatorCli = cli
+	dc.dynamicCli = dynamicCli
+	dc.recorder = recorder
+	dc.initialized = true
+	return nil
+}
+
+// Initialize implements AdmissionHook.Initialize interface. It's is called as
+// a post-start hook.
+func (dc *DeletionProtectionAdmissionControl) Initialize(cfg *rest.Config, stopCh <-chan struct{}) error {
+	dc.lock.Lock()
+	defer dc.lock.Unlock()
+
+	cli, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	kubeCli, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	dynamicCli, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(klog.Infof)
+	eventBroadcaster.StartRecordingToSink(&eventv1.EventSinkImpl{
+		Interface: eventv1.New(kubeCli.CoreV1().RESTClient()).Events("")})
+	recorder := eventBroadcaster.NewRecorder(v1alpha1.Scheme, core.EventSource{Component: "tidb-admission-controller"})
+
+	return dc.initialize(cli, dynamicCli, recorder)
+}
@@ -0,0 +1,217 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletion
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	admission "k8s.io/api/admission/v1beta1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	namespace         = "default"
+	tcName            = "demo"
+	operatorSA        = "system:serviceaccount:tidb-admin:tidb-controller-manager"
+	userName          = "kubernetes-admin"
+	protectedAnnValue = "true"
+)
+
+func TestDeletionProtectionAdmissionControl(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name         string
+		protected    bool
+		tcDeleting   bool
+		operation    admission.Operation
+		username     string
+		kind         string
+		objFn        func(tc *v1alpha1.TidbCluster) runtime.Object
+		wantAllowed  bool
+		expectEvents int
+	}{
+		{
+			name:        "delete unprotected tidbcluster",
+			kind:        "TidbCluster",
+			objFn:       func(tc *v1alpha1.TidbCluster) runtime.Object { return tc },
+			wantAllowed: true,
+		},
+		{
+			name:         "delete protected tidbcluster",
+			protected:    true,
+			kind:         "TidbCluster",
+			objFn:        func(tc *v1alpha1.TidbCluster) runtime.Object { return tc },
+			wantAllowed:  false,
+			expectEvents: 1,
+		},
+		{
+			name:        "update protected tidbcluster",
+			protected:   true,
+			operation:   admission.Update,
+			kind:        "TidbCluster",
+			objFn:       func(tc *v1alpha1.TidbCluster) runtime.Object { return tc },
+			wantAllowed: true,
+		},
+		{
+			name:         "delete statefulset of protected tidbcluster",
+			protected:    true,
+			kind:         "StatefulSet",
+			objFn:        newStatefulSet,
+			wantAllowed:  false,
+			expectEvents: 1,
+		},
+		{
+			name:        "delete statefulset of unprotected tidbcluster",
+			kind:        "StatefulSet",
+			objFn:       newStatefulSet,
+			wantAllowed: true,
+		},
+		{
+			name:        "delete statefulset of protected tidbcluster being deleted",
+			protected:   true,
+			tcDeleting:  true,
+			kind:        "StatefulSet",
+			objFn:       newStatefulSet,
+			wantAllowed: true,
+		},
+		{
+			name:      "delete statefulset not controlled by tidbcluster",
+			protected: true,
+			kind:      "StatefulSet",
+			objFn: func(tc *v1alpha1.TidbCluster) runtime.Object {
+				set := newStatefulSet(tc).(*apps.StatefulSet)
+				set.OwnerReferences = nil
+				return set
+			},
+			wantAllowed: true,
+		},
+		{
+			name:         "delete pvc of protected tidbcluster",
+			protected:    true,
+			kind:         "PersistentVolumeClaim",
+			objFn:        newPVC,
+			wantAllowed:  false,
+			expectEvents: 1,
+		},
+		{
+			name:        "delete pvc of protected tidbcluster by tidb-controller-manager",
+			protected:   true,
+			username:    operatorSA,
+			kind:        "PersistentVolumeClaim",
+			objFn:       newPVC,
+			wantAllowed: true,
+		},
+		{
+			name:      "delete pvc not managed by tidb-operator",
+			protected: true,
+			kind:      "PersistentVolumeClaim",
+			objFn: func(tc *v1alpha1.TidbCluster) runtime.Object {
+				pvc := newPVC(tc).(*core.PersistentVolumeClaim)
+				pvc.Labels = nil
+				return pvc
+			},
+			wantAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbCluster(tt.protected)
+			if tt.tcDeleting {
+				now := metav1.Now()
+				tc.DeletionTimestamp = &now
+			}
+			cli := fake.NewSimpleClientset(tc)
+			recorder := record.NewFakeRecorder(10)
+			dc := NewDeletionProtectionAdmissionControl([]string{operatorSA})
+			g.Expect(dc.initialize(cli, nil, recorder)).To(Succeed())
+
+			raw, err := json.Marshal(tt.objFn(tc))
+			g.Expect(err).NotTo(HaveOccurred())
+			operation := tt.operation
+			if operation == "" {
+				operation = admission.Delete
+			}
+			username := tt.username
+			if username == "" {
+				username = userName
+			}
+			ar := &admission.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Kind: tt.kind},
+				Name:      "obj",
+				Namespace: namespace,
+				Operation: operation,
+				OldObject: runtime.RawExtension{Raw: raw},
+			}
+			ar.UserInfo.Username = username
+
+			resp := dc.Validate(ar)
+			g.Expect(resp.Allowed).To(Equal(tt.wantAllowed))
+			g.Expect(recorder.Events).To(HaveLen(tt.expectEvents))
+			if tt.expectEvents > 0 {
+				g.Expect(<-recorder.Events).To(ContainSubstring(deletionDeniedReason))
+			}
+		})
+	}
+}
+
+func newTidbCluster(protected bool) *v1alpha1.TidbCluster {
+	tc := &v1alpha1.TidbCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "TidbCluster",
+			APIVersion: "pingcap.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tcName,
+			Namespace: namespace,
+			UID:       "uid",
+		},
+	}
+	if protected {
+		tc.Annotations = map[string]string{label.AnnDeletionProtection: protectedAnnValue}
+	}
+	return tc
+}
+
+func newStatefulSet(tc *v1alpha1.TidbCluster) runtime.Object {
+	return &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.PDMemberName(tc.Name),
+			Namespace:       namespace,
+			Labels:          label.New().Instance(tc.Name).PD(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+	}
+}
+
+func newPVC(tc *v1alpha1.TidbCluster) runtime.Object {
+	return &core.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pd-demo-pd-0",
+			Namespace: namespace,
+			Labels:    label.New().Instance(tc.Name).PD(),
+		},
+	}
+}
@@ -30,6 +30,7 @@ import (
 	v1alpha1listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/features"
 	memberUtils "github.com/pingcap/tidb-operator/pkg/manager/member"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	"github.com/pingcap/tidb-operator/pkg/webhook/util"
 	admission "k8s.io/api/admission/v1beta1"
@@ -128,7 +129,8 @@ type admitPayload struct {
 	controllerDesc controllerDesc
 }
 
-func (pc *PodAdmissionControl) Admit(ar *admission.AdmissionRequest) *admission.AdmissionResponse {
+func (pc *PodAdmissionControl) Admit(ar *admission.AdmissionRequest) (resp *admission.AdmissionResponse) {
+	defer func() { metrics.ObserveAdmission("pod-mutation", ar, resp) }()
 	pc.lock.RLock()
 	defer pc.lock.RUnlock()
 	if !pc.initialized {
@@ -143,7 +145,8 @@ func (pc *PodAdmissionControl) Admit(ar *admission.AdmissionRequest) *admission.
 	return pc.mutatePod(ar)
 }
 
-func (pc *PodAdmissionControl) Validate(ar *admission.AdmissionRequest) *admission.AdmissionResponse {
+func (pc *PodAdmissionControl) Validate(ar *admission.AdmissionRequest) (resp *admission.AdmissionResponse) {
+	defer func() { metrics.ObserveAdmission("pod-validation", ar, resp) }()
 	pc.lock.RLock()
 	defer pc.lock.RUnlock()
 	if !pc.initialized {
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/features"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/webhook/util"
 	admission "k8s.io/api/admission/v1beta1"
 	apps "k8s.io/api/apps/v1"
@@ -61,7 +62,8 @@ func (sc *StatefulSetAdmissionControl) ValidatingResource() (plural schema.Group
 		"statefulsetvalidation"
 }
 
-func (sc *StatefulSetAdmissionControl) Validate(ar *admission.AdmissionRequest) *admission.AdmissionResponse {
+func (sc *StatefulSetAdmissionControl) Validate(ar *admission.AdmissionRequest) (resp *admission.AdmissionResponse) {
+	defer func() { metrics.ObserveAdmission("statefulset-validation", ar, resp) }()
 	sc.lock.RLock()
 	defer sc.lock.RUnlock()
 	if !sc.initialized {
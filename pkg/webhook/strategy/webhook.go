@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 
 	"github.com/openshift/generic-admission-server/pkg/apiserver"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/registry"
 	"github.com/pingcap/tidb-operator/pkg/webhook/util"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -56,7 +58,8 @@ func (w *StrategyAdmissionHook) MutatingResource() (plural schema.GroupVersionRe
 		"pingcapresourcemutation"
 }
 
-func (w *StrategyAdmissionHook) Validate(ar *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+func (w *StrategyAdmissionHook) Validate(ar *admissionv1beta1.AdmissionRequest) (resp *admissionv1beta1.AdmissionResponse) {
+	defer func() { metrics.ObserveAdmission("strategy-validation", ar, resp) }()
 	s, ok := w.registry.Get(ar.Kind)
 	if !ok {
 		// no strategy registered
@@ -71,8 +74,13 @@ func (w *StrategyAdmissionHook) Validate(ar *admissionv1beta1.AdmissionRequest)
 		return util.ARFail(err)
 	}
 	var allErr field.ErrorList
+	var warnings []string
+	ws, hasWarnings := s.(registry.WarningStrategy)
 	if ar.Operation == admissionv1beta1.Create {
 		allErr = s.Validate(context.TODO(), obj)
+		if hasWarnings {
+			warnings = ws.WarningsOnCreate(context.TODO(), obj)
+		}
 	} else {
 		old := s.NewObject()
 		if err := json.Unmarshal(ar.OldObject.Raw, old); err != nil {
@@ -80,14 +88,21 @@ func (w *StrategyAdmissionHook) Validate(ar *admissionv1beta1.AdmissionRequest)
 			return util.ARFail(err)
 		}
 		allErr = s.ValidateUpdate(context.TODO(), obj, old)
+		if hasWarnings {
+			warnings = ws.WarningsOnUpdate(context.TODO(), obj, old)
+		}
 	}
 	if len(allErr) > 0 {
-		return util.ARFail(allErr.ToAggregate())
+		resp = util.ARFail(allErr.ToAggregate())
+	} else {
+		resp = util.ARSuccess()
 	}
-	return util.ARSuccess()
+	resp.Warnings = warnings
+	return resp
 }
 
-func (w *StrategyAdmissionHook) Admit(ar *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+func (w *StrategyAdmissionHook) Admit(ar *admissionv1beta1.AdmissionRequest) (resp *admissionv1beta1.AdmissionResponse) {
+	defer func() { metrics.ObserveAdmission("strategy-mutation", ar, resp) }()
 	s, ok := w.registry.Get(ar.Kind)
 	if !ok {
 		return util.ARSuccess()
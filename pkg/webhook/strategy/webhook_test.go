@@ -220,6 +220,7 @@ type FakeStrategy struct {
 	prepareForUpdateTracker controller.RequestTracker
 	validateTracker         controller.RequestTracker
 	validateUpdateTracker   controller.RequestTracker
+	warnings                []string
 }
 
 func (s *FakeStrategy) NewObject() runtime.Object {
@@ -252,6 +253,49 @@ func (s *FakeStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Obje
 	return allErrs
 }
 
+func (s *FakeStrategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string {
+	return s.warnings
+}
+
+func (s *FakeStrategy) WarningsOnUpdate(ctx context.Context, obj, old runtime.Object) []string {
+	return s.warnings
+}
+
+func TestStrategyAdmissionHook_ValidateWarnings(t *testing.T) {
+	g := NewGomegaWithT(t)
+	for _, op := range []admissionv1beta1.Operation{admissionv1beta1.Create, admissionv1beta1.Update} {
+		for _, validateErr := range []error{nil, fmt.Errorf("invalid object")} {
+			r := NewRegistry()
+			s := &FakeStrategy{warnings: []string{"unknown config key"}}
+			r.Register(s)
+			w := NewStrategyAdmissionHook(&r)
+			tc := &v1alpha1.TidbCluster{}
+			gvk, err := controller.InferObjectKind(tc)
+			g.Expect(err).To(Succeed())
+			raw, err := json.Marshal(tc)
+			g.Expect(err).To(Succeed())
+			ar := admissionv1beta1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Kind:    gvk.Kind,
+					Group:   gvk.Group,
+					Version: gvk.Version,
+				},
+				Operation: op,
+				Object:    runtime.RawExtension{Raw: raw},
+				OldObject: runtime.RawExtension{Raw: raw},
+			}
+			if validateErr != nil {
+				s.validateTracker.SetError(validateErr)
+				s.validateUpdateTracker.SetError(validateErr)
+			}
+
+			resp := w.Validate(&ar)
+			g.Expect(resp.Allowed).To(Equal(validateErr == nil))
+			g.Expect(resp.Warnings).To(Equal([]string{"unknown config key"}))
+		}
+	}
+}
+
 func TestValidatingResource(t *testing.T) {
 	r := NewRegistry()
 	w := NewStrategyAdmissionHook(&r)